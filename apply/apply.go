@@ -0,0 +1,449 @@
+// Package apply reconciles the experiments and registered models of a tracking server with a
+// declarative spec, for GitOps administration of MLflow: the experiments and models of the
+// spec are created, or restored, and their tags, descriptions and user permissions are
+// updated to the declared ones.
+//
+//	experiments:
+//	  - name: fraud-detection
+//	    tags: {team: risk}
+//	    permissions: {alice: MANAGE, bob: READ}
+//	registered_models:
+//	  - name: fraud
+//	    description: Fraud classifier
+//	    permissions: {alice: MANAGE}
+//
+// The resources of the server not in the spec are left as they are.
+//
+//	spec, err := apply.Load("mlflow.yaml")
+//	a := apply.New(client, spec, &apply.Options{Prune: true})
+//	plan, err := a.Plan(ctx) // dry run
+//	plan.WriteDiff(os.Stdout)
+//	plan, err = a.Apply(ctx)
+package apply
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/codeocean/go-mlflow/internal/sorted"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Fields of the changes.
+const (
+	fieldDescription = "description"
+	fieldTags        = "tags"
+	fieldPermissions = "permissions"
+)
+
+// Options configures an applier.
+type Options struct {
+	// Prune deletes the tags of the resources which are not in the spec, except the mlflow.
+	// tags set by MLflow, for the resources declaring tags.
+	Prune bool
+}
+
+type Kind string
+
+const (
+	KindExperiment      Kind = "experiment"
+	KindRegisteredModel Kind = "registered_model"
+)
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	// ActionRestore restores a deleted experiment, and updates it.
+	ActionRestore Action = "restore"
+	ActionUpdate  Action = "update"
+	// ActionNone leaves a resource matching the spec as it is.
+	ActionNone Action = "none"
+)
+
+type Op string
+
+const (
+	OpAdd    Op = "add"
+	OpUpdate Op = "update"
+	OpDelete Op = "delete"
+)
+
+// Change is a change of a field of a resource.
+type Change struct {
+	Op Op `json:"op"`
+	// Field is the field changed: description, or a tag or permission such as tags.team or
+	// permissions.alice.
+	Field string `json:"field"`
+	// Old is the value before the update or deletion, New the value after the addition or
+	// update.
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+func (c *Change) String() string {
+	switch c.Op {
+	case OpAdd:
+		return fmt.Sprintf("+ %s = %q", c.Field, c.New)
+	case OpUpdate:
+		return fmt.Sprintf("~ %s: %q -> %q", c.Field, c.Old, c.New)
+	default:
+		return fmt.Sprintf("- %s = %q", c.Field, c.Old)
+	}
+}
+
+// Step is the reconciliation of a resource.
+type Step struct {
+	Kind   Kind   `json:"kind"`
+	Name   string `json:"name"`
+	Action Action `json:"action"`
+	// Changes are the changes of the fields of the resource, in order.
+	Changes []*Change `json:"changes,omitempty"`
+
+	// id is the ID of an existing experiment.
+	id         string
+	experiment *Experiment
+}
+
+func (s *Step) String() string {
+	return fmt.Sprintf("%s %s %s", s.Action, s.Kind, s.Name)
+}
+
+// Plan is the list of the steps of a reconciliation, one per resource of the spec.
+type Plan struct {
+	Steps []*Step `json:"steps"`
+}
+
+// Changed reports whether the plan changes any resource.
+func (p *Plan) Changed() bool {
+	for _, s := range p.Steps {
+		if s.Action != ActionNone {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteDiff writes the plan as a diff, a line per resource followed by its changes:
+//
+//   - experiment fraud-detection
+//   - tags.team = "risk"
+//     ~ registered_model fraud
+//     ~ description: "" -> "Fraud classifier"
+//     experiment demo
+func (p *Plan) WriteDiff(w io.Writer) error {
+	for _, s := range p.Steps {
+		symbol := " "
+		switch s.Action {
+		case ActionCreate:
+			symbol = "+"
+		case ActionRestore, ActionUpdate:
+			symbol = "~"
+		}
+		line := fmt.Sprintf("%s %s %s", symbol, s.Kind, s.Name)
+		if s.Action == ActionRestore {
+			line += " (restored)"
+		}
+		_, err := fmt.Fprintln(w, line)
+		if err != nil {
+			return err
+		}
+		for _, c := range s.Changes {
+			_, err = fmt.Fprintf(w, "    %s\n", c)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Applier reconciles a tracking server with a spec.
+type Applier struct {
+	client *mlflow.Client
+	spec   *Spec
+	opts   Options
+}
+
+// New returns an applier of the spec to the tracking server of client.
+func New(client *mlflow.Client, spec *Spec, opts *Options) *Applier {
+	a := &Applier{client: client, spec: spec}
+	if opts != nil {
+		a.opts = *opts
+	}
+	return a
+}
+
+// Plan returns the steps of the reconciliation without changing the server, as a dry run.
+func (a *Applier) Plan(ctx context.Context) (*Plan, error) {
+	p := &Plan{}
+
+	for _, e := range a.spec.Experiments {
+		step, err := a.planExperiment(ctx, e)
+		if err != nil {
+			return nil, fmt.Errorf("apply: experiment %q: %w", e.Name, err)
+		}
+		p.Steps = append(p.Steps, step)
+	}
+
+	for _, m := range a.spec.RegisteredModels {
+		step, err := a.planModel(ctx, m)
+		if err != nil {
+			return nil, fmt.Errorf("apply: registered model %q: %w", m.Name, err)
+		}
+		p.Steps = append(p.Steps, step)
+	}
+
+	return p, nil
+}
+
+func (a *Applier) planExperiment(ctx context.Context, e *Experiment) (*Step, error) {
+	step := &Step{Kind: KindExperiment, Name: e.Name, experiment: e}
+
+	existing, err := a.client.Experiments.GetByName(ctx, e.Name)
+	switch {
+	case mlflow.IsResourceDoesNotExist(err):
+		step.Action = ActionCreate
+		step.Changes = append(diffTags(nil, e.Tags, false), diffPermissions(nil, e.Permissions)...)
+		return step, nil
+	case err != nil:
+		return nil, err
+	}
+	step.id = existing.ExperimentID
+
+	tags := map[string]string{}
+	for _, t := range existing.Tags {
+		tags[t.Key] = t.Value
+	}
+	step.Changes = diffTags(tags, e.Tags, a.opts.Prune)
+
+	permissions, err := userPermissions(e.Permissions, func(username string) (mlflow.Permission, error) {
+		p, err := a.client.Experiments.GetPermission(ctx, existing.ExperimentID, username)
+		if err != nil {
+			return "", err
+		}
+		return p.Permission, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	step.Changes = append(step.Changes, diffPermissions(permissions, e.Permissions)...)
+
+	switch {
+	case existing.IsDeleted():
+		step.Action = ActionRestore
+	case len(step.Changes) > 0:
+		step.Action = ActionUpdate
+	default:
+		step.Action = ActionNone
+	}
+	return step, nil
+}
+
+// userPermissions returns the permissions the users of declared have on a resource, get
+// returning the permission of a user.
+func userPermissions(declared map[string]mlflow.Permission, get func(username string) (mlflow.Permission, error)) (map[string]mlflow.Permission, error) {
+	res := map[string]mlflow.Permission{}
+	for _, username := range sorted.Keys(declared) {
+		p, err := get(username)
+		switch {
+		case mlflow.IsResourceDoesNotExist(err):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		res[username] = p
+	}
+	return res, nil
+}
+
+func (a *Applier) planModel(ctx context.Context, m *Model) (*Step, error) {
+	step := &Step{Kind: KindRegisteredModel, Name: m.Name}
+
+	existing, err := a.client.RegisteredModels.Get(ctx, m.Name)
+	switch {
+	case mlflow.IsResourceDoesNotExist(err):
+		step.Action = ActionCreate
+		if m.Description != nil && *m.Description != "" {
+			step.Changes = append(step.Changes, &Change{Op: OpAdd, Field: fieldDescription, New: *m.Description})
+		}
+		step.Changes = append(step.Changes, diffTags(nil, m.Tags, false)...)
+		step.Changes = append(step.Changes, diffPermissions(nil, m.Permissions)...)
+		return step, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if m.Description != nil && *m.Description != existing.Description {
+		step.Changes = append(step.Changes, &Change{Op: OpUpdate, Field: fieldDescription, Old: existing.Description, New: *m.Description})
+	}
+
+	tags := map[string]string{}
+	for _, t := range existing.Tags {
+		tags[t.Key] = t.Value
+	}
+	step.Changes = append(step.Changes, diffTags(tags, m.Tags, a.opts.Prune)...)
+
+	permissions, err := userPermissions(m.Permissions, func(username string) (mlflow.Permission, error) {
+		p, err := a.client.RegisteredModels.GetPermission(ctx, m.Name, username)
+		if err != nil {
+			return "", err
+		}
+		return p.Permission, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	step.Changes = append(step.Changes, diffPermissions(permissions, m.Permissions)...)
+
+	step.Action = ActionNone
+	if len(step.Changes) > 0 {
+		step.Action = ActionUpdate
+	}
+	return step, nil
+}
+
+// diffTags returns the changes of the tags from existing to declared, deleting the tags not
+// declared with prune, except the mlflow. tags, unless declared is nil.
+func diffTags(existing, declared map[string]string, prune bool) []*Change {
+	var changes []*Change
+	for _, key := range sorted.Keys(declared) {
+		old, ok := existing[key]
+		switch {
+		case !ok:
+			changes = append(changes, &Change{Op: OpAdd, Field: fieldTags + "." + key, New: declared[key]})
+		case old != declared[key]:
+			changes = append(changes, &Change{Op: OpUpdate, Field: fieldTags + "." + key, Old: old, New: declared[key]})
+		}
+	}
+
+	if !prune || declared == nil {
+		return changes
+	}
+	for _, key := range sorted.Keys(existing) {
+		if _, ok := declared[key]; !ok && !strings.HasPrefix(key, "mlflow.") {
+			changes = append(changes, &Change{Op: OpDelete, Field: fieldTags + "." + key, Old: existing[key]})
+		}
+	}
+	return changes
+}
+
+// diffPermissions returns the changes of the permissions from existing to declared.
+func diffPermissions(existing, declared map[string]mlflow.Permission) []*Change {
+	var changes []*Change
+	for _, username := range sorted.Keys(declared) {
+		old, ok := existing[username]
+		switch {
+		case !ok:
+			changes = append(changes, &Change{Op: OpAdd, Field: fieldPermissions + "." + username, New: string(declared[username])})
+		case old != declared[username]:
+			changes = append(changes, &Change{Op: OpUpdate, Field: fieldPermissions + "." + username, Old: string(old), New: string(declared[username])})
+		}
+	}
+	return changes
+}
+
+// Apply reconciles the server with the spec and returns the plan applied. It stops at the
+// first failure, the steps before it being applied.
+func (a *Applier) Apply(ctx context.Context) (*Plan, error) {
+	p, err := a.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range p.Steps {
+		if step.Action == ActionNone {
+			continue
+		}
+
+		err = a.execute(ctx, step)
+		if err != nil {
+			return p, fmt.Errorf("apply: %s: %w", step, err)
+		}
+	}
+
+	return p, nil
+}
+
+func (a *Applier) execute(ctx context.Context, step *Step) error {
+	switch step.Kind {
+	case KindExperiment:
+		switch step.Action {
+		case ActionCreate:
+			id, err := a.client.Experiments.CreateWithOptions(ctx, &mlflow.ExperimentCreateOptions{
+				Name:             step.Name,
+				ArtifactLocation: step.experiment.ArtifactLocation,
+			})
+			if err != nil {
+				return err
+			}
+			step.id = id
+		case ActionRestore:
+			err := a.client.Experiments.Restore(ctx, step.id)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, c := range step.Changes {
+			err := a.changeExperiment(ctx, step.id, c)
+			if err != nil {
+				return fmt.Errorf("%s: %w", c.Field, err)
+			}
+		}
+
+	case KindRegisteredModel:
+		if step.Action == ActionCreate {
+			_, err := a.client.RegisteredModels.Create(ctx, &mlflow.RegisteredModelCreateOptions{Name: step.Name})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, c := range step.Changes {
+			err := a.changeModel(ctx, step.Name, c)
+			if err != nil {
+				return fmt.Errorf("%s: %w", c.Field, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a *Applier) changeExperiment(ctx context.Context, id string, c *Change) error {
+	field, key, _ := strings.Cut(c.Field, ".")
+	switch {
+	case field == fieldTags && c.Op == OpDelete:
+		return a.client.Experiments.DeleteTag(ctx, id, key)
+	case field == fieldTags:
+		return a.client.Experiments.SetTag(ctx, id, key, c.New)
+	case field == fieldPermissions && c.Op == OpAdd:
+		_, err := a.client.Experiments.CreatePermission(ctx, id, key, mlflow.Permission(c.New))
+		return err
+	case field == fieldPermissions:
+		return a.client.Experiments.UpdatePermission(ctx, id, key, mlflow.Permission(c.New))
+	}
+	return fmt.Errorf("unsupported change of %s", c.Field)
+}
+
+func (a *Applier) changeModel(ctx context.Context, name string, c *Change) error {
+	field, key, _ := strings.Cut(c.Field, ".")
+	switch {
+	case field == fieldDescription:
+		_, err := a.client.RegisteredModels.Update(ctx, name, c.New)
+		return err
+	case field == fieldTags && c.Op == OpDelete:
+		return a.client.RegisteredModels.DeleteTag(ctx, name, key)
+	case field == fieldTags:
+		return a.client.RegisteredModels.SetTag(ctx, name, key, c.New)
+	case field == fieldPermissions && c.Op == OpAdd:
+		_, err := a.client.RegisteredModels.CreatePermission(ctx, name, key, mlflow.Permission(c.New))
+		return err
+	case field == fieldPermissions:
+		return a.client.RegisteredModels.UpdatePermission(ctx, name, key, mlflow.Permission(c.New))
+	}
+	return fmt.Errorf("unsupported change of %s", c.Field)
+}
@@ -0,0 +1,120 @@
+package apply
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Spec is the declared state of the experiments and registered models of a tracking server.
+type Spec struct {
+	Experiments      []*Experiment `yaml:"experiments"`
+	RegisteredModels []*Model      `yaml:"registered_models"`
+}
+
+// Experiment is the declared state of an experiment, identified by its name.
+type Experiment struct {
+	Name string `yaml:"name"`
+	// ArtifactLocation is the artifact location of the experiment when it is created, that of
+	// an existing experiment cannot be changed.
+	ArtifactLocation string `yaml:"artifact_location"`
+	// Tags are set on the experiment. With Options.Prune, the other tags are deleted, except
+	// the mlflow. tags, unless Tags is omitted.
+	Tags map[string]string `yaml:"tags"`
+	// Permissions are the permissions of users on the experiment, by username. The permissions
+	// of the other users are left as they are, the authentication API cannot list them.
+	Permissions map[string]mlflow.Permission `yaml:"permissions"`
+}
+
+// Model is the declared state of a registered model, identified by its name.
+type Model struct {
+	Name string `yaml:"name"`
+	// Description is the description of the model, left as it is if omitted.
+	Description *string                      `yaml:"description"`
+	Tags        map[string]string            `yaml:"tags"`
+	Permissions map[string]mlflow.Permission `yaml:"permissions"`
+}
+
+// Load reads and validates the spec at path.
+func Load(path string) (*Spec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Parse parses and validates a YAML spec, rejecting unknown fields.
+func Parse(b []byte) (*Spec, error) {
+	s := &Spec{}
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	err := dec.Decode(s)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("apply: %w", err)
+	}
+
+	err = s.validate()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Spec) validate() error {
+	names := map[string]bool{}
+	for i, e := range s.Experiments {
+		if e == nil || e.Name == "" {
+			return fmt.Errorf("apply: experiment %d has no name", i)
+		}
+		if names[e.Name] {
+			return fmt.Errorf("apply: experiment %q is declared twice", e.Name)
+		}
+		names[e.Name] = true
+		err := normalizePermissions(e.Permissions)
+		if err != nil {
+			return fmt.Errorf("apply: experiment %q: %w", e.Name, err)
+		}
+	}
+
+	names = map[string]bool{}
+	for i, m := range s.RegisteredModels {
+		if m == nil || m.Name == "" {
+			return fmt.Errorf("apply: registered model %d has no name", i)
+		}
+		if names[m.Name] {
+			return fmt.Errorf("apply: registered model %q is declared twice", m.Name)
+		}
+		names[m.Name] = true
+		err := normalizePermissions(m.Permissions)
+		if err != nil {
+			return fmt.Errorf("apply: registered model %q: %w", m.Name, err)
+		}
+	}
+	return nil
+}
+
+// normalizePermissions upper cases the permissions, which are case insensitive in specs.
+func normalizePermissions(permissions map[string]mlflow.Permission) error {
+	for username, p := range permissions {
+		p = mlflow.Permission(strings.ToUpper(string(p)))
+		switch p {
+		case mlflow.PermissionRead, mlflow.PermissionEdit, mlflow.PermissionManage, mlflow.PermissionNoPermissions:
+		default:
+			return fmt.Errorf("unknown permission %q of %s, expected READ, EDIT, MANAGE or NO_PERMISSIONS", p, username)
+		}
+		permissions[username] = p
+	}
+	return nil
+}
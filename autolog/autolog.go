@@ -0,0 +1,224 @@
+// Package autolog logs the training of models with Go machine learning libraries to MLflow
+// runs, like the autologging of the MLflow Python client: the hyperparameters of the model as
+// parameters, the metrics of each epoch, and the trained model as an artifact.
+//
+// Gonum optimizations are logged by a Recorder. The models and values of Gorgonia and GoLearn
+// are handled through the methods they implement, without depending on those libraries, such
+// as in the training loop of a Gorgonia model:
+//
+//	l := autolog.New(client, runID, nil)
+//	err := l.LogParams(ctx, map[string]any{"learning_rate": 0.01, "epochs": epochs})
+//	for epoch := range epochs {
+//		err = vm.RunAll()
+//		err = solver.Step(gorgonia.NodesToValueGrads(learnables))
+//		vm.Reset()
+//		err = l.LogEpoch(ctx, epoch, map[string]any{"loss": lossValue})
+//	}
+//	_, err = l.LogModel(ctx, "model", weights)
+//
+// or of a GoLearn classifier:
+//
+//	cls := knn.NewKnnClassifier("euclidean", "linear", 2)
+//	err := l.LogHyperparameters(ctx, cls)
+//	err = cls.Fit(train)
+//	predictions, err := cls.Predict(test)
+//	cm, err := evaluation.GetConfusionMatrix(test, predictions)
+//	err = l.LogEpoch(ctx, 0, map[string]any{"accuracy": evaluation.GetAccuracy(cm)})
+//	_, err = l.LogModel(ctx, "model", cls)
+package autolog
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+const (
+	// maxBatchParams is the maximum number of parameters logged in a batch.
+	maxBatchParams = 100
+	// maxBatchMetrics is the maximum number of metrics logged in a batch.
+	maxBatchMetrics = 1000
+)
+
+// Config configures a logger.
+type Config struct {
+	// OnError is called with the errors of the logging done in the background, such as by a
+	// Recorder, which does not fail the training.
+	OnError func(err error)
+}
+
+// Logger logs the training of a model to a run.
+type Logger struct {
+	client *mlflow.Client
+	runID  string
+	cfg    Config
+}
+
+// New returns a logger of the training of a model to the run of ID runID.
+func New(client *mlflow.Client, runID string, cfg *Config) *Logger {
+	l := &Logger{client: client, runID: runID}
+	if cfg != nil {
+		l.cfg = *cfg
+	}
+	return l
+}
+
+// LogParams logs parameters, formatted with fmt.Sprint.
+func (l *Logger) LogParams(ctx context.Context, params map[string]any) error {
+	var batch []*mlflow.Param
+	for _, key := range slices.Sorted(maps.Keys(params)) {
+		batch = append(batch, &mlflow.Param{Key: key, Value: fmt.Sprint(params[key])})
+	}
+
+	for len(batch) > 0 {
+		n := min(len(batch), maxBatchParams)
+		err := l.client.Runs.LogBatch(ctx, l.runID, &mlflow.RunData{Params: batch[:n]})
+		if err != nil {
+			return fmt.Errorf("autolog: logging parameters: %w", err)
+		}
+		batch = batch[n:]
+	}
+	return nil
+}
+
+// LogHyperparameters logs the hyperparameters of a model, its fields as returned by Params.
+func (l *Logger) LogHyperparameters(ctx context.Context, model any) error {
+	return l.LogParams(ctx, Params(model))
+}
+
+// LogEpoch logs the metrics of an epoch, with the epoch as step. The values are numbers, or
+// values whose Data method returns a number, such as Gorgonia values and scalar tensors.
+func (l *Logger) LogEpoch(ctx context.Context, epoch int, metrics map[string]any) error {
+	now := time.Now().UnixMilli()
+	var batch []*mlflow.Metric
+	for _, key := range slices.Sorted(maps.Keys(metrics)) {
+		v, err := Float(metrics[key])
+		if err != nil {
+			return fmt.Errorf("autolog: metric %s: %w", key, err)
+		}
+		batch = append(batch, &mlflow.Metric{Key: key, Value: v, Timestamp: now, Step: int64(epoch)})
+	}
+
+	for len(batch) > 0 {
+		n := min(len(batch), maxBatchMetrics)
+		err := l.client.Runs.LogBatch(ctx, l.runID, &mlflow.RunData{Metrics: batch[:n]})
+		if err != nil {
+			return fmt.Errorf("autolog: logging metrics: %w", err)
+		}
+		batch = batch[n:]
+	}
+	return nil
+}
+
+func (l *Logger) error(err error) {
+	if l.cfg.OnError != nil {
+		l.cfg.OnError(err)
+	}
+}
+
+// Float converts a metric value to a float, a number of any type, or a value whose Data
+// method returns a number, such as a Gorgonia value or a scalar tensor.
+func Float(v any) (float64, error) {
+	if d, ok := v.(interface{ Data() any }); ok {
+		v = d.Data()
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	case reflect.Bool:
+		if rv.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, fmt.Errorf("%T is not a number", v)
+}
+
+// Params returns the hyperparameters of a model or of its options, to be logged with
+// LogParams: the exported fields of a struct, or of the struct a pointer points to, whose
+// values are booleans, numbers or strings, keyed by their names in snake case. The fields of
+// nested structs are keyed by the name of the struct field, a dot and their name, and the
+// fields with an mlflow tag are keyed by the tag, or skipped if it is "-":
+//
+//	type Options struct {
+//		LearningRate float64       // learning_rate
+//		Seed         int64         `mlflow:"-"`
+//		Schedule     time.Duration `mlflow:"lr_schedule"`
+//	}
+//
+// The fields of other types, such as slices, functions and interfaces, are skipped.
+func Params(v any) map[string]any {
+	params := map[string]any{}
+	addParams(params, "", reflect.ValueOf(v))
+	return params
+}
+
+func addParams(params map[string]any, prefix string, v reflect.Value) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Tag.Get("mlflow")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = snakeCase(f.Name)
+		}
+
+		fv := v.Field(i)
+		switch fv.Kind() {
+		case reflect.Bool, reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			params[prefix+name] = fv.Interface()
+		case reflect.Struct, reflect.Pointer:
+			addParams(params, prefix+name+".", fv)
+		}
+	}
+}
+
+// snakeCase converts a Go name to snake case, such as NearestNeighbours to
+// nearest_neighbours and HTTPTimeout to http_timeout.
+func snakeCase(name string) string {
+	r := []rune(name)
+	var b strings.Builder
+	for i, c := range r {
+		if unicode.IsUpper(c) {
+			// A word starts at an upper case letter after a lower case letter or digit, or
+			// before a lower case letter in an acronym.
+			if i > 0 && (!unicode.IsUpper(r[i-1]) || i+1 < len(r) && unicode.IsLower(r[i+1])) {
+				b.WriteByte('_')
+			}
+			c = unicode.ToLower(c)
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
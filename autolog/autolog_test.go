@@ -0,0 +1,41 @@
+package autolog_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/codeocean/go-mlflow/autolog"
+	"github.com/codeocean/go-mlflow/mlflowtest"
+)
+
+func TestLoggerLogEpochBatches(t *testing.T) {
+	ctx := context.Background()
+	srv := mlflowtest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, err := client.Runs.Create(ctx, "0", "trained", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := map[string]any{}
+	for i := 0; i < 1500; i++ {
+		metrics[fmt.Sprintf("m%04d", i)] = i
+	}
+	err = autolog.New(client, run.Info.RunID, nil).LogEpoch(ctx, 3, metrics)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run, err = client.Runs.Get(ctx, run.Info.RunID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(run.Data.Metrics) != len(metrics) {
+		t.Errorf("got %d metrics, want %d", len(run.Data.Metrics), len(metrics))
+	}
+}
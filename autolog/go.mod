@@ -0,0 +1,10 @@
+module github.com/codeocean/go-mlflow/autolog
+
+go 1.24.0
+
+require (
+	github.com/codeocean/go-mlflow v0.0.0-20261016210201-e648cfd6b464
+	gonum.org/v1/gonum v0.17.0
+)
+
+require golang.org/x/tools v0.30.0 // indirect
@@ -0,0 +1,94 @@
+package autolog
+
+import (
+	"context"
+	"math"
+	"reflect"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/optimize"
+)
+
+// LogOptimizeSettings logs the hyperparameters of a gonum optimization: the name of the
+// method, such as LBFGS, and its fields, and the fields of the settings under settings.
+// method and settings may be nil, as for optimize.Minimize.
+func (l *Logger) LogOptimizeSettings(ctx context.Context, method optimize.Method, settings *optimize.Settings) error {
+	params := map[string]any{}
+	if method != nil {
+		params = Params(method)
+		params["method"] = reflect.Indirect(reflect.ValueOf(method)).Type().Name()
+	}
+	for k, v := range Params(settings) {
+		params["settings."+k] = v
+	}
+	return l.LogParams(ctx, params)
+}
+
+// Recorder logs the progress of a gonum optimization, as the Recorder of its settings: the
+// value of the objective function as the loss metric and the norm of its gradient as the
+// gradient_norm metric, with the major iteration as step, and the evaluation counts of the
+// optimization once it is done. Logging errors are passed to Config.OnError, they do not stop
+// the optimization.
+//
+//	settings := &optimize.Settings{Recorder: l.Recorder(ctx, 10)}
+//	err := l.LogOptimizeSettings(ctx, method, settings)
+//	res, err := optimize.Minimize(problem, x0, settings, method)
+//	_, err = l.LogModel(ctx, "model", res.X)
+type Recorder struct {
+	l     *Logger
+	ctx   context.Context
+	every int
+}
+
+var _ optimize.Recorder = (*Recorder)(nil)
+
+// Recorder returns a recorder logging every major iteration whose number is a multiple of
+// every, all of them if every is zero or less.
+func (l *Logger) Recorder(ctx context.Context, every int) *Recorder {
+	return &Recorder{l: l, ctx: ctx, every: max(every, 1)}
+}
+
+func (r *Recorder) Init() error {
+	return nil
+}
+
+func (r *Recorder) Record(loc *optimize.Location, op optimize.Operation, stats *optimize.Stats) error {
+	switch {
+	case op == optimize.InitIteration:
+	case op&optimize.MajorIteration != 0:
+		if stats.MajorIterations%r.every != 0 {
+			return nil
+		}
+	case op == optimize.PostIteration:
+	default:
+		return nil
+	}
+
+	// The initial location is not evaluated yet, with an infinite value, unless the
+	// settings have InitValues.
+	metrics := map[string]any{}
+	if finite(loc.F) {
+		metrics["loss"] = loc.F
+	}
+	if len(loc.Gradient) > 0 {
+		if norm := floats.Norm(loc.Gradient, 2); finite(norm) {
+			metrics["gradient_norm"] = norm
+		}
+	}
+	if op == optimize.PostIteration {
+		metrics["func_evaluations"] = stats.FuncEvaluations
+		metrics["grad_evaluations"] = stats.GradEvaluations
+		metrics["hess_evaluations"] = stats.HessEvaluations
+		metrics["runtime_seconds"] = stats.Runtime.Seconds()
+	}
+
+	err := r.l.LogEpoch(r.ctx, stats.MajorIterations, metrics)
+	if err != nil {
+		r.l.error(err)
+	}
+	return nil
+}
+
+func finite(v float64) bool {
+	return !math.IsInf(v, 0) && !math.IsNaN(v)
+}
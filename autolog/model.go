@@ -0,0 +1,96 @@
+package autolog
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ModelFile is the file of the models logged by LogModel, in their artifact directory.
+const ModelFile = "model"
+
+// ModelInfoFile is the JSON file describing the models logged by LogModel, a ModelInfo, in
+// their artifact directory.
+const ModelInfoFile = "model.json"
+
+// Formats of the models logged by LogModel.
+const (
+	// FormatSave is the format of the models saved by their Save method, such as GoLearn
+	// classifiers.
+	FormatSave = "save"
+	// FormatBinary is the format of the models marshaled by their MarshalBinary method, such
+	// as gonum matrices.
+	FormatBinary = "binary"
+	// FormatGob is the format of the other models, encoded with encoding/gob, such as the
+	// Gorgonia tensors of the learnables of a model.
+	FormatGob = "gob"
+)
+
+// ModelInfo describes a model logged by LogModel.
+type ModelInfo struct {
+	// Format is the format of ModelFile.
+	Format string `json:"format"`
+	// Type is the Go type of the model, such as *knn.KNNClassifier.
+	Type string `json:"type"`
+}
+
+// LogModel logs a trained model as artifacts of the run under artifactPath: the model as
+// ModelFile and its ModelInfo as ModelInfoFile. Models with a Save(path string) error method,
+// such as GoLearn classifiers, are saved with it, models implementing
+// encoding.BinaryMarshaler are marshaled with it, and the others are encoded with
+// encoding/gob.
+func (l *Logger) LogModel(ctx context.Context, artifactPath string, model any) (*ModelInfo, error) {
+	dir, err := os.MkdirTemp("", "autolog")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	format, err := saveModel(filepath.Join(dir, ModelFile), model)
+	if err != nil {
+		return nil, fmt.Errorf("autolog: saving %T: %w", model, err)
+	}
+
+	info := &ModelInfo{Format: format, Type: fmt.Sprintf("%T", model)}
+	b, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(filepath.Join(dir, ModelInfoFile), b, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.client.Runs.LogArtifacts(ctx, l.runID, dir, artifactPath)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// saveModel saves a model to a file and returns its format.
+func saveModel(name string, model any) (string, error) {
+	if s, ok := model.(interface{ Save(path string) error }); ok {
+		return FormatSave, s.Save(name)
+	}
+
+	if m, ok := model.(encoding.BinaryMarshaler); ok {
+		b, err := m.MarshalBinary()
+		if err != nil {
+			return "", err
+		}
+		return FormatBinary, os.WriteFile(name, b, 0o644)
+	}
+
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(model)
+	if err != nil {
+		return "", err
+	}
+	return FormatGob, os.WriteFile(name, buf.Bytes(), 0o644)
+}
@@ -0,0 +1,44 @@
+// Package awssecrets provides MLflow credentials stored in AWS Secrets Manager, see
+// mlflow.ParseCredentials for the format of the secret.
+//
+//	cfg, err := config.LoadDefaultConfig(ctx)
+//	client, err := mlflow.NewClient(nil, "https://mlflow.example.com",
+//		mlflow.WithCredentialsProvider(awssecrets.NewCredentialsProvider(
+//			secretsmanager.NewFromConfig(cfg), "mlflow/credentials", 5*time.Minute)))
+package awssecrets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// NewCredentialsProvider returns a provider of the credentials stored in the secret with the
+// given ID or ARN, fetched again once they are older than ttl.
+func NewCredentialsProvider(client *secretsmanager.Client, secretID string, ttl time.Duration) mlflow.CredentialsProvider {
+	return mlflow.CachingCredentialsProvider(mlflow.CredentialsProviderFunc(func(ctx context.Context) (*mlflow.Credentials, error) {
+		out, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId: aws.String(secretID),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		secret := out.SecretBinary
+		if out.SecretString != nil {
+			secret = []byte(*out.SecretString)
+		}
+
+		credentials, err := mlflow.ParseCredentials(secret)
+		if err != nil {
+			return nil, fmt.Errorf("awssecrets: secret %s: %w", secretID, err)
+		}
+
+		return credentials, nil
+	}), ttl)
+}
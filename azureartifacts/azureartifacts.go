@@ -0,0 +1,176 @@
+// Package azureartifacts implements an MLflow artifact repository for artifacts stored in
+// Azure Blob Storage under wasbs:// URIs, or in Azure Data Lake Storage Gen2 under abfss://
+// URIs.
+//
+//	cred, err := azidentity.NewDefaultAzureCredential(nil)
+//	factory := azureartifacts.Factory(cred, nil)
+//	client, err := mlflow.NewClient(nil, "http://localhost:5000",
+//		mlflow.WithArtifactRepository("wasbs", factory),
+//		mlflow.WithArtifactRepository("abfss", factory))
+package azureartifacts
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Repository accesses the artifacts below a wasbs://container@account.blob.core.windows.net/prefix
+// or abfss://container@account.dfs.core.windows.net/prefix URI.
+type Repository struct {
+	client    *azblob.Client
+	container string
+	prefix    string
+}
+
+// NewRepository returns the repository of the artifacts below rootURI, a wasbs:// or abfss://
+// URI, authenticating with cred. ADLS Gen2 storage is accessed through its Blob endpoint.
+func NewRepository(cred azcore.TokenCredential, options *azblob.ClientOptions, rootURI string) (*Repository, error) {
+	u, err := url.Parse(rootURI)
+	if err != nil {
+		return nil, err
+	}
+
+	if (u.Scheme != "wasbs" && u.Scheme != "abfss") || u.User == nil || u.User.Username() == "" || u.Host == "" {
+		return nil, fmt.Errorf("azureartifacts: invalid Azure storage URI %q", rootURI)
+	}
+
+	host := u.Host
+	if u.Scheme == "abfss" {
+		host = strings.Replace(host, ".dfs.", ".blob.", 1)
+	}
+
+	client, err := azblob.NewClient("https://"+host+"/", cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{
+		client:    client,
+		container: u.User.Username(),
+		prefix:    strings.Trim(u.Path, "/"),
+	}, nil
+}
+
+// Factory returns a factory of repositories authenticating with cred, to be registered with
+// mlflow.WithArtifactRepository for the "wasbs" and "abfss" schemes.
+func Factory(cred azcore.TokenCredential, options *azblob.ClientOptions) mlflow.ArtifactRepositoryFactory {
+	return func(ctx context.Context, rootURI string) (mlflow.ArtifactRepository, error) {
+		return NewRepository(cred, options, rootURI)
+	}
+}
+
+func (r *Repository) Get(ctx context.Context, path string, w io.Writer) error {
+	res, err := r.client.DownloadStream(ctx, r.container, r.key(path), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	_, err = io.Copy(w, res.Body)
+	return err
+}
+
+func (r *Repository) Put(ctx context.Context, path string, body io.Reader) error {
+	_, err := r.client.UploadStream(ctx, r.container, r.key(path), body, nil)
+	return err
+}
+
+func (r *Repository) List(ctx context.Context, path string) ([]*mlflow.FileInfo, error) {
+	prefix := r.key(path)
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	var files []*mlflow.FileInfo
+
+	pager := r.client.ServiceClient().NewContainerClient(r.container).NewListBlobsHierarchyPager("/", &container.ListBlobsHierarchyOptions{
+		Prefix: &prefix,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range page.Segment.BlobPrefixes {
+			files = append(files, &mlflow.FileInfo{
+				Path:  r.relative(strings.TrimSuffix(deref(p.Name), "/")),
+				IsDir: true,
+			})
+		}
+		for _, b := range page.Segment.BlobItems {
+			f := &mlflow.FileInfo{Path: r.relative(deref(b.Name))}
+			if b.Properties != nil {
+				setProperties(f, b.Properties.ContentLength, b.Properties.LastModified, b.Properties.ContentMD5)
+			}
+			files = append(files, f)
+		}
+	}
+
+	return files, nil
+}
+
+func (r *Repository) Stat(ctx context.Context, path string) (*mlflow.FileInfo, error) {
+	props, err := r.client.ServiceClient().NewContainerClient(r.container).NewBlobClient(r.key(path)).GetProperties(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &mlflow.FileInfo{Path: strings.Trim(path, "/")}
+	setProperties(f, props.ContentLength, props.LastModified, props.ContentMD5)
+	return f, nil
+}
+
+// setProperties sets the metadata of f from the properties of its blob, Content-MD5 is only
+// set for blobs uploaded in a single request or by clients computing it.
+func setProperties(f *mlflow.FileInfo, size *int64, lastModified *time.Time, md5 []byte) {
+	if size != nil {
+		f.FileSize = *size
+	}
+	if lastModified != nil {
+		f.LastModified = *lastModified
+	}
+	if len(md5) > 0 {
+		f.ContentHash = "md5:" + hex.EncodeToString(md5)
+	}
+}
+
+// key returns the blob name of the artifact at path.
+func (r *Repository) key(path string) string {
+	path = strings.Trim(path, "/")
+	if r.prefix == "" {
+		return path
+	}
+	if path == "" {
+		return r.prefix
+	}
+
+	return r.prefix + "/" + path
+}
+
+// relative returns the artifact path of a blob name.
+func (r *Repository) relative(name string) string {
+	if r.prefix == "" {
+		return name
+	}
+
+	return strings.TrimPrefix(strings.TrimPrefix(name, r.prefix), "/")
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/codeocean/go-mlflow/apply"
+)
+
+func applySpec(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	dryRun := fs.Bool("dry-run", false, "only print the changes, without applying them")
+	prune := fs.Bool("prune", false, "delete the tags not in the spec of the resources declaring tags")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	spec, err := apply.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	applier := apply.New(a.client, spec, &apply.Options{Prune: *prune})
+	var plan *apply.Plan
+	if *dryRun {
+		plan, err = applier.Plan(ctx)
+	} else {
+		plan, err = applier.Apply(ctx)
+	}
+	if plan == nil {
+		return err
+	}
+
+	// The plan is written even if applying it failed, to show the steps before the failure.
+	var writeErr error
+	if a.format == formatJSON {
+		writeErr = a.write(plan, nil, nil)
+	} else {
+		writeErr = plan.WriteDiff(a.stdout)
+	}
+	if err != nil {
+		return err
+	}
+	return writeErr
+}
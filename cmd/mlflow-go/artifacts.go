@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+var artifactCommands = []*command{
+	{name: "list", args: "<run-id> [path]", summary: "List the artifacts of a run", run: listArtifacts},
+	{name: "download", args: "<run-id> [path]", summary: "Download an artifact file or directory of a run", run: downloadArtifacts},
+	{name: "upload", args: "<run-id> <local-path> [artifact-path]", summary: "Upload a local file or directory to the artifacts of a run", run: uploadArtifacts},
+}
+
+func listArtifacts(ctx context.Context, a *app, flags *flag.FlagSet, args []string) error {
+	recursive := flags.Bool("recursive", false, "list the artifacts of the subdirectories")
+	args, err := parse(flags, args, 1, 2)
+	if err != nil {
+		return err
+	}
+	var root string
+	if len(args) > 1 {
+		root = args[1]
+	}
+
+	files := []*mlflow.FileInfo{}
+	err = a.client.Artifacts.Walk(ctx, args[0], root, func(f *mlflow.FileInfo) error {
+		files = append(files, f)
+		if f.IsDir && !*recursive {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(files))
+	for i, f := range files {
+		size := strconv.FormatInt(f.FileSize, 10)
+		if f.IsDir {
+			size = "-"
+		}
+		rows[i] = []string{f.Path, size}
+	}
+	return a.write(files, []string{"PATH", "SIZE"}, rows)
+}
+
+func downloadArtifacts(ctx context.Context, a *app, flags *flag.FlagSet, args []string) error {
+	dir := flags.String("dir", ".", "local directory the artifacts are downloaded to")
+	args, err := parse(flags, args, 1, 2)
+	if err != nil {
+		return err
+	}
+	runID := args[0]
+	if len(args) == 1 {
+		return a.client.Artifacts.DownloadDir(ctx, runID, "", *dir)
+	}
+
+	// Listing a file returns no artifacts.
+	p := args[1]
+	res, err := a.client.Artifacts.List(ctx, &mlflow.ListArtifactsRequest{RunID: runID, Path: p})
+	if err != nil {
+		return err
+	}
+	if len(res.Files) > 0 {
+		return a.client.Artifacts.DownloadDir(ctx, runID, p, filepath.Join(*dir, path.Base(p)))
+	}
+
+	err = os.MkdirAll(*dir, 0o755)
+	if err != nil {
+		return err
+	}
+	return a.client.Artifacts.DownloadFile(ctx, runID, p, filepath.Join(*dir, path.Base(p)))
+}
+
+func uploadArtifacts(ctx context.Context, a *app, flags *flag.FlagSet, args []string) error {
+	args, err := parse(flags, args, 2, 3)
+	if err != nil {
+		return err
+	}
+	runID, localPath := args[0], args[1]
+	var artifactPath string
+	if len(args) > 2 {
+		artifactPath = args[2]
+	}
+
+	fi, err := os.Stat(localPath)
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return a.client.Runs.LogArtifacts(ctx, runID, localPath, artifactPath)
+	}
+	return a.client.Runs.LogArtifact(ctx, runID, localPath, artifactPath)
+}
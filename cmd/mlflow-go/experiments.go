@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+var experimentCommands = []*command{
+	{name: "search", summary: "Search experiments", run: searchExperiments},
+	{name: "get", args: "<experiment-id>", summary: "Get an experiment by ID, or by name with -name", run: getExperiment},
+}
+
+// viewType parses the value of a -view flag.
+func viewType(s string) (mlflow.ViewType, error) {
+	v := mlflow.ViewType(strings.ToUpper(s))
+	switch v {
+	case mlflow.ViewTypeActiveOnly, mlflow.ViewTypeDeletedOnly, mlflow.ViewTypeAll:
+		return v, nil
+	}
+	return "", fmt.Errorf("unknown view %q, expected active_only, deleted_only or all", s)
+}
+
+// collect returns the first max values of an iterator, all of them if max is 0.
+func collect[T any](it *mlflow.Iterator[T], max int) ([]T, error) {
+	var values []T
+	for (max <= 0 || len(values) < max) && it.Next() {
+		values = append(values, it.Value())
+	}
+	return values, it.Err()
+}
+
+func searchExperiments(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	filter := fs.String("filter", "", "search filter, such as \"name LIKE 'fraud-%'\"")
+	var orderBy stringsFlag
+	fs.Var(&orderBy, "order-by", "order by clause, such as \"creation_time DESC\" (repeatable)")
+	view := fs.String("view", "active_only", "lifecycle stage of the experiments: active_only, deleted_only or all")
+	max := fs.Int("max", 0, "maximum number of experiments, 0 for all of them")
+	_, err := parse(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	vt, err := viewType(*view)
+	if err != nil {
+		return err
+	}
+
+	experiments, err := collect(a.client.Experiments.Iterate(ctx, &mlflow.ExperimentsSearchOptions{
+		Filter:   *filter,
+		ViewType: vt,
+		OrderBy:  orderBy,
+	}), *max)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(experiments))
+	for i, e := range experiments {
+		rows[i] = []string{e.ExperimentID, e.Name, string(e.LifecycleStage), e.ArtifactLocation, formatTime(e.CreationTime)}
+	}
+	if experiments == nil {
+		experiments = []*mlflow.Experiment{}
+	}
+	return a.write(experiments, []string{"ID", "NAME", "STAGE", "ARTIFACT_LOCATION", "CREATED"}, rows)
+}
+
+func getExperiment(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	byName := fs.Bool("name", false, "get the experiment by name")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	var e *mlflow.Experiment
+	if *byName {
+		e, err = a.client.Experiments.GetByName(ctx, args[0])
+	} else {
+		e, err = a.client.Experiments.Get(ctx, args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	fields := [][2]string{
+		{"id", e.ExperimentID},
+		{"name", e.Name},
+		{"stage", string(e.LifecycleStage)},
+		{"artifact_location", e.ArtifactLocation},
+		{"created", formatTime(e.CreationTime)},
+		{"updated", formatTime(e.LastUpdateTime)},
+	}
+	for _, t := range e.Tags {
+		fields = append(fields, [2]string{"tags." + t.Key, t.Value})
+	}
+	return a.writeFields(e, fields)
+}
@@ -0,0 +1,11 @@
+module github.com/codeocean/go-mlflow/cmd/mlflow-go
+
+go 1.19
+
+require (
+	github.com/codeocean/go-mlflow v0.0.0-20261016211053-944b9ed880a5
+	github.com/codeocean/go-mlflow/apply v0.0.0-20261016211054-db89ee9af1f2
+	github.com/codeocean/go-mlflow/filestore v0.0.0-20261016211054-a6a4e3873d75
+)
+
+require gopkg.in/yaml.v3 v3.0.1 // indirect
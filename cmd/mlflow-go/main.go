@@ -0,0 +1,235 @@
+// Command mlflow-go searches, reads and logs the experiments, runs, registered models and
+// artifacts of an MLflow tracking server, for shell scripts and CI jobs without Python.
+//
+// Usage:
+//
+//	mlflow-go [-tracking-uri uri] [-output table|json] <command> [subcommand] [flags] [args]
+//
+// The commands are:
+//
+//	experiments search|get
+//	runs        search|get|create|log|end
+//	models      search|get|versions|promote|download
+//	artifacts   list|download|upload
+//	apply       <spec.yaml>
+//
+// The tracking server is MLFLOW_TRACKING_URI, unless -tracking-uri is set, authenticated with
+// MLFLOW_TRACKING_TOKEN, or MLFLOW_TRACKING_USERNAME and MLFLOW_TRACKING_PASSWORD. Local
+// paths and file:// URIs are read as mlruns directories, like MLflow does.
+//
+// Results are written to the standard output as tables, or as JSON with -output json. Errors
+// are written to the standard error, and make the command exit with status 1, or 2 for usage
+// errors.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/codeocean/go-mlflow/filestore"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Environment variables of the tracking server, as read by MLflow.
+const (
+	envTrackingURI      = "MLFLOW_TRACKING_URI"
+	envTrackingToken    = "MLFLOW_TRACKING_TOKEN"
+	envTrackingUsername = "MLFLOW_TRACKING_USERNAME"
+	envTrackingPassword = "MLFLOW_TRACKING_PASSWORD"
+)
+
+// errUsage is returned by the commands called with invalid flags or arguments, after
+// printing their usage.
+var errUsage = errors.New("usage")
+
+type command struct {
+	name string
+	// args describes the arguments of the command, after its flags.
+	args    string
+	summary string
+	// run runs the command, defining its flags on fs and parsing args with parse.
+	run func(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error
+}
+
+type group struct {
+	name     string
+	commands []*command
+}
+
+// commands are the commands without subcommands.
+var commands = []*command{
+	{name: "apply", args: "<spec.yaml>", summary: "Reconcile experiments and registered models with a YAML spec", run: applySpec},
+}
+
+var groups = []*group{
+	{name: "experiments", commands: experimentCommands},
+	{name: "runs", commands: runCommands},
+	{name: "models", commands: modelCommands},
+	{name: "artifacts", commands: artifactCommands},
+}
+
+// app is the state shared by the commands.
+type app struct {
+	client *mlflow.Client
+	format format
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run runs the command line args, returning the exit status.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	a := &app{format: formatTable, stdout: stdout, stderr: stderr}
+
+	fs := flag.NewFlagSet("mlflow-go", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	trackingURI := fs.String("tracking-uri", os.Getenv(envTrackingURI), "URI of the tracking server, or path of an mlruns directory")
+	fs.Var(&a.format, "output", "output format, table or json")
+	fs.Usage = func() { usage(stderr, fs) }
+	err := fs.Parse(args)
+	if err != nil {
+		return 2
+	}
+
+	name, cmd, args := findCommand(fs.Args())
+	if cmd == nil {
+		if name != "" {
+			fmt.Fprintf(stderr, "mlflow-go: unknown command %q\n", name)
+		}
+		fs.Usage()
+		return 2
+	}
+
+	a.client, err = newClient(*trackingURI)
+	if err != nil {
+		fmt.Fprintf(stderr, "mlflow-go: %v\n", err)
+		return 1
+	}
+
+	err = cmd.run(ctx, a, a.flags(name, cmd), args)
+	switch {
+	case errors.Is(err, errUsage):
+		return 2
+	case err != nil:
+		fmt.Fprintf(stderr, "mlflow-go: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// findCommand returns the command of the command line args, with its name and arguments.
+func findCommand(args []string) (string, *command, []string) {
+	if len(args) == 0 {
+		return "", nil, nil
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.name, c, args[1:]
+		}
+	}
+	if len(args) < 2 {
+		return strings.Join(args, " "), nil, nil
+	}
+
+	name := args[0] + " " + args[1]
+	for _, g := range groups {
+		if g.name != args[0] {
+			continue
+		}
+		for _, c := range g.commands {
+			if c.name == args[1] {
+				return name, c, args[2:]
+			}
+		}
+	}
+	return name, nil, nil
+}
+
+func usage(w io.Writer, fs *flag.FlagSet) {
+	fmt.Fprintf(w, "Usage: mlflow-go [flags] <command> [subcommand] [flags] [args]\n\nFlags:\n")
+	fs.PrintDefaults()
+	fmt.Fprintf(w, "\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-22s %s\n", c.name, c.summary)
+	}
+	for _, g := range groups {
+		for _, c := range g.commands {
+			fmt.Fprintf(w, "  %-22s %s\n", g.name+" "+c.name, c.summary)
+		}
+	}
+}
+
+// flags returns the flag set of a command, printing its usage on errors.
+func (a *app) flags(name string, cmd *command) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: mlflow-go %s [flags] %s\n\n%s.\n", name, cmd.args, cmd.summary)
+		var hasFlags bool
+		fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprintf(a.stderr, "\nFlags:\n")
+			fs.PrintDefaults()
+		}
+	}
+	return fs
+}
+
+// parse parses the flags of a command, and checks it has between min and max arguments, max
+// being -1 for no limit.
+func parse(fs *flag.FlagSet, args []string, min, max int) ([]string, error) {
+	err := fs.Parse(args)
+	if err != nil {
+		return nil, errUsage
+	}
+	args = fs.Args()
+	if len(args) < min || (max >= 0 && len(args) > max) {
+		fs.Usage()
+		return nil, errUsage
+	}
+	return args, nil
+}
+
+// newClient returns the client of the tracking server at trackingURI, or of the mlruns
+// directory at a local path or file:// URI.
+func newClient(trackingURI string) (*mlflow.Client, error) {
+	if trackingURI == "" {
+		return nil, fmt.Errorf("no tracking server, set %s or -tracking-uri", envTrackingURI)
+	}
+
+	u, err := url.Parse(trackingURI)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case u.Scheme == "file":
+		return filestore.NewClient(filepath.FromSlash(u.Path))
+	case len(u.Scheme) <= 1:
+		// No scheme, or a Windows drive letter.
+		return filestore.NewClient(trackingURI)
+	case u.Scheme != "http" && u.Scheme != "https":
+		return nil, fmt.Errorf("unsupported tracking URI %q", trackingURI)
+	}
+
+	opts := []mlflow.ClientOption{mlflow.WithArtifactRepository("file", filestore.Factory())}
+	if token := os.Getenv(envTrackingToken); token != "" {
+		opts = append(opts, mlflow.WithToken(token))
+	} else if username := os.Getenv(envTrackingUsername); username != "" {
+		opts = append(opts, mlflow.WithBasicAuth(username, os.Getenv(envTrackingPassword)))
+	}
+	return mlflow.NewClient(nil, trackingURI, opts...)
+}
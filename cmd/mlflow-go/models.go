@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/codeocean/go-mlflow/internal/filterstring"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+var modelCommands = []*command{
+	{name: "search", summary: "Search registered models", run: searchModels},
+	{name: "get", args: "<name>", summary: "Get a registered model", run: getModel},
+	{name: "versions", args: "<name>", summary: "Search the versions of a registered model", run: searchVersions},
+	{name: "promote", args: "<name> <version>", summary: "Promote a model version to a stage or alias", run: promoteVersion},
+	{name: "download", args: "<model-uri>", summary: "Download a model, such as models:/name@alias or runs:/run_id/model", run: downloadModel},
+}
+
+func searchModels(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	filter := fs.String("filter", "", "search filter, such as \"name LIKE 'fraud-%'\"")
+	var orderBy stringsFlag
+	fs.Var(&orderBy, "order-by", "order by clause, such as \"last_updated_timestamp DESC\" (repeatable)")
+	max := fs.Int("max", 0, "maximum number of models, 0 for all of them")
+	_, err := parse(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	models, err := collect(a.client.RegisteredModels.Iterate(ctx, &mlflow.RegisteredModelsSearchOptions{
+		Filter:  *filter,
+		OrderBy: orderBy,
+	}), *max)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(models))
+	for i, m := range models {
+		var latest, aliases []string
+		for _, v := range m.LatestVersions {
+			latest = append(latest, v.Version+":"+string(v.CurrentStage))
+		}
+		for _, alias := range m.Aliases {
+			aliases = append(aliases, alias.Alias+":"+alias.Version)
+		}
+		rows[i] = []string{m.Name, strings.Join(latest, ","), strings.Join(aliases, ","), formatTime(m.LastUpdatedTimestamp)}
+	}
+	if models == nil {
+		models = []*mlflow.RegisteredModel{}
+	}
+	return a.write(models, []string{"NAME", "LATEST_VERSIONS", "ALIASES", "UPDATED"}, rows)
+}
+
+func getModel(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	m, err := a.client.RegisteredModels.Get(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	fields := [][2]string{
+		{"name", m.Name},
+		{"description", m.Description},
+		{"created", formatTime(m.CreationTimestamp)},
+		{"updated", formatTime(m.LastUpdatedTimestamp)},
+	}
+	for _, v := range m.LatestVersions {
+		fields = append(fields, [2]string{"latest_versions." + string(v.CurrentStage), v.Version})
+	}
+	for _, alias := range m.Aliases {
+		fields = append(fields, [2]string{"aliases." + alias.Alias, alias.Version})
+	}
+	for _, t := range m.Tags {
+		fields = append(fields, [2]string{"tags." + t.Key, t.Value})
+	}
+	return a.writeFields(m, fields)
+}
+
+func searchVersions(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	filter := fs.String("filter", "", "additional search filter, such as \"tags.validated = 'true'\"")
+	var orderBy stringsFlag
+	fs.Var(&orderBy, "order-by", "order by clause, such as \"version_number DESC\" (repeatable)")
+	max := fs.Int("max", 0, "maximum number of versions, 0 for all of them")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	f := "name = " + filterstring.Quote(args[0])
+	if *filter != "" {
+		f += " AND " + *filter
+	}
+	versions, err := collect(a.client.ModelVersions.Iterate(ctx, &mlflow.ModelVersionsSearchOptions{
+		Filter:  f,
+		OrderBy: orderBy,
+	}), *max)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(versions))
+	for i, v := range versions {
+		rows[i] = []string{v.Version, string(v.CurrentStage), string(v.Status), strings.Join(v.Aliases, ","), v.RunID, v.Source, formatTime(v.CreationTimestamp)}
+	}
+	if versions == nil {
+		versions = []*mlflow.ModelVersion{}
+	}
+	return a.write(versions, []string{"VERSION", "STAGE", "STATUS", "ALIASES", "RUN_ID", "SOURCE", "CREATED"}, rows)
+}
+
+// previousVersionsActions maps the values of the -previous flag to actions.
+var previousVersionsActions = map[string]mlflow.PreviousVersionsAction{
+	"keep":    mlflow.PreviousVersionsKeep,
+	"archive": mlflow.PreviousVersionsArchive,
+	"unalias": mlflow.PreviousVersionsUnalias,
+}
+
+// stages maps the lower case stages to the stages.
+var stages = map[string]mlflow.ModelVersionStage{
+	"none":       mlflow.ModelVersionStageNone,
+	"staging":    mlflow.ModelVersionStageStaging,
+	"production": mlflow.ModelVersionStageProduction,
+	"archived":   mlflow.ModelVersionStageArchived,
+}
+
+func promoteVersion(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	stage := fs.String("stage", "", "stage the version is moved to: Staging, Production, Archived or None")
+	alias := fs.String("alias", "", "alias pointed to the version, such as champion")
+	previous := fs.String("previous", "keep", "what to do with the versions previously in the stage or alias: keep, archive or unalias")
+	previousAlias := fs.String("previous-alias", "", "alias pointed to the version the alias pointed to, to roll back to")
+	dryRun := fs.Bool("dry-run", false, "only print the changes, without applying them")
+	args, err := parse(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	policy := &mlflow.PromotionPolicy{Alias: *alias, PreviousAlias: *previousAlias, DryRun: *dryRun}
+	if *stage != "" {
+		s, ok := stages[strings.ToLower(*stage)]
+		if !ok {
+			return fmt.Errorf("unknown stage %q, expected Staging, Production, Archived or None", *stage)
+		}
+		policy.Stage = s
+	}
+	action, ok := previousVersionsActions[*previous]
+	if !ok {
+		return fmt.Errorf("unknown action %q, expected keep, archive or unalias", *previous)
+	}
+	policy.Previous = action
+	if policy.Stage == "" && policy.Alias == "" {
+		return fmt.Errorf("no promotion, set -stage or -alias")
+	}
+
+	changes, err := a.client.ModelVersions.PromoteVersion(ctx, args[0], args[1], policy)
+	if err != nil {
+		return err
+	}
+
+	type change struct {
+		Type    mlflow.PromotionChangeType `json:"type"`
+		Version string                     `json:"version"`
+		Stage   mlflow.ModelVersionStage   `json:"stage,omitempty"`
+		Alias   string                     `json:"alias,omitempty"`
+	}
+	res := make([]*change, len(changes))
+	rows := make([][]string, len(changes))
+	for i, c := range changes {
+		res[i] = &change{Type: c.Type, Version: c.Version, Stage: c.Stage, Alias: c.Alias}
+		rows[i] = []string{c.String()}
+	}
+	return a.write(res, []string{"CHANGE"}, rows)
+}
+
+func downloadModel(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	dir := fs.String("dir", ".", "local directory the model is downloaded to")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	return a.client.DownloadModel(ctx, args[0], *dir)
+}
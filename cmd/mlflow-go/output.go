@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/codeocean/go-mlflow/internal/sorted"
+)
+
+// format is the format of the results written by the commands.
+type format string
+
+const (
+	formatTable format = "table"
+	formatJSON  format = "json"
+)
+
+func (f *format) String() string {
+	return string(*f)
+}
+
+func (f *format) Set(s string) error {
+	switch format(s) {
+	case formatTable, formatJSON:
+		*f = format(s)
+		return nil
+	}
+	return fmt.Errorf("unknown format %q, expected table or json", s)
+}
+
+// write writes the result of a command: v as JSON, or the rows of a table with a header.
+func (a *app) write(v any, header []string, rows [][]string) error {
+	if a.format == formatJSON {
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(a.stdout, "%s\n", b)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// writeFields writes the result of a get command: v as JSON, or a table of the names and
+// values of its fields.
+func (a *app) writeFields(v any, fields [][2]string) error {
+	rows := make([][]string, len(fields))
+	for i, f := range fields {
+		rows[i] = []string{f[0], f[1]}
+	}
+	return a.write(v, []string{"FIELD", "VALUE"}, rows)
+}
+
+// formatTime formats a timestamp in milliseconds, empty if unset.
+func formatTime(ms int64) string {
+	if ms == 0 {
+		return ""
+	}
+	return time.UnixMilli(ms).UTC().Format(time.RFC3339)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// stringsFlag is a flag which can be repeated.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
+// keyValuesFlag is a repeated flag of key=value pairs.
+type keyValuesFlag map[string]string
+
+func (f *keyValuesFlag) String() string {
+	var pairs []string
+	for _, key := range sorted.Keys(*f) {
+		pairs = append(pairs, key+"="+(*f)[key])
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f *keyValuesFlag) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("%q is not a key=value pair", s)
+	}
+	if *f == nil {
+		*f = keyValuesFlag{}
+	}
+	(*f)[key] = value
+	return nil
+}
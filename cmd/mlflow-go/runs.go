@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codeocean/go-mlflow/internal/sorted"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+var runCommands = []*command{
+	{name: "search", summary: "Search the runs of experiments", run: searchRuns},
+	{name: "get", args: "<run-id>", summary: "Get a run", run: getRun},
+	{name: "create", summary: "Create a run", run: createRun},
+	{name: "log", args: "<run-id>", summary: "Log metrics, params and tags to a run", run: logRun},
+	{name: "end", args: "<run-id>", summary: "End a run", run: endRun},
+}
+
+// experimentIDs returns the IDs of experiments given by ID or by name.
+func experimentIDs(ctx context.Context, client *mlflow.Client, ids, names []string) ([]string, error) {
+	ids = append([]string(nil), ids...)
+	for _, name := range names {
+		e, err := client.Experiments.GetByName(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("experiment %q: %w", name, err)
+		}
+		ids = append(ids, e.ExperimentID)
+	}
+	return ids, nil
+}
+
+// runColumn returns the value of a column of the runs table, such as metrics.rmse,
+// params.lr or tags.team.
+func runColumn(run *mlflow.Run, column string) string {
+	kind, key, _ := strings.Cut(column, ".")
+	switch kind {
+	case "metrics":
+		for _, m := range run.Data.Metrics {
+			if m.Key == key {
+				return formatFloat(m.Value)
+			}
+		}
+	case "params":
+		for _, p := range run.Data.Params {
+			if p.Key == key {
+				return p.Value
+			}
+		}
+	case "tags":
+		for _, t := range run.Data.Tags {
+			if t.Key == key {
+				return t.Value
+			}
+		}
+	}
+	return ""
+}
+
+func searchRuns(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	var ids, names, orderBy, columns stringsFlag
+	fs.Var(&ids, "experiment-id", "ID of an experiment of the runs (repeatable)")
+	fs.Var(&names, "experiment-name", "name of an experiment of the runs (repeatable)")
+	filter := fs.String("filter", "", "search filter, such as \"metrics.rmse < 1 AND params.model = 'tree'\"")
+	fs.Var(&orderBy, "order-by", "order by clause, such as \"metrics.rmse ASC\" (repeatable)")
+	fs.Var(&columns, "column", "additional column of the table, such as metrics.rmse, params.lr or tags.team (repeatable)")
+	view := fs.String("view", "active_only", "lifecycle stage of the runs: active_only, deleted_only or all")
+	max := fs.Int("max", 0, "maximum number of runs, 0 for all of them")
+	_, err := parse(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	vt, err := viewType(*view)
+	if err != nil {
+		return err
+	}
+	experimentIDs, err := experimentIDs(ctx, a.client, ids, names)
+	if err != nil {
+		return err
+	}
+	if len(experimentIDs) == 0 {
+		return fmt.Errorf("no experiment, set -experiment-id or -experiment-name")
+	}
+
+	runs, err := collect(a.client.Runs.Iterate(ctx, &mlflow.RunSearchOptions{
+		ExperimentIDs: experimentIDs,
+		Filter:        *filter,
+		RunViewType:   vt,
+		OrderBy:       orderBy,
+	}), *max)
+	if err != nil {
+		return err
+	}
+
+	header := append([]string{"RUN_ID", "NAME", "EXPERIMENT_ID", "STATUS", "STARTED"}, columns...)
+	rows := make([][]string, len(runs))
+	for i, r := range runs {
+		rows[i] = []string{r.Info.RunID, r.Info.RunName, r.Info.ExperimentID, string(r.Info.Status), formatTime(r.Info.StartTime)}
+		for _, c := range columns {
+			rows[i] = append(rows[i], runColumn(r, c))
+		}
+	}
+	if runs == nil {
+		runs = []*mlflow.Run{}
+	}
+	return a.write(runs, header, rows)
+}
+
+// writeRun writes a run, with its metrics, params and tags in table fields such as
+// metrics.rmse.
+func (a *app) writeRun(run *mlflow.Run) error {
+	info := run.Info
+	fields := [][2]string{
+		{"run_id", info.RunID},
+		{"name", info.RunName},
+		{"experiment_id", info.ExperimentID},
+		{"status", string(info.Status)},
+		{"stage", string(info.LifecycleStage)},
+		{"started", formatTime(info.StartTime)},
+		{"ended", formatTime(info.EndTime)},
+		{"artifact_uri", info.ArtifactUri},
+	}
+	if data := run.Data; data != nil {
+		for _, m := range data.Metrics {
+			fields = append(fields, [2]string{"metrics." + m.Key, formatFloat(m.Value)})
+		}
+		for _, p := range data.Params {
+			fields = append(fields, [2]string{"params." + p.Key, p.Value})
+		}
+		for _, t := range data.Tags {
+			fields = append(fields, [2]string{"tags." + t.Key, t.Value})
+		}
+	}
+	return a.writeFields(run, fields)
+}
+
+func getRun(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	run, err := a.client.Runs.Get(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return a.writeRun(run)
+}
+
+func createRun(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	id := fs.String("experiment-id", "", "ID of the experiment of the run")
+	experimentName := fs.String("experiment-name", "", "name of the experiment of the run, created if needed")
+	name := fs.String("name", "", "name of the run")
+	var tags keyValuesFlag
+	fs.Var(&tags, "tag", "tag of the run, as key=value (repeatable)")
+	_, err := parse(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case *id != "" && *experimentName != "":
+		return fmt.Errorf("set only one of -experiment-id and -experiment-name")
+	case *experimentName != "":
+		e, err := a.client.Experiments.GetByName(ctx, *experimentName)
+		switch {
+		case mlflow.IsResourceDoesNotExist(err):
+			*id, err = a.client.Experiments.Create(ctx, *experimentName)
+			if err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		default:
+			*id = e.ExperimentID
+		}
+	case *id == "":
+		return fmt.Errorf("no experiment, set -experiment-id or -experiment-name")
+	}
+
+	run, err := a.client.Runs.Create(ctx, *id, *name, 0, tags)
+	if err != nil {
+		return err
+	}
+	return a.writeRun(run)
+}
+
+func logRun(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	var metrics, params, tags keyValuesFlag
+	fs.Var(&metrics, "metric", "metric value, as key=value (repeatable)")
+	fs.Var(&params, "param", "param, as key=value (repeatable)")
+	fs.Var(&tags, "tag", "tag, as key=value (repeatable)")
+	step := fs.Int64("step", 0, "step of the metric values")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	data := &mlflow.RunData{}
+	timestamp := time.Now().UnixMilli()
+	for _, key := range sorted.Keys(metrics) {
+		value, err := strconv.ParseFloat(metrics[key], 64)
+		if err != nil {
+			return fmt.Errorf("metric %s: %q is not a number", key, metrics[key])
+		}
+		data.Metrics = append(data.Metrics, &mlflow.Metric{Key: key, Value: value, Timestamp: timestamp, Step: *step})
+	}
+	for _, key := range sorted.Keys(params) {
+		data.Params = append(data.Params, &mlflow.Param{Key: key, Value: params[key]})
+	}
+	for _, key := range sorted.Keys(tags) {
+		data.Tags = append(data.Tags, &mlflow.RunTag{Key: key, Value: tags[key]})
+	}
+	if len(data.Metrics)+len(data.Params)+len(data.Tags) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	return a.client.Runs.LogBatch(ctx, args[0], data)
+}
+
+func endRun(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	status := fs.String("status", string(mlflow.RunStatusFinished), "status of the run: FINISHED, FAILED or KILLED")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	s := mlflow.RunStatus(strings.ToUpper(*status))
+	switch s {
+	case mlflow.RunStatusFinished, mlflow.RunStatusFailed, mlflow.RunStatusKilled:
+	default:
+		return fmt.Errorf("unknown status %q, expected FINISHED, FAILED or KILLED", *status)
+	}
+
+	_, err = a.client.Runs.Update(ctx, args[0], "", s, time.Now().UnixMilli())
+	return err
+}
@@ -0,0 +1,165 @@
+// Package dataset builds the MLflow datasets logged with Runs.LogInputs from Go data, with
+// their schema, profile and digest.
+//
+//	d, err := dataset.FromSlice("train", rows)
+//	err = client.Runs.LogInputs(ctx, runID, []*mlflow.DatasetInput{dataset.Input(d, dataset.ContextTraining)})
+package dataset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// The tag of dataset inputs holding the context the dataset was used in, and its usual values.
+const (
+	TagContext        = "mlflow.data.context"
+	ContextTraining   = "training"
+	ContextValidation = "validation"
+	ContextEvaluation = "eval"
+)
+
+// SourceTypeCode is the source type of datasets built in code.
+const SourceTypeCode = "code"
+
+// Table is tabular data exposing its records with a header record, such as a gota DataFrame.
+type Table interface {
+	Records() [][]string
+}
+
+type profile struct {
+	NumRows     int `json:"num_rows"`
+	NumElements int `json:"num_elements"`
+}
+
+// FromSlice builds a dataset from a slice of records, such as structs, with the schema
+// inferred by mlflow.InferSchema.
+func FromSlice(name string, rows any) (*mlflow.Dataset, error) {
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("dataset: expected a slice, got %T", rows)
+	}
+
+	schema, err := mlflow.InferSchema(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	width := len(schema.Columns)
+	if width == 0 {
+		width = 1
+	}
+
+	return build(name, schema, rows, v.Len(), width)
+}
+
+// FromRecords builds a dataset from CSV-like records, the first record being the header. The
+// type of each column is inferred from its values: long, double or boolean if all the values
+// parse as such, string otherwise; columns with empty values are optional.
+func FromRecords(name string, records [][]string) (*mlflow.Dataset, error) {
+	if len(records) == 0 {
+		return nil, errors.New("dataset: no header record")
+	}
+	header, rows := records[0], records[1:]
+
+	schema := &mlflow.Schema{}
+	for i, col := range header {
+		spec := &mlflow.ColSpec{Name: col, Type: mlflow.DataTypeString, Required: true}
+		var values []string
+		for j, row := range rows {
+			if len(row) != len(header) {
+				return nil, fmt.Errorf("dataset: record %d has %d fields, expected %d", j+1, len(row), len(header))
+			}
+			if row[i] == "" {
+				spec.Required = false
+				continue
+			}
+			values = append(values, row[i])
+		}
+		if len(values) > 0 {
+			spec.Type = inferType(values)
+		}
+		schema.Columns = append(schema.Columns, spec)
+	}
+
+	return build(name, schema, records, len(rows), len(header))
+}
+
+// FromTable builds a dataset from a table, see FromRecords.
+func FromTable(name string, t Table) (*mlflow.Dataset, error) {
+	return FromRecords(name, t.Records())
+}
+
+// Input returns the input of the dataset used in the given context, such as ContextTraining,
+// to be logged with Runs.LogInputs.
+func Input(d *mlflow.Dataset, context string) *mlflow.DatasetInput {
+	input := &mlflow.DatasetInput{Dataset: d}
+	if context != "" {
+		input.Tags = []*mlflow.InputTag{{Key: TagContext, Value: context}}
+	}
+	return input
+}
+
+func inferType(values []string) mlflow.DataType {
+	for _, t := range []struct {
+		typ   mlflow.DataType
+		parse func(string) error
+	}{
+		{mlflow.DataTypeLong, func(s string) error { _, err := strconv.ParseInt(s, 10, 64); return err }},
+		{mlflow.DataTypeDouble, func(s string) error { _, err := strconv.ParseFloat(s, 64); return err }},
+		{mlflow.DataTypeBoolean, func(s string) error { _, err := strconv.ParseBool(s); return err }},
+	} {
+		ok := true
+		for _, v := range values {
+			if t.parse(v) != nil {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return t.typ
+		}
+	}
+
+	return mlflow.DataTypeString
+}
+
+// build returns the dataset of data, digested with its schema.
+func build(name string, schema *mlflow.Schema, data any, rows, width int) (*mlflow.Dataset, error) {
+	key := "mlflow_colspec"
+	if len(schema.Tensors) > 0 {
+		key = "mlflow_tensorspec"
+	}
+	schemaJSON, err := json.Marshal(map[string]*mlflow.Schema{key: schema})
+	if err != nil {
+		return nil, err
+	}
+
+	profileJSON, err := json.Marshal(&profile{NumRows: rows, NumElements: rows * width})
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	h.Write(schemaJSON)
+	h.Write(content)
+
+	return &mlflow.Dataset{
+		Name:       name,
+		Digest:     hex.EncodeToString(h.Sum(nil))[:8],
+		SourceType: SourceTypeCode,
+		Source:     "{}",
+		Schema:     string(schemaJSON),
+		Profile:    string(profileJSON),
+	}, nil
+}
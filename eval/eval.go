@@ -0,0 +1,346 @@
+// Package eval evaluates models on datasets and logs the results to MLflow runs, as
+// mlflow.evaluate does: it calls a prediction function on every example, scores the
+// predictions with metrics and judges, such as LLM judges, and logs a table of the examples
+// along with the aggregated metrics.
+//
+//	res, err := eval.Evaluate(ctx, client, runID, examples, predict, &eval.Config{
+//		Metrics: []*eval.Metric{eval.ExactMatch},
+//		Dataset: d,
+//	})
+package eval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/codeocean/go-mlflow/dataset"
+	"github.com/codeocean/go-mlflow/internal/concurrency"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// TableFile is the default artifact of the table of the evaluated examples, the one the
+// MLflow UI shows in its evaluation view.
+const TableFile = "eval_results_table.json"
+
+// Example is an example of a dataset, the inputs of a prediction and its expected value.
+type Example struct {
+	Inputs map[string]any
+	Target any
+}
+
+// PredictFunc returns the prediction of the model for the inputs of an example. It is called
+// concurrently when Config.Concurrency is greater than one.
+type PredictFunc func(ctx context.Context, inputs map[string]any) (any, error)
+
+// Metric scores predictions against their targets and aggregates the scores of the examples.
+type Metric struct {
+	Name  string
+	Score func(prediction, target any) (float64, error)
+	// Aggregate aggregates the scores of the examples, their mean if nil.
+	Aggregate func(scores []float64) float64
+}
+
+// Builtin metrics. The error metrics take numeric predictions and targets.
+var (
+	ExactMatch = &Metric{
+		Name: "exact_match",
+		Score: func(prediction, target any) (float64, error) {
+			if reflect.DeepEqual(prediction, target) || fmt.Sprint(prediction) == fmt.Sprint(target) {
+				return 1, nil
+			}
+			return 0, nil
+		},
+	}
+	MeanAbsoluteError = &Metric{
+		Name: "mean_absolute_error",
+		Score: numericScore(func(prediction, target float64) float64 {
+			return math.Abs(prediction - target)
+		}),
+	}
+	MeanSquaredError = &Metric{
+		Name: "mean_squared_error",
+		Score: numericScore(func(prediction, target float64) float64 {
+			return (prediction - target) * (prediction - target)
+		}),
+	}
+	RootMeanSquaredError = &Metric{
+		Name:  "root_mean_squared_error",
+		Score: MeanSquaredError.Score,
+		Aggregate: func(scores []float64) float64 {
+			return math.Sqrt(mean(scores))
+		},
+	}
+)
+
+// Config configures an evaluation.
+type Config struct {
+	Metrics []*Metric
+	// Judges judge the predictions, which are then logged as traces of the run holding the
+	// judgments as feedbacks.
+	Judges []*Judge
+	// Dataset is the dataset of the examples, logged as an input of the run in the
+	// dataset.ContextEvaluation context. The aggregated metrics are logged for it.
+	Dataset *mlflow.Dataset
+	// ModelID is the ID of a logged model the aggregated metrics are also logged for.
+	ModelID string
+	// TableFile is the artifact of the table of the evaluated examples, TableFile if empty.
+	TableFile string
+	// Concurrency is the number of examples predicted or judged concurrently, one if zero.
+	Concurrency int
+}
+
+// Row is an evaluated example.
+type Row struct {
+	*Example
+	Prediction any
+	// Error is the error of the prediction, the example is then left out of the aggregated
+	// metrics and judged.
+	Error  error
+	Scores map[string]float64
+	// ScoreErrors are the errors of the metrics which failed to score the example, by metric
+	// name, the example being left out of their aggregated metrics only.
+	ScoreErrors map[string]error
+	// Judgments are the judgments of the prediction by judge name, logged as feedbacks on the
+	// trace TraceID.
+	Judgments map[string]*Judgment
+	TraceID   string
+
+	start, end time.Time
+}
+
+// Result is the result of an evaluation.
+type Result struct {
+	Rows []*Row
+	// Metrics are the aggregated metrics by name, not set for the metrics which could score
+	// no example.
+	Metrics map[string]float64
+}
+
+// Evaluate evaluates predict on the examples and logs the result to a run: the table of the
+// examples, with their inputs, targets, predictions, errors and scores, and the aggregated
+// metrics. Examples whose prediction or scoring fails are recorded with their error rather
+// than failing the evaluation.
+func Evaluate(ctx context.Context, client *mlflow.Client, runID string, examples []*Example, predict PredictFunc, cfg *Config) (*Result, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	res := &Result{Rows: make([]*Row, len(examples)), Metrics: map[string]float64{}}
+	err := concurrency.ForEach(ctx, len(examples), cfg.Concurrency, func(ctx context.Context, i int) error {
+		row := &Row{Example: examples[i], Scores: map[string]float64{}, ScoreErrors: map[string]error{}, Judgments: map[string]*Judgment{}}
+		row.start = time.Now()
+		row.Prediction, row.Error = predict(ctx, row.Inputs)
+		row.end = time.Now()
+		res.Rows[i] = row
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Judges) > 0 {
+		err = judgeAll(ctx, client, runID, res.Rows, cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, m := range cfg.Metrics {
+		var scores []float64
+		for _, row := range res.Rows {
+			if row.Error != nil {
+				continue
+			}
+			score, err := m.Score(row.Prediction, row.Target)
+			if err != nil {
+				row.ScoreErrors[m.Name] = fmt.Errorf("eval: metric %s: %w", m.Name, err)
+				continue
+			}
+			row.Scores[m.Name] = score
+			scores = append(scores, score)
+		}
+		if len(scores) == 0 {
+			continue
+		}
+
+		aggregate := mean
+		if m.Aggregate != nil {
+			aggregate = m.Aggregate
+		}
+		res.Metrics[m.Name] = aggregate(scores)
+	}
+	for _, j := range cfg.Judges {
+		j.aggregate(res)
+	}
+
+	if cfg.Dataset != nil {
+		err = client.Runs.LogInputs(ctx, runID, []*mlflow.DatasetInput{dataset.Input(cfg.Dataset, dataset.ContextEvaluation)})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	table := cfg.TableFile
+	if table == "" {
+		table = TableFile
+	}
+	columns, rows := res.table(cfg.Metrics, cfg.Judges)
+	err = client.Runs.LogTable(ctx, runID, table, columns, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &mlflow.RunData{}
+	timestamp := time.Now().UnixMilli()
+	for _, name := range cfg.names() {
+		value, ok := res.Metrics[name]
+		if !ok {
+			continue
+		}
+		metric := &mlflow.Metric{Key: name, Value: value, Timestamp: timestamp, ModelID: cfg.ModelID}
+		if cfg.Dataset != nil {
+			metric.DatasetName = cfg.Dataset.Name
+			metric.DatasetDigest = cfg.Dataset.Digest
+		}
+		data.Metrics = append(data.Metrics, metric)
+	}
+	if len(data.Metrics) > 0 {
+		err = client.Runs.LogBatch(ctx, runID, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// names returns the names of the metrics and judges.
+func (cfg *Config) names() []string {
+	var names []string
+	for _, m := range cfg.Metrics {
+		names = append(names, m.Name)
+	}
+	for _, j := range cfg.Judges {
+		names = append(names, j.Name)
+	}
+	return names
+}
+
+// table returns the columns and rows of the table of the evaluated examples: the inputs,
+// sorted by name, the target, the prediction, the error of the prediction, the scores and
+// errors of the metrics and the judgments.
+func (r *Result) table(metrics []*Metric, judges []*Judge) ([]string, [][]any) {
+	var inputs []string
+	seen := map[string]bool{}
+	for _, row := range r.Rows {
+		for name := range row.Inputs {
+			if !seen[name] {
+				seen[name] = true
+				inputs = append(inputs, name)
+			}
+		}
+	}
+	sort.Strings(inputs)
+
+	columns := append(append([]string(nil), inputs...), "target", "prediction", "error")
+	for _, m := range metrics {
+		columns = append(columns, m.Name+"/score", m.Name+"/error")
+	}
+	for _, j := range judges {
+		columns = append(columns, j.Name+"/value", j.Name+"/rationale")
+	}
+	if len(judges) > 0 {
+		columns = append(columns, "trace_id")
+	}
+
+	rows := make([][]any, len(r.Rows))
+	for i, row := range r.Rows {
+		values := make([]any, 0, len(columns))
+		for _, name := range inputs {
+			values = append(values, row.Inputs[name])
+		}
+
+		var msg any
+		if row.Error != nil {
+			msg = row.Error.Error()
+		}
+		values = append(values, row.Target, row.Prediction, msg)
+
+		for _, m := range metrics {
+			var score, scoreErr any
+			if s, ok := row.Scores[m.Name]; ok {
+				score = s
+			}
+			if err, ok := row.ScoreErrors[m.Name]; ok {
+				scoreErr = err.Error()
+			}
+			values = append(values, score, scoreErr)
+		}
+		for _, j := range judges {
+			var value, rationale any
+			if judgment, ok := row.Judgments[j.Name]; ok {
+				value, rationale = judgment.Value, judgment.Rationale
+			}
+			values = append(values, value, rationale)
+		}
+		if len(judges) > 0 {
+			values = append(values, row.TraceID)
+		}
+		rows[i] = values
+	}
+
+	return columns, rows
+}
+
+func mean(scores []float64) float64 {
+	var sum float64
+	for _, s := range scores {
+		sum += s
+	}
+	return sum / float64(len(scores))
+}
+
+// numericScore returns a score function of numeric predictions and targets.
+func numericScore(fn func(prediction, target float64) float64) func(prediction, target any) (float64, error) {
+	return func(prediction, target any) (float64, error) {
+		p, err := toFloat(prediction)
+		if err != nil {
+			return 0, fmt.Errorf("prediction: %w", err)
+		}
+		t, err := toFloat(target)
+		if err != nil {
+			return 0, fmt.Errorf("target: %w", err)
+		}
+		return fn(p, t), nil
+	}
+}
+
+// toFloat converts numbers and numeric strings to float64.
+func toFloat(v any) (float64, error) {
+	switch v := v.(type) {
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case fmt.Stringer:
+		// Such as json.Number.
+		return strconv.ParseFloat(v.String(), 64)
+	case nil:
+		return 0, errors.New("no value")
+	}
+
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.CanFloat():
+		return rv.Float(), nil
+	case rv.CanInt():
+		return float64(rv.Int()), nil
+	case rv.CanUint():
+		return float64(rv.Uint()), nil
+	}
+	return 0, fmt.Errorf("%T is not a number", v)
+}
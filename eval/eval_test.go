@@ -0,0 +1,64 @@
+package eval_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/codeocean/go-mlflow/eval"
+	"github.com/codeocean/go-mlflow/mlflowtest"
+)
+
+func TestEvaluateMetricErrors(t *testing.T) {
+	ctx := context.Background()
+	srv := mlflowtest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, err := client.Runs.Create(ctx, "0", "eval", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	examples := []*eval.Example{
+		{Inputs: map[string]any{"x": 1}, Target: 2},
+		{Inputs: map[string]any{"x": 2}, Target: "n/a"},
+		{Inputs: map[string]any{"x": 3}, Target: 4},
+	}
+	predict := func(ctx context.Context, inputs map[string]any) (any, error) {
+		x := inputs["x"].(int)
+		if x == 3 {
+			return nil, errors.New("timeout")
+		}
+		return x + 1, nil
+	}
+	cfg := &eval.Config{Metrics: []*eval.Metric{eval.MeanAbsoluteError, eval.ExactMatch}}
+
+	res, err := eval.Evaluate(ctx, client, run.Info.RunID, examples, predict, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row := res.Rows[1]
+	if row.Error != nil {
+		t.Errorf("got error %v for a failed metric, want nil", row.Error)
+	}
+	if row.ScoreErrors[eval.MeanAbsoluteError.Name] == nil {
+		t.Error("no error for the failed metric")
+	}
+	if _, ok := row.Scores[eval.ExactMatch.Name]; !ok {
+		t.Error("the row was not scored by the metric following the failed one")
+	}
+	if res.Rows[2].Error == nil || len(res.Rows[2].Scores) > 0 {
+		t.Errorf("got error %v and scores %v for a failed prediction", res.Rows[2].Error, res.Rows[2].Scores)
+	}
+
+	want := map[string]float64{eval.MeanAbsoluteError.Name: 0, eval.ExactMatch.Name: 0.5}
+	for name, v := range want {
+		if got, ok := res.Metrics[name]; !ok || got != v {
+			t.Errorf("got %s %v, want %v", name, got, v)
+		}
+	}
+}
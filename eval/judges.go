@@ -0,0 +1,133 @@
+package eval
+
+import (
+	"context"
+	"errors"
+
+	"github.com/codeocean/go-mlflow/internal/concurrency"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Judge judges predictions, such as an LLM judge asking a model whether a prediction answers
+// the inputs correctly.
+type Judge struct {
+	Name string
+	// Judge judges the prediction of an example. It is called concurrently when
+	// Config.Concurrency is greater than one.
+	Judge func(ctx context.Context, example *Example, prediction any) (*Judgment, error)
+	// Aggregate aggregates the scores of the judgments, their mean if nil.
+	Aggregate func(scores []float64) float64
+}
+
+// Judgment is the verdict of a judge on a prediction.
+type Judgment struct {
+	// Value is a score, a boolean or a label. Scores and booleans, as 1 or 0, are aggregated
+	// into the metric of the judge.
+	Value     any
+	Rationale string
+}
+
+// judgeErrorCode is the error code of the feedbacks of the judges which failed.
+const judgeErrorCode = "JUDGE_ERROR"
+
+// judgeAll logs the examples as traces of the run and judges their predictions, logging the
+// judgments as feedbacks on the traces.
+func judgeAll(ctx context.Context, client *mlflow.Client, runID string, rows []*Row, cfg *Config) error {
+	run, err := client.Runs.Get(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	return concurrency.ForEach(ctx, len(rows), cfg.Concurrency, func(ctx context.Context, i int) error {
+		row := rows[i]
+
+		span := &mlflow.Span{
+			Name:       "predict",
+			Context:    mlflow.NewSpanContext(""),
+			StartTime:  row.start.UnixNano(),
+			EndTime:    row.end.UnixNano(),
+			StatusCode: mlflow.SpanStatusOK,
+			Attributes: map[string]any{
+				mlflow.SpanAttributeType:    mlflow.SpanTypeChain,
+				mlflow.SpanAttributeInputs:  row.Inputs,
+				mlflow.SpanAttributeOutputs: row.Prediction,
+			},
+		}
+		if row.Error != nil {
+			span.StatusCode = mlflow.SpanStatusError
+			span.StatusMessage = row.Error.Error()
+		}
+
+		info, err := client.Traces.LogForRun(ctx, run.Info, &mlflow.TraceData{Spans: []*mlflow.Span{span}})
+		if err != nil {
+			return err
+		}
+		row.TraceID = info.RequestID
+
+		if row.Error != nil {
+			return nil
+		}
+
+		for _, j := range cfg.Judges {
+			a := &mlflow.Assessment{
+				AssessmentName: j.Name,
+				TraceID:        info.RequestID,
+				Source:         &mlflow.AssessmentSource{SourceType: mlflow.AssessmentSourceLLMJudge, SourceID: j.Name},
+			}
+
+			judgment, err := j.Judge(ctx, row.Example, row.Prediction)
+			if err == nil && judgment == nil {
+				err = errors.New("no judgment")
+			}
+			if err != nil {
+				a.Feedback = &mlflow.Feedback{Error: &mlflow.AssessmentError{ErrorCode: judgeErrorCode, ErrorMessage: err.Error()}}
+			} else {
+				a.Feedback = &mlflow.Feedback{Value: judgment.Value}
+				a.Rationale = judgment.Rationale
+				row.Judgments[j.Name] = judgment
+			}
+
+			_, err = client.Traces.CreateAssessment(ctx, a)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// aggregate sets the metric of the judge from the scores of its judgments.
+func (j *Judge) aggregate(res *Result) {
+	var scores []float64
+	for _, row := range res.Rows {
+		judgment, ok := row.Judgments[j.Name]
+		if !ok {
+			continue
+		}
+		if b, ok := judgment.Value.(bool); ok {
+			if b {
+				scores = append(scores, 1)
+			} else {
+				scores = append(scores, 0)
+			}
+			continue
+		}
+		if _, ok := judgment.Value.(string); ok {
+			// A label, even a numeric one.
+			continue
+		}
+		if score, err := toFloat(judgment.Value); err == nil {
+			scores = append(scores, score)
+		}
+	}
+	if len(scores) == 0 {
+		return
+	}
+
+	aggregate := mean
+	if j.Aggregate != nil {
+		aggregate = j.Aggregate
+	}
+	res.Metrics[j.Name] = aggregate(scores)
+}
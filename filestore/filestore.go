@@ -0,0 +1,366 @@
+// Package filestore reads the experiments, runs and metrics of a local mlruns directory, as
+// written by MLflow's file store, without a tracking server, for offline analysis and tests.
+//
+// NewClient returns a client serving the read APIs of the Runs, Experiments, Metrics and
+// Artifacts services from the directory:
+//
+//	client, err := filestore.NewClient("mlruns")
+//	runs, err := client.Runs.SearchAll(ctx, &mlflow.RunSearchOptions{
+//		ExperimentIDs: []string{"0"},
+//		Filter:        "metrics.rmse < 0.5",
+//	})
+//
+// The other APIs fail with an ENDPOINT_NOT_FOUND error. Search filters are conjunctions of
+// comparisons of attributes, metrics, params and tags; dataset filters are not supported.
+package filestore
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/codeocean/go-mlflow/internal/localpath"
+	"github.com/codeocean/go-mlflow/internal/search"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Default maximum numbers of results of searches.
+const (
+	defaultMaxExperiments = 1000
+	defaultMaxRuns        = 1000
+)
+
+const apiPrefix = "/api/2.0/mlflow/"
+
+// NewClient returns a client reading the file store at dir, with a repository of the local
+// artifacts, see Factory. opts may configure the repositories of the artifacts stored
+// elsewhere.
+func NewClient(dir string, opts ...mlflow.ClientOption) (*mlflow.Client, error) {
+	httpClient := &http.Client{Transport: &handlerTransport{handler: NewHandler(dir)}}
+	opts = append([]mlflow.ClientOption{mlflow.WithArtifactRepository("file", Factory())}, opts...)
+	return mlflow.NewClient(httpClient, "http://filestore/", opts...)
+}
+
+// handlerTransport serves requests with a handler, in process.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t *handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+
+	rec := httptest.NewRecorder()
+	t.handler.ServeHTTP(rec, req)
+	return rec.Result(), nil
+}
+
+// NewHandler returns a handler serving the read endpoints of the REST API of a tracking
+// server from the file store at dir. The artifact locations on the local file system are
+// reported as file:// URIs of the directories of the store, wherever it was written.
+func NewHandler(dir string) http.Handler {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	s := &store{dir: dir}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+apiPrefix+"experiments/get", handle(s.getExperiment))
+	mux.HandleFunc("GET "+apiPrefix+"experiments/get-by-name", handle(s.getExperimentByName))
+	mux.HandleFunc("POST "+apiPrefix+"experiments/search", handle(s.searchExperiments))
+	mux.HandleFunc("GET "+apiPrefix+"runs/get", handle(s.getRun))
+	mux.HandleFunc("POST "+apiPrefix+"runs/search", handle(s.searchRuns))
+	mux.HandleFunc("GET "+apiPrefix+"metrics/get-history", handle(s.getMetricHistory))
+	mux.HandleFunc("GET "+apiPrefix+"artifacts/list", handle(s.listArtifacts))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, &mlflow.Error{
+			StatusCode: http.StatusNotFound,
+			ErrorCode:  "ENDPOINT_NOT_FOUND",
+			Message:    r.Method + " " + r.URL.Path + " is not supported by the file store",
+		})
+	})
+	return mux
+}
+
+func handle(f func(r *http.Request) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := f(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.Header().Set("content-type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*mlflow.Error)
+	if !ok {
+		e = &mlflow.Error{StatusCode: http.StatusInternalServerError, ErrorCode: "INTERNAL_ERROR", Message: err.Error()}
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(e.StatusCode)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error_code": e.ErrorCode, "message": e.Message})
+}
+
+// decode decodes the JSON body of a request.
+func decode(r *http.Request, v any) error {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil && err != io.EOF {
+		return errInvalid("invalid request body: %v", err)
+	}
+	return nil
+}
+
+// maxResults parses the max_results query parameter.
+func maxResults(q url.Values) (int, error) {
+	s := q.Get("max_results")
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errInvalid("invalid max_results %q", s)
+	}
+	return n, nil
+}
+
+// matchesViewType reports whether an entity in a lifecycle stage is returned for a view
+// type, ACTIVE_ONLY if empty.
+func matchesViewType(stage mlflow.LifecycleStage, viewType mlflow.ViewType) bool {
+	switch viewType {
+	case mlflow.ViewTypeAll:
+		return true
+	case mlflow.ViewTypeDeletedOnly:
+		return stage == mlflow.LifecycleStageDeleted
+	}
+	return stage != mlflow.LifecycleStageDeleted
+}
+
+func (s *store) getExperiment(r *http.Request) (any, error) {
+	e, err := s.experiment(r.URL.Query().Get("experiment_id"))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"experiment": e}, nil
+}
+
+func (s *store) getExperimentByName(r *http.Request) (any, error) {
+	name := r.URL.Query().Get("experiment_name")
+	experiments, err := s.experiments()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range experiments {
+		if e.Name == name {
+			return map[string]any{"experiment": e}, nil
+		}
+	}
+	return nil, errNotFound("Could not find experiment with name '%s'", name)
+}
+
+func (s *store) searchExperiments(r *http.Request) (any, error) {
+	var req mlflow.ExperimentsSearchOptions
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, order, err := parseSearch(req.Filter, req.OrderBy, "creation_time DESC")
+	if err != nil {
+		return nil, err
+	}
+	order = append(order, &search.OrderKey{Kind: search.KindAttribute, Key: "experiment_id"})
+
+	experiments, err := s.experiments()
+	if err != nil {
+		return nil, err
+	}
+	matched := experiments[:0]
+	for _, e := range experiments {
+		if matchesViewType(e.LifecycleStage, req.ViewType) && filter.Match(search.ExperimentLookup(e)) {
+			matched = append(matched, e)
+		}
+	}
+	experiments = matched
+	sort.SliceStable(experiments, func(i, j int) bool {
+		return order.Compare(search.ExperimentLookup(experiments[i]), search.ExperimentLookup(experiments[j])) < 0
+	})
+
+	page, token, err := paginate(experiments, req.PageToken, int(req.MaxResults), defaultMaxExperiments)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"experiments": page, "next_page_token": token}, nil
+}
+
+func (s *store) getRun(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	id := q.Get("run_id")
+	if id == "" {
+		id = q.Get("run_uuid")
+	}
+
+	run, err := s.run(id)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"run": run}, nil
+}
+
+func (s *store) searchRuns(r *http.Request) (any, error) {
+	var req mlflow.RunSearchOptions
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, order, err := parseSearch(req.Filter, req.OrderBy, "start_time DESC")
+	if err != nil {
+		return nil, err
+	}
+	order = append(order, &search.OrderKey{Kind: search.KindAttribute, Key: "run_id"})
+
+	var runs []*mlflow.Run
+	for _, id := range req.ExperimentIDs {
+		dir, err := s.experimentDir(id)
+		if mlflow.IsResourceDoesNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		dirs, err := s.runDirs(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range dirs {
+			run, err := s.readRun(dir)
+			if err != nil {
+				return nil, err
+			}
+			if matchesViewType(run.Info.LifecycleStage, req.RunViewType) && filter.Match(search.RunLookup(run)) {
+				runs = append(runs, run)
+			}
+		}
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return order.Compare(search.RunLookup(runs[i]), search.RunLookup(runs[j])) < 0
+	})
+
+	page, token, err := paginate(runs, req.PageToken, int(req.MaxResults), defaultMaxRuns)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"runs": page, "next_page_token": token}, nil
+}
+
+func (s *store) getMetricHistory(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	id := q.Get("run_id")
+	if id == "" {
+		id = q.Get("run_uuid")
+	}
+	key := q.Get("metric_key")
+	if key == "" {
+		return nil, errInvalid("missing metric_key")
+	}
+	n, err := maxResults(q)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.metricHistory(id, key)
+	if err != nil {
+		return nil, err
+	}
+
+	page, token, err := paginate(history, q.Get("page_token"), n, 0)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"metrics": page, "next_page_token": token}, nil
+}
+
+func (s *store) listArtifacts(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	id := q.Get("run_id")
+	if id == "" {
+		id = q.Get("run_uuid")
+	}
+
+	run, err := s.run(id)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := localPath(run.Info.ArtifactUri)
+	if err != nil {
+		return nil, errInvalid("the artifacts of run %s are not stored locally", id)
+	}
+	p := strings.Trim(q.Get("path"), "/")
+	if p != "" && !localpath.IsLocal(filepath.FromSlash(p)) {
+		return nil, errInvalid("invalid artifact path %q", p)
+	}
+
+	files := []*mlflow.FileInfo{}
+	entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(p)))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, e := range entries {
+		f := &mlflow.FileInfo{Path: path.Join(p, e.Name()), IsDir: e.IsDir()}
+		if !e.IsDir() {
+			fi, err := e.Info()
+			if err != nil {
+				return nil, err
+			}
+			f.FileSize = fi.Size()
+		}
+		files = append(files, f)
+	}
+	return map[string]any{"root_uri": run.Info.ArtifactUri, "files": files}, nil
+}
+
+// parseSearch parses the filter and order by expressions of a search, ordering by
+// defaultOrder if orderBy is empty.
+func parseSearch(filter string, orderBy []string, defaultOrder string) (search.Filter, search.Order, error) {
+	f, err := search.ParseFilter(filter)
+	if err != nil {
+		return nil, nil, errInvalid("%v", err)
+	}
+	if len(orderBy) == 0 {
+		orderBy = []string{defaultOrder}
+	}
+	o, err := search.ParseOrder(orderBy)
+	if err != nil {
+		return nil, nil, errInvalid("%v", err)
+	}
+	return f, o, nil
+}
+
+// paginate returns a page of items, of defaultMax items if maxResults is zero.
+func paginate[T any](items []T, token string, maxResults, defaultMax int) ([]T, string, error) {
+	if maxResults == 0 {
+		maxResults = defaultMax
+	}
+	page, next, err := search.Page(items, token, maxResults)
+	if err != nil {
+		return nil, "", errInvalid("%v", err)
+	}
+	return page, next, nil
+}
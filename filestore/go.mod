@@ -0,0 +1,8 @@
+module github.com/codeocean/go-mlflow/filestore
+
+go 1.19
+
+require (
+	github.com/codeocean/go-mlflow v0.0.0-20261016211053-944b9ed880a5
+	gopkg.in/yaml.v3 v3.0.1
+)
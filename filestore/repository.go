@@ -0,0 +1,152 @@
+package filestore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/codeocean/go-mlflow/internal/localpath"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Repository accesses the artifacts below a directory of the local file system.
+type Repository struct {
+	root string
+}
+
+// NewRepository returns the repository of the artifacts below rootURI, a file:// URI or a
+// local path.
+func NewRepository(rootURI string) (*Repository, error) {
+	root, err := localPath(rootURI)
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{root: root}, nil
+}
+
+// Factory returns a factory of repositories, to be registered with
+// mlflow.WithArtifactRepository for the "file" scheme.
+func Factory() mlflow.ArtifactRepositoryFactory {
+	return func(ctx context.Context, rootURI string) (mlflow.ArtifactRepository, error) {
+		return NewRepository(rootURI)
+	}
+}
+
+// localPath returns the path of a file:// URI or local path.
+func localPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case u.Scheme == "file" && (u.Host == "" || u.Host == "localhost"):
+		return filepath.FromSlash(u.Path), nil
+	case len(u.Scheme) <= 1:
+		// No scheme, or a Windows drive letter.
+		return uri, nil
+	}
+	return "", fmt.Errorf("filestore: %q is not a local URI", uri)
+}
+
+func (r *Repository) Get(ctx context.Context, path string, w io.Writer) error {
+	name, err := r.local(path)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+func (r *Repository) Put(ctx context.Context, path string, body io.Reader) error {
+	name, err := r.local(path)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(name), 0o755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(f, body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (r *Repository) List(ctx context.Context, p string) ([]*mlflow.FileInfo, error) {
+	name, err := r.local(p)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(name)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*mlflow.FileInfo
+	for _, e := range entries {
+		fi, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, fileInfo(path.Join(strings.Trim(p, "/"), e.Name()), fi))
+	}
+	return files, nil
+}
+
+func (r *Repository) Stat(ctx context.Context, path string) (*mlflow.FileInfo, error) {
+	name, err := r.local(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	return fileInfo(strings.Trim(path, "/"), fi), nil
+}
+
+func fileInfo(path string, fi os.FileInfo) *mlflow.FileInfo {
+	f := &mlflow.FileInfo{Path: path, IsDir: fi.IsDir(), LastModified: fi.ModTime()}
+	if !fi.IsDir() {
+		f.FileSize = fi.Size()
+	}
+	return f
+}
+
+// local returns the name of the file of the artifact at path, which must be below the root.
+func (r *Repository) local(path string) (string, error) {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return r.root, nil
+	}
+	if !localpath.IsLocal(filepath.FromSlash(path)) {
+		return "", fmt.Errorf("filestore: invalid artifact path %q", path)
+	}
+	return filepath.Join(r.root, filepath.FromSlash(path)), nil
+}
@@ -0,0 +1,415 @@
+package filestore
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/codeocean/go-mlflow/internal/localpath"
+	"github.com/codeocean/go-mlflow/internal/sorted"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+const (
+	metaFile = "meta.yaml"
+	trashDir = ".trash"
+)
+
+// Directories of a run.
+const (
+	metricsDir   = "metrics"
+	paramsDir    = "params"
+	tagsDir      = "tags"
+	artifactsDir = "artifacts"
+)
+
+// runStatuses maps the statuses of the meta.yaml of runs to run statuses.
+var runStatuses = map[int]mlflow.RunStatus{
+	1: mlflow.RunStatusRunning,
+	2: mlflow.RunStatusScheduled,
+	3: mlflow.RunStatusFinished,
+	4: mlflow.RunStatusFailed,
+	5: mlflow.RunStatusKilled,
+}
+
+type experimentMeta struct {
+	ExperimentID     string `yaml:"experiment_id"`
+	Name             string `yaml:"name"`
+	ArtifactLocation string `yaml:"artifact_location"`
+	LifecycleStage   string `yaml:"lifecycle_stage"`
+	CreationTime     int64  `yaml:"creation_time"`
+	LastUpdateTime   int64  `yaml:"last_update_time"`
+}
+
+type runMeta struct {
+	RunID string `yaml:"run_id"`
+	// RunUUID is the run ID of the runs of older versions of MLflow.
+	RunUUID        string `yaml:"run_uuid"`
+	RunName        string `yaml:"run_name"`
+	ExperimentID   string `yaml:"experiment_id"`
+	Status         int    `yaml:"status"`
+	StartTime      int64  `yaml:"start_time"`
+	EndTime        int64  `yaml:"end_time"`
+	ArtifactURI    string `yaml:"artifact_uri"`
+	LifecycleStage string `yaml:"lifecycle_stage"`
+}
+
+// store reads the experiments and runs of a file store.
+type store struct {
+	dir string
+}
+
+// errNotFound returns the error of a missing resource.
+func errNotFound(format string, args ...any) error {
+	return &mlflow.Error{
+		StatusCode: 404,
+		ErrorCode:  mlflow.ErrorResourceDoesNotExist,
+		Message:    fmt.Sprintf(format, args...),
+	}
+}
+
+// errInvalid returns the error of an invalid request.
+func errInvalid(format string, args ...any) error {
+	return &mlflow.Error{
+		StatusCode: 400,
+		ErrorCode:  mlflow.ErrorInvalidParameterValue,
+		Message:    fmt.Sprintf(format, args...),
+	}
+}
+
+// experimentDirs returns the directories of the experiments, active and deleted.
+func (s *store) experimentDirs() ([]string, error) {
+	var dirs []string
+	for _, root := range []string{s.dir, filepath.Join(s.dir, trashDir)} {
+		entries, err := os.ReadDir(root)
+		if errors.Is(err, fs.ErrNotExist) && root != s.dir {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range entries {
+			dir := filepath.Join(root, e.Name())
+			if e.IsDir() && e.Name() != trashDir && isFile(filepath.Join(dir, metaFile)) {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs, nil
+}
+
+// experimentDir returns the directory of an experiment.
+func (s *store) experimentDir(id string) (string, error) {
+	if !localpath.IsLocal(id) || strings.ContainsAny(id, `/\`) {
+		return "", errInvalid("invalid experiment ID %q", id)
+	}
+	for _, dir := range []string{filepath.Join(s.dir, id), filepath.Join(s.dir, trashDir, id)} {
+		if isFile(filepath.Join(dir, metaFile)) {
+			return dir, nil
+		}
+	}
+	return "", errNotFound("Could not find experiment with ID %s", id)
+}
+
+func (s *store) experiments() ([]*mlflow.Experiment, error) {
+	dirs, err := s.experimentDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	var experiments []*mlflow.Experiment
+	for _, dir := range dirs {
+		e, err := s.readExperiment(dir)
+		if err != nil {
+			return nil, err
+		}
+		experiments = append(experiments, e)
+	}
+	return experiments, nil
+}
+
+func (s *store) experiment(id string) (*mlflow.Experiment, error) {
+	dir, err := s.experimentDir(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.readExperiment(dir)
+}
+
+func (s *store) readExperiment(dir string) (*mlflow.Experiment, error) {
+	var meta experimentMeta
+	err := readYAML(filepath.Join(dir, metaFile), &meta)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &mlflow.Experiment{
+		ExperimentID:     meta.ExperimentID,
+		Name:             meta.Name,
+		ArtifactLocation: localURI(meta.ArtifactLocation, dir),
+		LifecycleStage:   mlflow.LifecycleStage(meta.LifecycleStage),
+		CreationTime:     meta.CreationTime,
+		LastUpdateTime:   meta.LastUpdateTime,
+	}
+	if e.ExperimentID == "" {
+		e.ExperimentID = filepath.Base(dir)
+	}
+
+	tags, err := readValues(filepath.Join(dir, tagsDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range sorted.Keys(tags) {
+		e.Tags = append(e.Tags, &mlflow.ExperimentTag{Key: key, Value: tags[key]})
+	}
+
+	return e, nil
+}
+
+// runDirs returns the directories of the runs of an experiment.
+func (s *store) runDirs(experimentDir string) ([]string, error) {
+	entries, err := os.ReadDir(experimentDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for _, e := range entries {
+		dir := filepath.Join(experimentDir, e.Name())
+		if e.IsDir() && isFile(filepath.Join(dir, metaFile)) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs, nil
+}
+
+// runDir returns the directory of a run, found in the directories of the experiments.
+func (s *store) runDir(id string) (string, error) {
+	if !localpath.IsLocal(id) || strings.ContainsAny(id, `/\`) {
+		return "", errInvalid("invalid run ID %q", id)
+	}
+
+	dirs, err := s.experimentDirs()
+	if err != nil {
+		return "", err
+	}
+	for _, dir := range dirs {
+		runDir := filepath.Join(dir, id)
+		if isFile(filepath.Join(runDir, metaFile)) {
+			return runDir, nil
+		}
+	}
+	return "", errNotFound("Run '%s' not found", id)
+}
+
+func (s *store) run(id string) (*mlflow.Run, error) {
+	dir, err := s.runDir(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.readRun(dir)
+}
+
+func (s *store) readRun(dir string) (*mlflow.Run, error) {
+	var meta runMeta
+	err := readYAML(filepath.Join(dir, metaFile), &meta)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &mlflow.RunInfo{
+		RunID:          meta.RunID,
+		RunName:        meta.RunName,
+		ExperimentID:   meta.ExperimentID,
+		Status:         runStatuses[meta.Status],
+		StartTime:      meta.StartTime,
+		EndTime:        meta.EndTime,
+		ArtifactUri:    localURI(meta.ArtifactURI, filepath.Join(dir, artifactsDir)),
+		LifecycleStage: mlflow.LifecycleStage(meta.LifecycleStage),
+	}
+	if info.RunID == "" {
+		info.RunID = meta.RunUUID
+	}
+	if info.RunID == "" {
+		info.RunID = filepath.Base(dir)
+	}
+
+	data := &mlflow.RunData{}
+
+	params, err := readValues(filepath.Join(dir, paramsDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range sorted.Keys(params) {
+		data.Params = append(data.Params, &mlflow.Param{Key: key, Value: params[key]})
+	}
+
+	tags, err := readValues(filepath.Join(dir, tagsDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range sorted.Keys(tags) {
+		data.Tags = append(data.Tags, &mlflow.RunTag{Key: key, Value: tags[key]})
+	}
+	if info.RunName == "" {
+		info.RunName = tags["mlflow.runName"]
+	}
+
+	keys, err := readKeys(filepath.Join(dir, metricsDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		history, err := readMetric(filepath.Join(dir, metricsDir), key)
+		if err != nil {
+			return nil, err
+		}
+		if latest := latestMetric(history); latest != nil {
+			data.Metrics = append(data.Metrics, latest)
+		}
+	}
+
+	return &mlflow.Run{Info: info, Data: data}, nil
+}
+
+// metricHistory returns the history of a metric of a run.
+func (s *store) metricHistory(runID, key string) ([]*mlflow.Metric, error) {
+	dir, err := s.runDir(runID)
+	if err != nil {
+		return nil, err
+	}
+	if !localpath.IsLocal(filepath.FromSlash(key)) {
+		return nil, errInvalid("invalid metric key %q", key)
+	}
+
+	history, err := readMetric(filepath.Join(dir, metricsDir), key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return history, err
+}
+
+// readMetric reads the history of a metric, one "<timestamp> <value> <step>" line per value.
+func readMetric(dir, key string) ([]*mlflow.Metric, error) {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(key)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var history []*mlflow.Metric
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("filestore: invalid value of metric %s: %q", key, scanner.Text())
+		}
+
+		m := &mlflow.Metric{Key: key}
+		m.Timestamp, err = strconv.ParseInt(fields[0], 10, 64)
+		if err == nil {
+			m.Value, err = strconv.ParseFloat(fields[1], 64)
+		}
+		if err == nil && len(fields) > 2 {
+			m.Step, err = strconv.ParseInt(fields[2], 10, 64)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("filestore: invalid value of metric %s: %w", key, err)
+		}
+		history = append(history, m)
+	}
+	return history, scanner.Err()
+}
+
+// latestMetric returns the value of a metric at the highest step, the last logged one for
+// the step.
+func latestMetric(history []*mlflow.Metric) *mlflow.Metric {
+	var latest *mlflow.Metric
+	for _, m := range history {
+		if latest == nil || m.Step > latest.Step || (m.Step == latest.Step && m.Timestamp >= latest.Timestamp) {
+			latest = m
+		}
+	}
+	return latest
+}
+
+// readKeys returns the keys of the files of a directory of params, tags or metrics, where
+// the keys containing slashes are nested directories.
+func readKeys(dir string) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && p == dir {
+			return nil
+		}
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, filepath.ToSlash(rel))
+		return nil
+	})
+	return keys, err
+}
+
+// readValues reads the values of a directory of params or tags by key.
+func readValues(dir string) (map[string]string, error) {
+	keys, err := readKeys(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	for _, key := range keys {
+		b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(key)))
+		if err != nil {
+			return nil, err
+		}
+		values[key] = string(b)
+	}
+	return values, nil
+}
+
+func readYAML(name string, v any) error {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	err = yaml.Unmarshal(b, v)
+	if err != nil {
+		return fmt.Errorf("filestore: %s: %w", name, err)
+	}
+	return nil
+}
+
+func isFile(name string) bool {
+	fi, err := os.Stat(name)
+	return err == nil && fi.Mode().IsRegular()
+}
+
+// localURI returns the file:// URI of dir for the local artifact locations, which may point
+// to where the store was written rather than where it is read, and other URIs as is.
+func localURI(uri, dir string) string {
+	u, err := url.Parse(uri)
+	if err == nil && len(u.Scheme) > 1 && u.Scheme != "file" {
+		return uri
+	}
+
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(dir)}).String()
+}
@@ -0,0 +1,46 @@
+// Package gcpsecrets provides MLflow credentials stored in Google Cloud Secret Manager, see
+// mlflow.ParseCredentials for the format of the secret.
+//
+//	svc, err := secretmanager.NewService(ctx)
+//	client, err := mlflow.NewClient(nil, "https://mlflow.example.com",
+//		mlflow.WithCredentialsProvider(gcpsecrets.NewCredentialsProvider(
+//			svc, "projects/my-project/secrets/mlflow/versions/latest", 5*time.Minute)))
+package gcpsecrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/secretmanager/v1"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// NewCredentialsProvider returns a provider of the credentials stored in the secret version
+// name, such as projects/my-project/secrets/mlflow/versions/latest, fetched again once they
+// are older than ttl.
+func NewCredentialsProvider(svc *secretmanager.Service, name string, ttl time.Duration) mlflow.CredentialsProvider {
+	return mlflow.CachingCredentialsProvider(mlflow.CredentialsProviderFunc(func(ctx context.Context) (*mlflow.Credentials, error) {
+		res, err := svc.Projects.Secrets.Versions.Access(name).Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		if res.Payload == nil {
+			return nil, fmt.Errorf("gcpsecrets: secret %s has no payload", name)
+		}
+
+		secret, err := base64.StdEncoding.DecodeString(res.Payload.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		credentials, err := mlflow.ParseCredentials(secret)
+		if err != nil {
+			return nil, fmt.Errorf("gcpsecrets: secret %s: %w", name, err)
+		}
+
+		return credentials, nil
+	}), ttl)
+}
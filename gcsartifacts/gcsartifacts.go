@@ -0,0 +1,148 @@
+// Package gcsartifacts implements an MLflow artifact repository for artifacts stored in
+// Google Cloud Storage under gs:// URIs.
+//
+//	gcs, err := storage.NewClient(ctx)
+//	client, err := mlflow.NewClient(nil, "http://localhost:5000",
+//		mlflow.WithArtifactRepository("gs", gcsartifacts.Factory(gcs)))
+package gcsartifacts
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Repository accesses the artifacts below a gs://bucket/prefix URI.
+type Repository struct {
+	bucket *storage.BucketHandle
+	prefix string
+}
+
+// NewRepository returns the repository of the artifacts below rootURI, a gs:// URI.
+func NewRepository(client *storage.Client, rootURI string) (*Repository, error) {
+	u, err := url.Parse(rootURI)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "gs" || u.Host == "" {
+		return nil, fmt.Errorf("gcsartifacts: invalid GCS URI %q", rootURI)
+	}
+
+	return &Repository{
+		bucket: client.Bucket(u.Host),
+		prefix: strings.Trim(u.Path, "/"),
+	}, nil
+}
+
+// Factory returns a factory of repositories using client, to be registered with
+// mlflow.WithArtifactRepository for the "gs" scheme.
+func Factory(client *storage.Client) mlflow.ArtifactRepositoryFactory {
+	return func(ctx context.Context, rootURI string) (mlflow.ArtifactRepository, error) {
+		return NewRepository(client, rootURI)
+	}
+}
+
+func (r *Repository) Get(ctx context.Context, path string, w io.Writer) error {
+	reader, err := r.bucket.Object(r.key(path)).NewReader(ctx)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(w, reader)
+	return err
+}
+
+func (r *Repository) Put(ctx context.Context, path string, body io.Reader) error {
+	writer := r.bucket.Object(r.key(path)).NewWriter(ctx)
+
+	_, err := io.Copy(writer, body)
+	if cerr := writer.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (r *Repository) List(ctx context.Context, path string) ([]*mlflow.FileInfo, error) {
+	prefix := r.key(path)
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	var files []*mlflow.FileInfo
+
+	it := r.bucket.Objects(ctx, &storage.Query{Prefix: prefix, Delimiter: "/"})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if attrs.Prefix != "" {
+			files = append(files, &mlflow.FileInfo{
+				Path:  r.relative(strings.TrimSuffix(attrs.Prefix, "/")),
+				IsDir: true,
+			})
+			continue
+		}
+		files = append(files, fileInfo(r.relative(attrs.Name), attrs))
+	}
+}
+
+func (r *Repository) Stat(ctx context.Context, path string) (*mlflow.FileInfo, error) {
+	attrs, err := r.bucket.Object(r.key(path)).Attrs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return fileInfo(strings.Trim(path, "/"), attrs), nil
+}
+
+// fileInfo returns the mlflow.FileInfo of the object at path, composite objects have no MD5.
+func fileInfo(path string, attrs *storage.ObjectAttrs) *mlflow.FileInfo {
+	f := &mlflow.FileInfo{
+		Path:         path,
+		FileSize:     attrs.Size,
+		LastModified: attrs.Updated,
+	}
+	if len(attrs.MD5) > 0 {
+		f.ContentHash = "md5:" + hex.EncodeToString(attrs.MD5)
+	}
+
+	return f
+}
+
+// key returns the object name of the artifact at path.
+func (r *Repository) key(path string) string {
+	path = strings.Trim(path, "/")
+	if r.prefix == "" {
+		return path
+	}
+	if path == "" {
+		return r.prefix
+	}
+
+	return r.prefix + "/" + path
+}
+
+// relative returns the artifact path of an object name.
+func (r *Repository) relative(name string) string {
+	if r.prefix == "" {
+		return name
+	}
+
+	return strings.TrimPrefix(strings.TrimPrefix(name, r.prefix), "/")
+}
@@ -0,0 +1,17 @@
+// Package filterstring quotes the string literals of the MLflow search syntax, for the
+// packages of this module.
+package filterstring
+
+import "strings"
+
+// Quote returns s as a string literal of the MLflow search syntax.
+func Quote(s string) string {
+	switch {
+	case !strings.Contains(s, "'"):
+		return "'" + s + "'"
+	case !strings.Contains(s, `"`):
+		return `"` + s + `"`
+	default:
+		return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+	}
+}
@@ -0,0 +1,19 @@
+// Package localpath checks the paths read from stores and servers before they are joined to
+// local directories, for the packages of this module.
+package localpath
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IsLocal reports whether p is a relative path not escaping its directory, as
+// filepath.IsLocal of Go 1.20.
+func IsLocal(p string) bool {
+	if p == "" || filepath.IsAbs(p) || filepath.VolumeName(p) != "" || os.IsPathSeparator(p[0]) {
+		return false
+	}
+	p = filepath.Clean(p)
+	return p != ".." && !strings.HasPrefix(p, ".."+string(filepath.Separator))
+}
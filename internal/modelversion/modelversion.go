@@ -0,0 +1,26 @@
+// Package modelversion orders the versions of registered models, for the packages of this
+// module.
+package modelversion
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Compare orders model versions numerically, returning -1, 0 or +1 as a is before, equal to
+// or after b. Versions that are not numbers are compared as strings.
+func Compare(a, b string) int {
+	x, errA := strconv.ParseInt(a, 10, 64)
+	y, errB := strconv.ParseInt(b, 10, 64)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
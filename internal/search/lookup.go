@@ -0,0 +1,103 @@
+package search
+
+import "github.com/codeocean/go-mlflow/mlflow"
+
+// datasetContextTag is the input tag of the context of a dataset, such as "training".
+const datasetContextTag = "mlflow.data.context"
+
+// ExperimentLookup returns the lookup of the attributes and tags of an experiment.
+func ExperimentLookup(e *mlflow.Experiment) Lookup {
+	return func(kind, key string) (any, bool) {
+		switch kind {
+		case KindAttribute:
+			switch key {
+			case "experiment_id":
+				return e.ExperimentID, true
+			case "name":
+				return e.Name, true
+			case "artifact_location":
+				return e.ArtifactLocation, true
+			case "lifecycle_stage":
+				return string(e.LifecycleStage), true
+			case "creation_time":
+				return float64(e.CreationTime), true
+			case "last_update_time":
+				return float64(e.LastUpdateTime), true
+			}
+		case KindTag:
+			for _, t := range e.Tags {
+				if t.Key == key {
+					return t.Value, true
+				}
+			}
+		}
+		return nil, false
+	}
+}
+
+// RunLookup returns the lookup of the attributes, latest metrics, params, tags and dataset
+// inputs of a run, the values of datasets being the []any of the values of the inputs.
+func RunLookup(run *mlflow.Run) Lookup {
+	return func(kind, key string) (any, bool) {
+		switch kind {
+		case KindAttribute:
+			switch key {
+			case "run_id":
+				return run.Info.RunID, true
+			case "run_name":
+				return run.Info.RunName, true
+			case "experiment_id":
+				return run.Info.ExperimentID, true
+			case "status":
+				return string(run.Info.Status), true
+			case "start_time", "created":
+				return float64(run.Info.StartTime), true
+			case "end_time":
+				return float64(run.Info.EndTime), true
+			case "artifact_uri":
+				return run.Info.ArtifactUri, true
+			case "lifecycle_stage":
+				return string(run.Info.LifecycleStage), true
+			}
+		case KindMetric:
+			for _, m := range run.Data.Metrics {
+				if m.Key == key {
+					return m.Value, true
+				}
+			}
+		case KindParam:
+			for _, p := range run.Data.Params {
+				if p.Key == key {
+					return p.Value, true
+				}
+			}
+		case KindTag:
+			for _, t := range run.Data.Tags {
+				if t.Key == key {
+					return t.Value, true
+				}
+			}
+		case KindDataset:
+			if run.Inputs == nil {
+				break
+			}
+			var values []any
+			for _, in := range run.Inputs.DatasetInputs {
+				switch key {
+				case "name":
+					values = append(values, in.Dataset.Name)
+				case "digest":
+					values = append(values, in.Dataset.Digest)
+				case "context":
+					for _, t := range in.Tags {
+						if t.Key == datasetContextTag {
+							values = append(values, t.Value)
+						}
+					}
+				}
+			}
+			return values, len(values) > 0
+		}
+		return nil, false
+	}
+}
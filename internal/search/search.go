@@ -0,0 +1,498 @@
+// Package search evaluates the filter and order by expressions of the MLflow search APIs
+// against entities held in memory, for the backends of this module serving the REST API
+// without a tracking server.
+//
+// Filters are conjunctions of comparisons, such as
+//
+//	metrics.rmse < 1 AND params.model = 'tree' AND attributes.status != 'FAILED'
+//
+// with the operators =, !=, <, <=, >, >=, LIKE, ILIKE, IN and NOT IN.
+package search
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Kinds of the values of an entity.
+const (
+	KindAttribute = "attributes"
+	KindMetric    = "metrics"
+	KindParam     = "params"
+	KindTag       = "tags"
+	KindDataset   = "datasets"
+)
+
+// kinds maps the prefixes of the identifiers of filters to kinds.
+var kinds = map[string]string{
+	"attribute":  KindAttribute,
+	"attributes": KindAttribute,
+	"attr":       KindAttribute,
+	"run":        KindAttribute,
+	"metric":     KindMetric,
+	"metrics":    KindMetric,
+	"param":      KindParam,
+	"params":     KindParam,
+	"parameter":  KindParam,
+	"parameters": KindParam,
+	"tag":        KindTag,
+	"tags":       KindTag,
+	"dataset":    KindDataset,
+	"datasets":   KindDataset,
+}
+
+// Lookup returns the value of an entity of a kind and key, a float64 for numeric values, a
+// string otherwise or a []any of several values, and whether the entity has it.
+type Lookup func(kind, key string) (any, bool)
+
+// Clause is a comparison of a value of an entity.
+type Clause struct {
+	Kind string
+	Key  string
+	// Op is the upper case operator.
+	Op string
+	// Value is a float64, a string, or a []any for IN and NOT IN.
+	Value any
+}
+
+// Filter is a conjunction of clauses, matching all the entities if empty.
+type Filter []*Clause
+
+// ParseFilter parses a filter expression.
+func ParseFilter(s string) (Filter, error) {
+	p := &parser{s: s}
+	var f Filter
+	for {
+		p.skipSpace()
+		if p.done() {
+			if len(f) > 0 {
+				return nil, p.errorf("expected a comparison after AND")
+			}
+			return f, nil
+		}
+
+		c, err := p.clause()
+		if err != nil {
+			return nil, err
+		}
+		f = append(f, c)
+
+		p.skipSpace()
+		if p.done() {
+			return f, nil
+		}
+		if !strings.EqualFold(p.word(), "AND") {
+			return nil, p.errorf("expected AND")
+		}
+	}
+}
+
+// Match reports whether an entity matches all the clauses of the filter.
+func (f Filter) Match(lookup Lookup) bool {
+	for _, c := range f {
+		if !c.Match(lookup) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match reports whether the value of an entity matches the clause, any of them for entities
+// with several values, such as the datasets of runs. Entities without the value match no
+// clause.
+func (c *Clause) Match(lookup Lookup) bool {
+	v, ok := lookup(c.Kind, c.Key)
+	if !ok {
+		return false
+	}
+	if values, ok := v.([]any); ok {
+		for _, v := range values {
+			if c.match(v) {
+				return true
+			}
+		}
+		return false
+	}
+	return c.match(v)
+}
+
+func (c *Clause) match(v any) bool {
+	switch c.Op {
+	case "IN", "NOT IN":
+		in := false
+		for _, x := range c.Value.([]any) {
+			if compare(c.Kind, v, x) == 0 {
+				in = true
+			}
+		}
+		return in == (c.Op == "IN")
+	case "LIKE":
+		return like(fmt.Sprint(v), fmt.Sprint(c.Value), false)
+	case "ILIKE":
+		return like(fmt.Sprint(v), fmt.Sprint(c.Value), true)
+	}
+
+	n := compare(c.Kind, v, c.Value)
+	switch c.Op {
+	case "=":
+		return n == 0
+	case "!=":
+		return n != 0
+	case "<":
+		return n < 0
+	case "<=":
+		return n <= 0
+	case ">":
+		return n > 0
+	case ">=":
+		return n >= 0
+	}
+	return false
+}
+
+// compare compares a value of an entity of a kind with another value, numerically for
+// metrics and numeric attributes, whose lookups return float64 values, and as strings
+// otherwise: params and tags are strings, where '1.10' is not '1.1' and '007' is not '7'.
+func compare(kind string, a, b any) int {
+	_, numeric := a.(float64)
+	x, okA := toFloat(a)
+	y, okB := toFloat(b)
+	if (kind == KindMetric || kind == KindAttribute && numeric) && okA && okB {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// like reports whether s matches a SQL LIKE pattern.
+func like(s, pattern string, fold bool) bool {
+	var b strings.Builder
+	b.WriteString("^")
+	if fold {
+		b.WriteString("(?i)")
+	}
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	return err == nil && re.MatchString(s)
+}
+
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("search: invalid filter %q at %d: %s", p.s, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *parser) skipSpace() {
+	for !p.done() && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// word reads a run of letters, digits, underscores, dots and dashes.
+func (p *parser) word() string {
+	p.skipSpace()
+	start := p.pos
+	for !p.done() {
+		c := p.s[p.pos]
+		if c != '_' && c != '.' && c != '-' && !unicode.IsLetter(rune(c)) && !unicode.IsDigit(rune(c)) {
+			break
+		}
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+// quoted reads a string quoted with q, where q is escaped by a backslash or doubled.
+func (p *parser) quoted() (string, error) {
+	q := p.s[p.pos]
+	p.pos++
+
+	var b strings.Builder
+	for !p.done() {
+		c := p.s[p.pos]
+		p.pos++
+		switch {
+		case c == '\\' && !p.done():
+			b.WriteByte(p.s[p.pos])
+			p.pos++
+		case c == q && p.peek() == q:
+			b.WriteByte(q)
+			p.pos++
+		case c == q:
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// identifier reads an identifier such as metrics.rmse, tags.`a b` or start_time.
+func (p *parser) identifier() (kind, key string, err error) {
+	p.skipSpace()
+	if c := p.peek(); c == '`' || c == '"' {
+		key, err = p.quoted()
+		return KindAttribute, key, err
+	}
+
+	start := p.pos
+	for !p.done() {
+		c := p.s[p.pos]
+		if c == '.' || !(c == '_' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))) {
+			break
+		}
+		p.pos++
+	}
+	prefix := p.s[start:p.pos]
+	if prefix == "" {
+		return "", "", p.errorf("expected an identifier")
+	}
+	if p.peek() != '.' {
+		return KindAttribute, prefix, nil
+	}
+	kind, ok := kinds[strings.ToLower(prefix)]
+	if !ok {
+		return "", "", p.errorf("unknown entity type %q", prefix)
+	}
+	p.pos++
+
+	if c := p.peek(); c == '`' || c == '"' {
+		key, err = p.quoted()
+		return kind, key, err
+	}
+	key = p.word()
+	if key == "" {
+		return "", "", p.errorf("expected a key")
+	}
+	return kind, key, nil
+}
+
+func (p *parser) operator() (string, error) {
+	p.skipSpace()
+	for _, op := range []string{"!=", "<>", "<=", ">=", "=", "<", ">"} {
+		if strings.HasPrefix(p.s[p.pos:], op) {
+			p.pos += len(op)
+			if op == "<>" {
+				op = "!="
+			}
+			return op, nil
+		}
+	}
+
+	op := strings.ToUpper(p.word())
+	switch op {
+	case "LIKE", "ILIKE", "IN":
+		return op, nil
+	case "NOT":
+		if strings.ToUpper(p.word()) == "IN" {
+			return "NOT IN", nil
+		}
+	}
+	return "", p.errorf("expected an operator")
+}
+
+// value reads a quoted string or a number.
+func (p *parser) value() (any, error) {
+	p.skipSpace()
+	if c := p.peek(); c == '\'' || c == '"' {
+		return p.quoted()
+	}
+
+	w := p.word()
+	f, err := strconv.ParseFloat(w, 64)
+	if err != nil {
+		return nil, p.errorf("expected a string or a number, got %q", w)
+	}
+	return f, nil
+}
+
+func (p *parser) clause() (*Clause, error) {
+	kind, key, err := p.identifier()
+	if err != nil {
+		return nil, err
+	}
+	op, err := p.operator()
+	if err != nil {
+		return nil, err
+	}
+	c := &Clause{Kind: kind, Key: key, Op: op}
+
+	if op != "IN" && op != "NOT IN" {
+		c.Value, err = p.value()
+		return c, err
+	}
+
+	p.skipSpace()
+	if p.peek() != '(' {
+		return nil, p.errorf("expected (")
+	}
+	p.pos++
+	var values []any
+	for {
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+			continue
+		case ')':
+			p.pos++
+			c.Value = values
+			return c, nil
+		}
+		return nil, p.errorf("expected , or )")
+	}
+}
+
+// OrderKey is a key entities are sorted by.
+type OrderKey struct {
+	Kind string
+	Key  string
+	Desc bool
+}
+
+// Order sorts entities by keys in turn.
+type Order []*OrderKey
+
+// ParseOrder parses order by expressions such as "metrics.rmse DESC" or "start_time".
+func ParseOrder(orderBy []string) (Order, error) {
+	var o Order
+	for _, s := range orderBy {
+		p := &parser{s: s}
+		kind, key, err := p.identifier()
+		if err != nil {
+			return nil, fmt.Errorf("search: invalid order by %q", s)
+		}
+		k := &OrderKey{Kind: kind, Key: key}
+
+		switch strings.ToUpper(p.word()) {
+		case "", "ASC":
+		case "DESC":
+			k.Desc = true
+		default:
+			return nil, fmt.Errorf("search: invalid order by %q, expected ASC or DESC", s)
+		}
+		p.skipSpace()
+		if !p.done() {
+			return nil, fmt.Errorf("search: invalid order by %q", s)
+		}
+		o = append(o, k)
+	}
+	return o, nil
+}
+
+// Compare compares two entities, the entities without a value sorting after the others.
+func (o Order) Compare(a, b Lookup) int {
+	for _, k := range o {
+		x, okA := a(k.Kind, k.Key)
+		y, okB := b(k.Kind, k.Key)
+		var n int
+		switch {
+		case !okA && !okB:
+			continue
+		case !okA:
+			return 1
+		case !okB:
+			return -1
+		default:
+			n = compare(k.Kind, x, y)
+		}
+		if k.Desc {
+			n = -n
+		}
+		if n != 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+type pageToken struct {
+	Offset int `json:"offset"`
+}
+
+// Page returns the page of items starting at the offset of token, of at most maxResults
+// items, or all the remaining ones if maxResults is not positive, and the token of the next
+// page, empty after the last one.
+func Page[T any](items []T, token string, maxResults int) ([]T, string, error) {
+	offset, err := parsePageToken(token)
+	if err != nil {
+		return nil, "", err
+	}
+	if offset > len(items) {
+		offset = len(items)
+	}
+	items = items[offset:]
+	if maxResults <= 0 || len(items) <= maxResults {
+		return items, "", nil
+	}
+
+	b, _ := json.Marshal(pageToken{Offset: offset + maxResults})
+	return items[:maxResults], base64.StdEncoding.EncodeToString(b), nil
+}
+
+func parsePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	var t pageToken
+	b, err := base64.StdEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(b, &t)
+	}
+	if err != nil || t.Offset < 0 {
+		return 0, fmt.Errorf("search: invalid page token %q", token)
+	}
+	return t.Offset, nil
+}
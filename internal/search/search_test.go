@@ -0,0 +1,88 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/codeocean/go-mlflow/internal/search"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+func testRun() *mlflow.Run {
+	return &mlflow.Run{
+		Info: &mlflow.RunInfo{RunID: "r1", RunName: "10", Status: mlflow.RunStatusFinished, StartTime: 1000},
+		Data: &mlflow.RunData{
+			Metrics: []*mlflow.Metric{{Key: "rmse", Value: 0.5}, {Key: "epochs", Value: 10}},
+			Params:  []*mlflow.Param{{Key: "id", Value: "7"}, {Key: "lr", Value: "0.10"}},
+			Tags:    []*mlflow.RunTag{{Key: "version", Value: "1.1"}},
+		},
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"metrics.rmse < 1", true},
+		{"metrics.rmse = 0.50", true},
+		{"metrics.epochs > 9", true},
+		{"metrics.epochs = '10.0'", true},
+		{"metrics.epochs IN (10, 20)", true},
+		{"attributes.start_time >= 1000", true},
+		{"attributes.start_time > 999.5", true},
+		{"attributes.status = 'FINISHED'", true},
+		{"attributes.run_name = '10.0'", false},
+		{"tags.version = '1.1'", true},
+		{"tags.version = '1.10'", false},
+		{"tags.version != '1.10'", true},
+		{"params.id = '7'", true},
+		{"params.id = '007'", false},
+		{"params.id IN ('007', '7.0')", false},
+		{"params.lr = '0.1'", false},
+		{"params.lr LIKE '0.1%'", true},
+		{"params.missing = '7'", false},
+	}
+
+	lookup := search.RunLookup(testRun())
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := search.ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.Match(lookup); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderCompare(t *testing.T) {
+	a, b := testRun(), testRun()
+	a.Data.Metrics[1].Value = 9
+	a.Data.Params[0].Value = "10"
+	b.Data.Params[0].Value = "9"
+
+	tests := []struct {
+		orderBy string
+		want    int
+	}{
+		{"metrics.epochs", -1},
+		{"metrics.epochs DESC", 1},
+		// Params are strings, "10" sorting before "9".
+		{"params.id", -1},
+		{"params.missing", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.orderBy, func(t *testing.T) {
+			o, err := search.ParseOrder([]string{tt.orderBy})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := o.Compare(search.RunLookup(a), search.RunLookup(b)); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
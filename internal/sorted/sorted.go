@@ -0,0 +1,14 @@
+// Package sorted returns the keys of maps in order, for the packages of this module.
+package sorted
+
+import "sort"
+
+// Keys returns the keys of m, sorted.
+func Keys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,488 @@
+// Package migrate copies experiments and registered models from a tracking server to
+// another: experiments with their active runs, metric histories, tags and artifacts, and
+// registered models with their versions, stages, aliases and the runs of the versions.
+//
+// Resources get new IDs on the destination, the mapping of the IDs is saved to a checkpoint
+// after every copied resource, so that an interrupted migration resumes where it stopped.
+//
+//	m, err := migrate.New(src, dst, &migrate.Config{
+//		ExperimentIDs: []string{"12"},
+//		Models:        []string{"fraud"},
+//		Checkpoint:    "migration.json",
+//	})
+//	plan, err := m.Plan(ctx) // dry run
+//	cp, err := m.Run(ctx)
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codeocean/go-mlflow/internal/filterstring"
+	"github.com/codeocean/go-mlflow/internal/modelversion"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// tagParentRunID is the tag of nested runs holding the ID of their parent run.
+const tagParentRunID = "mlflow.parentRunId"
+
+// Config selects the resources to migrate.
+type Config struct {
+	// ExperimentIDs are the source experiments copied with their active runs.
+	ExperimentIDs []string
+	// Models are the names of the registered models copied with their versions. The runs of
+	// the versions are copied too, to the copy of their experiment.
+	Models []string
+	// Checkpoint is the file the mapping of the IDs is saved to, and resumed from. The
+	// migration is not resumable if empty.
+	Checkpoint string
+}
+
+// Checkpoint maps the source resources to the destination ones.
+type Checkpoint struct {
+	// Experiments and Runs map source IDs to destination IDs.
+	Experiments map[string]string `json:"experiments"`
+	Runs        map[string]string `json:"runs"`
+	// Models lists the registered models created, or reused, on the destination.
+	Models map[string]bool `json:"models"`
+	// ModelVersions maps the source versions, as name/version, to destination versions.
+	ModelVersions map[string]string `json:"model_versions"`
+	// ReadyModelVersions lists the source versions whose destination versions are ready, in
+	// the stage of the source versions.
+	ReadyModelVersions map[string]bool `json:"ready_model_versions"`
+	// Aliases lists the registered models whose aliases were set.
+	Aliases map[string]bool `json:"aliases"`
+}
+
+type Kind string
+
+const (
+	KindExperiment      Kind = "experiment"
+	KindRun             Kind = "run"
+	KindRegisteredModel Kind = "registered_model"
+	KindModelVersion    Kind = "model_version"
+	KindAliases         Kind = "aliases"
+)
+
+type Action string
+
+const (
+	// ActionCreate creates the resource on the destination.
+	ActionCreate Action = "create"
+	// ActionReuse uses the resource of the same name existing on the destination.
+	ActionReuse Action = "reuse"
+	// ActionSkip skips the resource, copied by a previous migration.
+	ActionSkip Action = "skip"
+	// ActionResume completes the copy of the resource, created by a previous migration.
+	ActionResume Action = "resume"
+)
+
+// Step is the migration of a resource.
+type Step struct {
+	Kind   Kind
+	Action Action
+	// Source is the ID of the source resource, or the name of a registered model, or name/version
+	// for a model version.
+	Source string
+	// Name is the name of the resource, if it has one.
+	Name string
+	// Destination is the ID of the destination resource for the skipped and resumed steps.
+	Destination string
+
+	experiment *mlflow.Experiment
+	run        *mlflow.Run
+	model      *mlflow.RegisteredModel
+	version    *mlflow.ModelVersion
+}
+
+func (s *Step) String() string {
+	res := fmt.Sprintf("%s %s %s", s.Action, s.Kind, s.Source)
+	if s.Name != "" && s.Name != s.Source {
+		res += " (" + s.Name + ")"
+	}
+	if s.Destination != "" {
+		res += " -> " + s.Destination
+	}
+	return res
+}
+
+// Plan is the list of the steps of a migration, in order.
+type Plan struct {
+	Steps []*Step
+}
+
+// Migrator migrates resources from a source server to a destination server.
+type Migrator struct {
+	src, dst *mlflow.Client
+	cfg      Config
+	cp       *Checkpoint
+}
+
+// New returns a migrator, resuming the migration saved to cfg.Checkpoint if it exists.
+func New(src, dst *mlflow.Client, cfg *Config) (*Migrator, error) {
+	m := &Migrator{src: src, dst: dst, cfg: *cfg, cp: &Checkpoint{}}
+
+	if cfg.Checkpoint != "" {
+		b, err := os.ReadFile(cfg.Checkpoint)
+		switch {
+		case err == nil:
+			err = json.Unmarshal(b, m.cp)
+			if err != nil {
+				return nil, fmt.Errorf("migrate: checkpoint %s: %w", cfg.Checkpoint, err)
+			}
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, err
+		}
+	}
+
+	if m.cp.Experiments == nil {
+		m.cp.Experiments = map[string]string{}
+	}
+	if m.cp.Runs == nil {
+		m.cp.Runs = map[string]string{}
+	}
+	if m.cp.Models == nil {
+		m.cp.Models = map[string]bool{}
+	}
+	if m.cp.ModelVersions == nil {
+		m.cp.ModelVersions = map[string]string{}
+	}
+	if m.cp.ReadyModelVersions == nil {
+		m.cp.ReadyModelVersions = map[string]bool{}
+	}
+	if m.cp.Aliases == nil {
+		m.cp.Aliases = map[string]bool{}
+	}
+
+	return m, nil
+}
+
+// Plan returns the steps of the migration without changing the destination, as a dry run.
+func (m *Migrator) Plan(ctx context.Context) (*Plan, error) {
+	p := &Plan{}
+	planned := map[string]bool{}
+
+	addExperiment := func(id string) error {
+		if planned["experiment/"+id] {
+			return nil
+		}
+		planned["experiment/"+id] = true
+
+		e, err := m.src.Experiments.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		step := &Step{Kind: KindExperiment, Action: ActionCreate, Source: id, Name: e.Name, experiment: e}
+		if dstID, ok := m.cp.Experiments[id]; ok {
+			step.Action, step.Destination = ActionSkip, dstID
+		} else if existing, err := m.dst.Experiments.GetByName(ctx, e.Name); err == nil {
+			step.Action, step.Destination = ActionReuse, existing.ExperimentID
+		} else if !mlflow.IsResourceDoesNotExist(err) {
+			return err
+		}
+		p.Steps = append(p.Steps, step)
+		return nil
+	}
+
+	addRun := func(run *mlflow.Run) error {
+		if planned["run/"+run.Info.RunID] {
+			return nil
+		}
+		planned["run/"+run.Info.RunID] = true
+
+		err := addExperiment(run.Info.ExperimentID)
+		if err != nil {
+			return err
+		}
+		step := &Step{Kind: KindRun, Action: ActionCreate, Source: run.Info.RunID, Name: run.Info.RunName, run: run}
+		if dstID, ok := m.cp.Runs[run.Info.RunID]; ok {
+			step.Action, step.Destination = ActionSkip, dstID
+		}
+		p.Steps = append(p.Steps, step)
+		return nil
+	}
+
+	for _, id := range m.cfg.ExperimentIDs {
+		err := addExperiment(id)
+		if err != nil {
+			return nil, err
+		}
+
+		runs, err := m.src.Runs.SearchAll(ctx, &mlflow.RunSearchOptions{
+			ExperimentIDs: []string{id},
+			OrderBy:       []string{"attributes.start_time ASC"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range runs {
+			err = addRun(run)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, name := range m.cfg.Models {
+		model, err := m.src.RegisteredModels.Get(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		step := &Step{Kind: KindRegisteredModel, Action: ActionCreate, Source: name, Name: name, model: model}
+		if m.cp.Models[name] {
+			step.Action = ActionSkip
+		} else if _, err := m.dst.RegisteredModels.Get(ctx, name); err == nil {
+			step.Action = ActionReuse
+		} else if !mlflow.IsResourceDoesNotExist(err) {
+			return nil, err
+		}
+		p.Steps = append(p.Steps, step)
+
+		versions, err := m.src.ModelVersions.SearchAll(ctx, &mlflow.ModelVersionsSearchOptions{
+			Filter: "name = " + filterstring.Quote(name),
+		})
+		if err != nil {
+			return nil, err
+		}
+		sortVersions(versions)
+
+		for _, v := range versions {
+			if v.RunID != "" {
+				run, err := m.src.Runs.Get(ctx, v.RunID)
+				switch {
+				case err == nil:
+					err = addRun(run)
+					if err != nil {
+						return nil, err
+					}
+				case !mlflow.IsResourceDoesNotExist(err):
+					return nil, err
+				}
+			}
+
+			key := v.Name + "/" + v.Version
+			step := &Step{Kind: KindModelVersion, Action: ActionCreate, Source: key, version: v}
+			if dst, ok := m.cp.ModelVersions[key]; ok {
+				step.Action, step.Destination = ActionResume, v.Name+"/"+dst
+				if m.cp.ReadyModelVersions[key] {
+					step.Action = ActionSkip
+				}
+			}
+			p.Steps = append(p.Steps, step)
+		}
+
+		if len(model.Aliases) > 0 {
+			step := &Step{Kind: KindAliases, Action: ActionCreate, Source: name, Name: name, model: model}
+			if m.cp.Aliases[name] {
+				step.Action = ActionSkip
+			}
+			p.Steps = append(p.Steps, step)
+		}
+	}
+
+	return p, nil
+}
+
+// Run migrates the resources following the plan and returns the mapping of the IDs. A run
+// whose copy fails is deleted from the destination and copied again on resumption.
+func (m *Migrator) Run(ctx context.Context) (*Checkpoint, error) {
+	p, err := m.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range p.Steps {
+		if step.Action == ActionSkip {
+			continue
+		}
+
+		err = m.execute(ctx, step)
+		if err != nil {
+			return m.cp, fmt.Errorf("migrate: %s: %w", step, err)
+		}
+		err = m.save()
+		if err != nil {
+			return m.cp, err
+		}
+	}
+
+	// The parent runs of the copied runs may have been copied after them.
+	for _, step := range p.Steps {
+		if step.Kind != KindRun || step.run.Data == nil {
+			continue
+		}
+		for _, tag := range step.run.Data.Tags {
+			if tag.Key != tagParentRunID {
+				continue
+			}
+			if parent, ok := m.cp.Runs[tag.Value]; ok {
+				err = m.dst.Runs.SetTag(ctx, m.cp.Runs[step.Source], tag.Key, parent)
+				if err != nil {
+					return m.cp, err
+				}
+			}
+		}
+	}
+
+	return m.cp, nil
+}
+
+func (m *Migrator) execute(ctx context.Context, step *Step) error {
+	switch step.Kind {
+	case KindExperiment:
+		if step.Action == ActionReuse {
+			m.cp.Experiments[step.Source] = step.Destination
+			return nil
+		}
+		opts := &mlflow.ExperimentCreateOptions{Name: step.experiment.Name, Tags: step.experiment.Tags}
+		id, err := m.dst.Experiments.CreateWithOptions(ctx, opts)
+		if err != nil {
+			return err
+		}
+		m.cp.Experiments[step.Source] = id
+
+	case KindRun:
+		dir, err := os.MkdirTemp("", "mlflow-migrate-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+
+		err = m.src.Runs.Export(ctx, step.Source, dir)
+		if err != nil {
+			return err
+		}
+		id, err := m.dst.Runs.Import(ctx, m.cp.Experiments[step.run.Info.ExperimentID], dir)
+		if err != nil {
+			if id != "" {
+				_ = m.dst.Runs.Delete(detachedContext{ctx}, id)
+			}
+			return err
+		}
+		m.cp.Runs[step.Source] = id
+
+	case KindRegisteredModel:
+		if step.Action == ActionCreate {
+			opts := &mlflow.RegisteredModelCreateOptions{Name: step.model.Name, Description: step.model.Description, Tags: step.model.Tags}
+			_, err := m.dst.RegisteredModels.Create(ctx, opts)
+			if err != nil {
+				return err
+			}
+		}
+		m.cp.Models[step.Source] = true
+
+	case KindModelVersion:
+		v := step.version
+		if step.Action == ActionCreate {
+			opts := &mlflow.ModelVersionCreateOptions{Name: v.Name, Source: v.Source, Description: v.Description, Tags: v.Tags}
+			if dstRunID, ok := m.cp.Runs[v.RunID]; ok {
+				source, err := m.rewriteSource(ctx, v.Source, v.RunID, dstRunID)
+				if err != nil {
+					return err
+				}
+				opts.Source, opts.RunID = source, dstRunID
+			}
+
+			created, err := m.dst.ModelVersions.Create(ctx, opts)
+			if err != nil {
+				return err
+			}
+			// The version is saved before waiting for it, not to be created twice if the
+			// migration is interrupted.
+			m.cp.ModelVersions[step.Source] = created.Version
+			err = m.save()
+			if err != nil {
+				return err
+			}
+		}
+
+		// Waiting and transitioning are resumed: the version may not be ready yet, or not
+		// in its stage.
+		dst, err := m.dst.ModelVersions.WaitUntilReady(ctx, v.Name, m.cp.ModelVersions[step.Source], time.Second)
+		if err != nil {
+			return err
+		}
+		if v.CurrentStage != "" && v.CurrentStage != string(mlflow.ModelVersionStageNone) && dst.CurrentStage != v.CurrentStage {
+			_, err = m.dst.ModelVersions.TransitionStage(ctx, v.Name, dst.Version, mlflow.ModelVersionStage(v.CurrentStage), false)
+			if err != nil {
+				return err
+			}
+		}
+		m.cp.ReadyModelVersions[step.Source] = true
+
+	case KindAliases:
+		for _, alias := range step.model.Aliases {
+			version, ok := m.cp.ModelVersions[step.model.Name+"/"+alias.Version]
+			if !ok {
+				continue
+			}
+			err := m.dst.RegisteredModels.SetAlias(ctx, step.model.Name, alias.Alias, version)
+			if err != nil {
+				return err
+			}
+		}
+		m.cp.Aliases[step.Source] = true
+	}
+
+	return nil
+}
+
+// rewriteSource rewrites the source of a model version referring to its source run, by run ID
+// or artifact URI, to the destination run.
+func (m *Migrator) rewriteSource(ctx context.Context, source, srcRunID, dstRunID string) (string, error) {
+	srcRun, err := m.src.Runs.Get(ctx, srcRunID)
+	if err != nil {
+		return "", err
+	}
+	dstRun, err := m.dst.Runs.Get(ctx, dstRunID)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.NewReplacer(
+		srcRun.Info.ArtifactUri, dstRun.Info.ArtifactUri,
+		srcRunID, dstRunID,
+	).Replace(source), nil
+}
+
+// sortVersions sorts model versions numerically.
+func sortVersions(versions []*mlflow.ModelVersion) {
+	sort.Slice(versions, func(i, j int) bool {
+		return modelversion.Compare(versions[i].Version, versions[j].Version) < 0
+	})
+}
+
+// save writes the checkpoint, atomically.
+func (m *Migrator) save() error {
+	if m.cfg.Checkpoint == "" {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(m.cp, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := m.cfg.Checkpoint + ".tmp"
+	err = os.WriteFile(tmp, b, 0o644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, m.cfg.Checkpoint)
+}
+
+// detachedContext has the values of its parent but is never canceled, to clean up after a
+// canceled step.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
@@ -0,0 +1,121 @@
+package migrate_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codeocean/go-mlflow/migrate"
+	"github.com/codeocean/go-mlflow/mlflow"
+	"github.com/codeocean/go-mlflow/mlflowtest"
+)
+
+// failingTransport fails the requests of the endpoints of fail, ending with their path.
+type failingTransport struct {
+	base http.RoundTripper
+	fail map[string]bool
+}
+
+func (t *failingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	for path, fail := range t.fail {
+		if fail && strings.HasSuffix(r.URL.Path, path) {
+			return nil, errors.New("connection reset")
+		}
+	}
+	return t.base.RoundTrip(r)
+}
+
+func TestMigrateResumesModelVersions(t *testing.T) {
+	ctx := context.Background()
+
+	src := mlflowtest.NewServer()
+	defer src.Close()
+	srcClient, err := src.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = srcClient.RegisteredModels.Create(ctx, &mlflow.RegisteredModelCreateOptions{Name: "m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		_, err = srcClient.ModelVersions.Create(ctx, &mlflow.ModelVersionCreateOptions{Name: "m", Source: "s3://bucket/model"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = srcClient.ModelVersions.TransitionStage(ctx, "m", "1", mlflow.ModelVersionStageProduction, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := mlflowtest.NewServer()
+	defer dst.Close()
+	transport := &failingTransport{base: dst.Client().Transport, fail: map[string]bool{}}
+	dstClient, err := mlflow.NewClient(&http.Client{Transport: transport}, dst.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &migrate.Config{Models: []string{"m"}, Checkpoint: filepath.Join(t.TempDir(), "checkpoint.json")}
+	run := func() (*migrate.Checkpoint, error) {
+		m, err := migrate.New(srcClient, dstClient, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m.Run(ctx)
+	}
+
+	// The first migration creates version 1 but fails to set its stage, the second one
+	// waits for it again but fails to set its stage too.
+	for _, path := range []string{"/model-versions/transition-stage", "/model-versions/get"} {
+		transport.fail[path] = true
+		_, err = run()
+		if err == nil {
+			t.Fatalf("migration succeeded failing %s", path)
+		}
+		transport.fail[path] = false
+	}
+
+	cp, err := run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.ModelVersions["m/1"] != "1" || cp.ModelVersions["m/2"] != "2" {
+		t.Errorf("got model versions %v", cp.ModelVersions)
+	}
+
+	versions, err := dstClient.ModelVersions.SearchAll(ctx, &mlflow.ModelVersionsSearchOptions{Filter: "name = 'm'"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("got %d versions on the destination, want 2", len(versions))
+	}
+	for _, v := range versions {
+		want := mlflow.ModelVersionStageNone
+		if v.Version == "1" {
+			want = mlflow.ModelVersionStageProduction
+		}
+		if v.CurrentStage != string(want) {
+			t.Errorf("version %s in stage %s, want %s", v.Version, v.CurrentStage, want)
+		}
+	}
+
+	plan, err := migrate.New(srcClient, dstClient, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := plan.Plan(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, step := range p.Steps {
+		if step.Action != migrate.ActionSkip {
+			t.Errorf("step %s after the migration", step)
+		}
+	}
+}
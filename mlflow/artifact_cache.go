@@ -0,0 +1,320 @@
+package mlflow
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WithArtifactCache makes the client keep the artifacts it downloads in a content-addressed
+// cache in dir, which persists across processes, and copy unchanged artifacts from there
+// instead of downloading them again, see DownloadModel. An artifact is unchanged when its
+// artifact root, path, size and content hash are the same. The listings of the artifacts
+// proxy have no content hashes, the artifacts it serves are identified instead by the
+// Digest, ETag or Last-Modified headers of a HEAD request; artifacts with neither are not
+// cached. When the cache grows beyond maxBytes, the least recently used artifacts are
+// evicted; maxBytes <= 0 means no limit.
+func WithArtifactCache(dir string, maxBytes int64) ClientOption {
+	return func(c *Client) {
+		c.artifactCache = &artifactCache{dir: dir, maxBytes: maxBytes}
+	}
+}
+
+// artifactCache stores the content of artifacts in dir/objects, named by their SHA-256, and
+// maps artifacts to their content with files in dir/index, named by the SHA-256 of the key
+// of the artifact. Files are written to temporary files renamed once complete, so that the
+// cache is only locked per key, while an artifact is looked up, downloaded and stored.
+type artifactCache struct {
+	dir      string
+	maxBytes int64
+
+	// mu guards keys, the locks of the keys in use.
+	mu   sync.Mutex
+	keys map[string]*keyLock
+	// evictMu serializes evictions.
+	evictMu sync.Mutex
+}
+
+type keyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lock locks key, and returns the function unlocking it.
+func (c *artifactCache) lock(key string) func() {
+	c.mu.Lock()
+	if c.keys == nil {
+		c.keys = map[string]*keyLock{}
+	}
+	l := c.keys[key]
+	if l == nil {
+		l = &keyLock{}
+		c.keys[key] = l
+	}
+	l.refs++
+	c.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+
+		c.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(c.keys, key)
+		}
+		c.mu.Unlock()
+	}
+}
+
+// artifactVersioner is implemented by artifact repositories able to identify the version of
+// an artifact whose listing has no content hash.
+type artifactVersioner interface {
+	// version returns the version of the artifact at path, empty if unknown.
+	version(ctx context.Context, path string) (string, error)
+}
+
+// version returns the checksum of the artifact given by the Digest headers of a HEAD
+// request, or else its ETag or Last-Modified date.
+func (r *proxyRepository) version(ctx context.Context, p string) (string, error) {
+	res, err := r.client.stream(ctx, "HEAD", r.client.artifactURL(joinArtifactPath(r.root, p)), nil, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	res.Body.Close()
+
+	if digest := parseDigest(res.Header); digest != nil {
+		return digest.String(), nil
+	}
+	return responseValidator(res.Header), nil
+}
+
+// downloadCachedFile downloads an artifact to localPath like downloadFile, through the
+// artifact cache of the client if it has one and the size and version of the artifact, its
+// FileInfo.ContentHash or else the version given by its repository, are known. Concurrent
+// downloads of the same artifact wait for the first one and copy it from the cache.
+func (s *ArtifactsService) downloadCachedFile(ctx context.Context, artifactURI, path, localPath string, size int64, contentHash string, progress func(int64)) error {
+	cache := s.client.artifactCache
+	if cache != nil && size >= 0 && contentHash == "" {
+		contentHash = s.artifactVersion(ctx, artifactURI, path)
+	}
+	if cache == nil || size < 0 || contentHash == "" {
+		return s.downloadFile(ctx, artifactURI, path, localPath, size, progress)
+	}
+
+	key := artifactCacheKey(artifactURI, path, size, contentHash)
+	unlock := cache.lock(key)
+	defer unlock()
+
+	ok, err := cache.get(key, localPath)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if progress != nil {
+			progress(size)
+		}
+		return nil
+	}
+
+	err = s.downloadFile(ctx, artifactURI, path, localPath, size, progress)
+	if err != nil {
+		return err
+	}
+
+	return cache.put(key, localPath)
+}
+
+// artifactVersion returns the version of an artifact given by its repository, empty if the
+// repository cannot give it. Failures are left to the download of the artifact to report.
+func (s *ArtifactsService) artifactVersion(ctx context.Context, artifactURI, path string) string {
+	repo, err := s.Repository(ctx, artifactURI)
+	if err != nil {
+		return ""
+	}
+	versioner, ok := repo.(artifactVersioner)
+	if !ok {
+		return ""
+	}
+
+	version, err := versioner.version(ctx, path)
+	if err != nil {
+		return ""
+	}
+	return version
+}
+
+// artifactCacheKey returns the cache key of an artifact of the given size and content hash,
+// or version, below the artifact root artifactURI.
+func artifactCacheKey(artifactURI, path string, size int64, contentHash string) string {
+	h := sha256.New()
+	io.WriteString(h, strings.TrimSuffix(artifactURI, "/")+"\x00"+path+"\x00"+strconv.FormatInt(size, 10)+"\x00"+contentHash)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// get copies the cached content of key to localPath, and reports whether it was found. key
+// must be locked.
+func (c *artifactCache) get(key, localPath string) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	sum, err := os.ReadFile(filepath.Join(c.dir, "index", key))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	object := filepath.Join(c.dir, "objects", string(sum))
+	src, err := os.Open(object)
+	if os.IsNotExist(err) {
+		// The content was evicted.
+		_ = os.Remove(filepath.Join(c.dir, "index", key))
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer src.Close()
+
+	err = copyToFile(localPath, src)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(object, now, now)
+
+	return true, nil
+}
+
+// put stores the content of the file at localPath as the content of key, then evicts
+// content if the cache is over its size limit. key must be locked.
+func (c *artifactCache) put(key, localPath string) error {
+	if c == nil {
+		return nil
+	}
+
+	src, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, src)
+	if err != nil {
+		return err
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+
+	_, err = src.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	object := filepath.Join(c.dir, "objects", sum)
+	if _, err := os.Stat(object); os.IsNotExist(err) {
+		err = copyToFile(object, src)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = copyToFile(filepath.Join(c.dir, "index", key), strings.NewReader(sum))
+	if err != nil {
+		return err
+	}
+
+	return c.evict()
+}
+
+// evict removes the least recently used content until the cache is under its size limit.
+// Content being copied to a destination remains readable once removed.
+func (c *artifactCache) evict() error {
+	if c.maxBytes <= 0 {
+		return nil
+	}
+
+	c.evictMu.Lock()
+	defer c.evictMu.Unlock()
+
+	entries, err := os.ReadDir(filepath.Join(c.dir, "objects"))
+	if err != nil {
+		return err
+	}
+
+	var (
+		objects []fs.FileInfo
+		total   int64
+	)
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		objects = append(objects, info)
+		total += info.Size()
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].ModTime().Before(objects[j].ModTime())
+	})
+
+	for _, info := range objects {
+		if total <= c.maxBytes {
+			break
+		}
+		err = os.Remove(filepath.Join(c.dir, "objects", info.Name()))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		total -= info.Size()
+	}
+
+	return nil
+}
+
+// copyToFile writes the content of r to a temporary file renamed to path once complete, so
+// that concurrent readers never see a partial file.
+func copyToFile(path string, r io.Reader) error {
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	err = f.Chmod(0o644)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
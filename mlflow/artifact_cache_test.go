@@ -0,0 +1,94 @@
+package mlflow_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// proxyServer serves a run whose model.bin artifact, served by the artifacts proxy, has
+// content and etag, counting the downloads of the artifact.
+type proxyServer struct {
+	mu        sync.Mutex
+	content   string
+	etag      string
+	downloads int
+}
+
+func (s *proxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/api/2.0/mlflow/runs/get":
+		_ = json.NewEncoder(w).Encode(map[string]any{"run": &mlflow.Run{
+			Info: &mlflow.RunInfo{RunID: "r1", ArtifactUri: "mlflow-artifacts:/0/r1/artifacts"},
+		}})
+	case "/api/2.0/mlflow/artifacts/list":
+		_ = json.NewEncoder(w).Encode(map[string]any{"files": []*mlflow.FileInfo{{Path: "model.bin", FileSize: int64(len(s.content))}}})
+	case "/api/2.0/mlflow-artifacts/artifacts/0/r1/artifacts/model.bin":
+		if r.Method == http.MethodGet {
+			s.downloads++
+		}
+		w.Header().Set("ETag", s.etag)
+		http.ServeContent(w, r, "model.bin", time.Time{}, bytes.NewReader([]byte(s.content)))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code": "ENDPOINT_NOT_FOUND"}`))
+	}
+}
+
+func TestArtifactCacheProxy(t *testing.T) {
+	s := &proxyServer{content: "0123456789", etag: `"v1"`}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	client, err := mlflow.NewClient(srv.Client(), srv.URL, mlflow.WithArtifactCache(t.TempDir(), 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		content       string
+		etag          string
+		wantDownloads int
+	}{
+		{name: "first download", content: "0123456789", etag: `"v1"`, wantDownloads: 1},
+		{name: "unchanged", content: "0123456789", etag: `"v1"`, wantDownloads: 1},
+		{name: "changed", content: "abcdefghij", etag: `"v2"`, wantDownloads: 2},
+	}
+	for _, tt := range tests {
+		s.mu.Lock()
+		s.content, s.etag = tt.content, tt.etag
+		s.mu.Unlock()
+
+		dir := t.TempDir()
+		err := client.Artifacts.DownloadTree(context.Background(), "r1", "", dir, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := os.ReadFile(filepath.Join(dir, "model.bin"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != tt.content {
+			t.Errorf("%s: got %q, want %q", tt.name, b, tt.content)
+		}
+		s.mu.Lock()
+		downloads := s.downloads
+		s.mu.Unlock()
+		if downloads != tt.wantDownloads {
+			t.Errorf("%s: got %d downloads, want %d", tt.name, downloads, tt.wantDownloads)
+		}
+	}
+}
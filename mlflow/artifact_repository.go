@@ -0,0 +1,97 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ArtifactRepository stores the artifacts below an artifact root URI, such as the
+// artifact_uri of a run. Paths are relative to the root and separated by slashes.
+type ArtifactRepository interface {
+	// Get writes the content of the artifact at path to w.
+	Get(ctx context.Context, path string, w io.Writer) error
+	// Put stores the content of r as the artifact at path.
+	Put(ctx context.Context, path string, r io.Reader) error
+	// List lists the files and directories directly below path.
+	List(ctx context.Context, path string) ([]*FileInfo, error)
+}
+
+// ArtifactRepositoryFactory returns the repository of the artifacts below rootURI.
+type ArtifactRepositoryFactory func(ctx context.Context, rootURI string) (ArtifactRepository, error)
+
+// WithArtifactRepository makes the client access artifacts with URIs of the given scheme,
+// such as "s3", directly through the repositories returned by factory, instead of through
+// the tracking server's artifacts proxy.
+func WithArtifactRepository(scheme string, factory ArtifactRepositoryFactory) ClientOption {
+	return func(c *Client) {
+		if c.artifactRepositories == nil {
+			c.artifactRepositories = map[string]ArtifactRepositoryFactory{}
+		}
+		c.artifactRepositories[scheme] = factory
+	}
+}
+
+// Repository returns the repository of the artifacts below rootURI: mlflow-artifacts URIs are
+// accessed through the tracking server's artifacts proxy, and other URIs through the
+// repositories configured with WithArtifactRepository.
+func (s *ArtifactsService) Repository(ctx context.Context, rootURI string) (ArtifactRepository, error) {
+	u, err := url.Parse(rootURI)
+	if err != nil {
+		return nil, err
+	}
+
+	if factory, ok := s.client.artifactRepositories[u.Scheme]; ok {
+		return factory(ctx, rootURI)
+	}
+
+	root, err := proxyPath(rootURI)
+	if err != nil {
+		return nil, fmt.Errorf("mlflow: no artifact repository for %q", rootURI)
+	}
+
+	return &proxyRepository{client: s.client, root: root}, nil
+}
+
+// proxyRepository accesses artifacts through the tracking server's artifacts proxy.
+type proxyRepository struct {
+	client *Client
+	root   string
+}
+
+func (r *proxyRepository) Get(ctx context.Context, p string, w io.Writer) error {
+	_, err := r.client.do(ctx, "GET", r.client.artifactURL(joinArtifactPath(r.root, p)), nil, nil, w)
+	return err
+}
+
+func (r *proxyRepository) Put(ctx context.Context, p string, body io.Reader) error {
+	_, err := r.client.do(ctx, "PUT", r.client.artifactURL(joinArtifactPath(r.root, p)), nil, body, nil)
+	return err
+}
+
+func (r *proxyRepository) List(ctx context.Context, p string) ([]*FileInfo, error) {
+	var res struct {
+		Files []*FileInfo `json:"files,omitempty"`
+	}
+
+	params := url.Values{}
+	params.Set("path", joinArtifactPath(r.root, p))
+
+	u := r.client.artifactURL("")
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	_, err := r.client.do(ctx, "GET", u, params, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	// The proxy returns the names of the files, make them relative to the root.
+	for _, f := range res.Files {
+		f.Path = joinArtifactPath(p, path.Base(f.Path))
+	}
+
+	return res.Files, nil
+}
@@ -0,0 +1,185 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ArtifactURI is a decomposed artifact URI.
+type ArtifactURI struct {
+	// Scheme is the scheme of the URI, such as "runs", "models", "mlflow-artifacts", "s3"
+	// or "dbfs".
+	Scheme string
+	// Location is the run ID of runs:/ URIs, the registered model name of models:/ URIs,
+	// and the host, or bucket, of other URIs.
+	Location string
+	// Path is the slash-separated path of the artifact below the location, without leading
+	// slash.
+	Path string
+
+	// Version, Stage and Alias identify the model version of models:/ URIs; one of them is
+	// set, Stage is "latest" for models:/name/latest.
+	Version string
+	Stage   string
+	Alias   string
+}
+
+// ParseArtifactURI parses an artifact URI such as runs:/<run_id>/path,
+// models:/<name>/<version>, models:/<name>/<stage>, models:/<name>@<alias>,
+// mlflow-artifacts:/path, s3://bucket/path or dbfs:/path.
+func ParseArtifactURI(uri string) (*ArtifactURI, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" {
+		return nil, fmt.Errorf("mlflow: artifact URI %q has no scheme", uri)
+	}
+
+	res := &ArtifactURI{Scheme: u.Scheme}
+	p := strings.Trim(u.Path, "/")
+
+	switch u.Scheme {
+	case "runs":
+		res.Location, res.Path, _ = strings.Cut(joinArtifactPath(u.Host, p), "/")
+		if res.Location == "" {
+			return nil, fmt.Errorf("mlflow: artifact URI %q has no run ID", uri)
+		}
+
+	case "models":
+		p = joinArtifactPath(u.Host, p)
+		first, rest, _ := strings.Cut(p, "/")
+		if name, alias, ok := strings.Cut(first, "@"); ok {
+			res.Location, res.Alias, res.Path = name, alias, rest
+		} else {
+			var ref string
+			ref, res.Path, _ = strings.Cut(rest, "/")
+			res.Location = first
+			if _, err := strconv.ParseInt(ref, 10, 64); err == nil {
+				res.Version = ref
+			} else {
+				res.Stage = ref
+			}
+		}
+		if res.Location == "" || (res.Version == "" && res.Stage == "" && res.Alias == "") {
+			return nil, fmt.Errorf("mlflow: invalid model URI %q, expected models:/<name>/<version>, models:/<name>/<stage> or models:/<name>@<alias>", uri)
+		}
+
+	default:
+		res.Location = u.Host
+		res.Path = p
+	}
+
+	return res, nil
+}
+
+// String returns the URI.
+func (u *ArtifactURI) String() string {
+	var s string
+	switch {
+	case u.Scheme == "runs":
+		s = "runs:/" + u.Location
+	case u.Scheme == "models" && u.Alias != "":
+		s = "models:/" + u.Location + "@" + u.Alias
+	case u.Scheme == "models":
+		s = "models:/" + u.Location + "/" + u.Version + u.Stage
+	case u.Location == "":
+		return u.Scheme + ":/" + u.Path
+	default:
+		s = u.Scheme + "://" + u.Location
+	}
+
+	if u.Path == "" {
+		return s
+	}
+	return s + "/" + u.Path
+}
+
+// ResolveURI resolves runs:/ and models:/ URIs to the URI of the location where the artifacts
+// are stored, using the tracking server and model registry. Other URIs are returned as is.
+func (s *ArtifactsService) ResolveURI(ctx context.Context, uri string) (string, error) {
+	u, err := ParseArtifactURI(uri)
+	if err != nil {
+		return "", err
+	}
+
+	var root string
+	switch u.Scheme {
+	case "runs":
+		run, err := s.client.Runs.Get(ctx, u.Location)
+		if err != nil {
+			return "", err
+		}
+		root = run.Info.ArtifactUri
+
+	case "models":
+		version, err := s.client.ModelVersions.resolveModelURI(ctx, u)
+		if err != nil {
+			return "", err
+		}
+		root, err = s.client.ModelVersions.GetDownloadURI(ctx, u.Location, version)
+		if err != nil {
+			return "", err
+		}
+
+	default:
+		return uri, nil
+	}
+
+	if u.Path == "" {
+		return root, nil
+	}
+	return strings.TrimSuffix(root, "/") + "/" + u.Path, nil
+}
+
+// resolveModelURI returns the version a models:/ URI refers to.
+func (s *ModelVersionService) resolveModelURI(ctx context.Context, u *ArtifactURI) (string, error) {
+	switch {
+	case u.Version != "":
+		return u.Version, nil
+
+	case u.Alias != "":
+		version, err := s.client.RegisteredModels.GetModelVersionByAlias(ctx, u.Location, u.Alias)
+		if err != nil {
+			return "", err
+		}
+		if version == nil {
+			return "", fmt.Errorf("mlflow: no version of model %q with alias %q", u.Location, u.Alias)
+		}
+		return version.Version, nil
+
+	default:
+		opts := struct {
+			Name   string   `json:"name,omitempty"`
+			Stages []string `json:"stages,omitempty"`
+		}{
+			Name: u.Location,
+		}
+		if !strings.EqualFold(u.Stage, "latest") {
+			opts.Stages = []string{u.Stage}
+		}
+
+		var res struct {
+			ModelVersions []*ModelVersion `json:"model_versions,omitempty"`
+		}
+
+		_, err := s.client.Do(ctx, "POST", "registered-models/get-latest-versions", nil, &opts, &res)
+		if err != nil {
+			return "", err
+		}
+
+		var latest int64 = -1
+		for _, v := range res.ModelVersions {
+			if n, err := strconv.ParseInt(v.Version, 10, 64); err == nil && n > latest {
+				latest = n
+			}
+		}
+		if latest < 0 {
+			return "", fmt.Errorf("mlflow: no version of model %q in stage %q", u.Location, u.Stage)
+		}
+		return strconv.FormatInt(latest, 10), nil
+	}
+}
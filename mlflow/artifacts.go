@@ -1,6 +1,15 @@
 package mlflow
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"strings"
+	"time"
+)
 
 type ArtifactsService service
 
@@ -20,15 +29,190 @@ type FileInfo struct {
 	Path     string `json:"path,omitempty"`
 	IsDir    bool   `json:"is_dir,omitempty"`
 	FileSize int64  `json:"file_size,omitempty"`
+
+	// LastModified and ContentHash are not part of the REST API listings, they are set by
+	// the artifact repositories able to provide them, see Stat.
+	LastModified time.Time `json:"-"`
+	// ContentHash is the checksum of the content as "<algorithm>:<hex digest>", where the
+	// algorithm is "md5" or "sha256".
+	ContentHash string `json:"-"`
 }
 
 func (s *ArtifactsService) List(ctx context.Context, opts *ListArtifactsRequest) (*ListArtifactsResponse, error) {
+	if opts == nil {
+		opts = &ListArtifactsRequest{}
+	}
+
 	var res ListArtifactsResponse
 
-	_, err := s.client.Do(ctx, "GET", "artifacts/list", nil, opts, &res)
+	params := url.Values{}
+	params.Set("run_id", opts.RunID)
+	if opts.Path != "" {
+		params.Set("path", opts.Path)
+	}
+	if opts.PageToken != "" {
+		params.Set("page_token", opts.PageToken)
+	}
+
+	_, err := s.client.Do(ctx, "GET", "artifacts/list", params, nil, &res)
 	if err != nil {
 		return nil, err
 	}
 
 	return &res, nil
 }
+
+// Upload uploads the content of r to the given path, relative to the artifact root of the run.
+// The artifact is stored through the run's artifact repository, see Repository.
+func (s *ArtifactsService) Upload(ctx context.Context, runID, path string, r io.Reader) error {
+	run, err := s.client.Runs.Get(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	return s.upload(ctx, run.Info.ArtifactUri, path, r)
+}
+
+// Download writes the content of an artifact of the run to w. The artifact is read through
+// the run's artifact repository, see Repository.
+func (s *ArtifactsService) Download(ctx context.Context, runID, path string, w io.Writer) error {
+	run, err := s.client.Runs.Get(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	return s.download(ctx, run.Info.ArtifactUri, path, w)
+}
+
+// Open opens an artifact of the run for reading, streaming its content from the run's
+// artifact repository. The caller must close the returned reader.
+func (s *ArtifactsService) Open(ctx context.Context, runID, path string) (io.ReadCloser, error) {
+	run, err := s.client.Runs.Get(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := s.Repository(ctx, run.Info.ArtifactUri)
+	if err != nil {
+		return nil, err
+	}
+
+	if opener, ok := repo.(rangeOpener); ok {
+		content, err := opener.openRange(ctx, path, 0, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return struct {
+			io.Reader
+			io.Closer
+		}{s.client.artifactLimiter.reader(ctx, content.body), content.body}, nil
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(repo.Get(ctx, path, s.client.artifactLimiter.writer(ctx, pw)))
+	}()
+
+	return pr, nil
+}
+
+// DownloadDir downloads the artifacts of the run below path, recursively, into localDir.
+func (s *ArtifactsService) DownloadDir(ctx context.Context, runID, path, localDir string) error {
+	return s.DownloadTree(ctx, runID, path, localDir, nil)
+}
+
+func (s *ArtifactsService) upload(ctx context.Context, artifactURI, path string, r io.Reader) error {
+	repo, err := s.Repository(ctx, artifactURI)
+	if err != nil {
+		return err
+	}
+
+	return repo.Put(ctx, path, s.client.artifactLimiter.reader(ctx, r))
+}
+
+func (s *ArtifactsService) download(ctx context.Context, artifactURI, path string, w io.Writer) error {
+	repo, err := s.Repository(ctx, artifactURI)
+	if err != nil {
+		return err
+	}
+
+	return repo.Get(ctx, path, s.client.artifactLimiter.writer(ctx, w))
+}
+
+// Walk calls fn for each file and directory below root in the artifacts of the run,
+// recursively, following page tokens. Directories are visited before their content; if fn
+// returns fs.SkipDir for a directory, its content is skipped. Any other error stops the
+// walk and is returned.
+func (s *ArtifactsService) Walk(ctx context.Context, runID, root string, fn func(*FileInfo) error) error {
+	opts := &ListArtifactsRequest{RunID: runID, Path: root}
+	for {
+		res, err := s.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range res.Files {
+			err = fn(f)
+			if f.IsDir && errors.Is(err, fs.SkipDir) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if f.IsDir {
+				err = s.Walk(ctx, runID, f.Path, fn)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if res.NextPageToken == "" {
+			return nil
+		}
+		opts.PageToken = res.NextPageToken
+	}
+}
+
+// listFiles lists the files below path recursively.
+func (s *ArtifactsService) listFiles(ctx context.Context, runID, path string) ([]*FileInfo, error) {
+	var files []*FileInfo
+
+	err := s.Walk(ctx, runID, path, func(f *FileInfo) error {
+		if !f.IsDir {
+			files = append(files, f)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// relativeArtifactPath returns the path of an artifact relative to the directory dir.
+func relativeArtifactPath(dir, path string) string {
+	dir = strings.Trim(dir, "/")
+	if dir == "" {
+		return path
+	}
+
+	return strings.TrimPrefix(strings.TrimPrefix(path, dir), "/")
+}
+
+// proxyPath returns the path on the artifacts proxy of a mlflow-artifacts:/ URI.
+func proxyPath(artifactURI string) (string, error) {
+	u, err := url.Parse(artifactURI)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme != "mlflow-artifacts" {
+		return "", fmt.Errorf("mlflow: artifact location %q is not served by the artifacts proxy", artifactURI)
+	}
+
+	return strings.Trim(u.Path, "/"), nil
+}
@@ -0,0 +1,135 @@
+package mlflow
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// ArchiveFormat is the format of an artifact archive.
+type ArchiveFormat string
+
+const (
+	ArchiveZip   ArchiveFormat = "zip"
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+)
+
+// DownloadArchive writes the artifacts of the run below path to w as an archive of the given
+// format. Entries are named relative to path. The archive is assembled as the artifacts are
+// downloaded, since the tracking server has no archive endpoint.
+func (s *ArtifactsService) DownloadArchive(ctx context.Context, runID, path string, w io.Writer, format ArchiveFormat) error {
+	run, err := s.client.Runs.Get(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	files, err := s.listFiles(ctx, runID, path)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case ArchiveZip:
+		return s.writeZip(ctx, run.Info.ArtifactUri, path, files, w)
+	case ArchiveTarGz:
+		return s.writeTarGz(ctx, run.Info.ArtifactUri, path, files, w)
+	default:
+		return fmt.Errorf("mlflow: unsupported archive format %q", format)
+	}
+}
+
+func (s *ArtifactsService) writeZip(ctx context.Context, artifactURI, path string, files []*FileInfo, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	now := time.Now()
+	for _, f := range files {
+		entry, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     relativeArtifactPath(path, f.Path),
+			Method:   zip.Deflate,
+			Modified: now,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = s.download(ctx, artifactURI, f.Path, entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func (s *ArtifactsService) writeTarGz(ctx context.Context, artifactURI, path string, files []*FileInfo, w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	now := time.Now()
+	for _, f := range files {
+		header := &tar.Header{
+			Name:    relativeArtifactPath(path, f.Path),
+			Mode:    0o644,
+			ModTime: now,
+			Size:    expectedSize(f),
+		}
+
+		err := s.writeTarEntry(ctx, artifactURI, f.Path, header, tw)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := tw.Close()
+	if err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+func (s *ArtifactsService) writeTarEntry(ctx context.Context, artifactURI, path string, header *tar.Header, tw *tar.Writer) error {
+	if header.Size >= 0 {
+		err := tw.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+
+		return s.download(ctx, artifactURI, path, tw)
+	}
+
+	// Tar headers hold the size of the entry: spool artifacts of unknown size to a temporary
+	// file first.
+	tmp, err := os.CreateTemp("", "mlflow-archive-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	err = s.download(ctx, artifactURI, path, tmp)
+	if err != nil {
+		return err
+	}
+	header.Size, err = tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	err = tw.WriteHeader(header)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tw, tmp)
+	return err
+}
@@ -0,0 +1,312 @@
+package mlflow
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// partialDownloadSuffix is appended to the name of files while they are downloaded.
+const partialDownloadSuffix = ".part"
+
+// validatorSuffix is appended to the name of partial downloads for the file holding the
+// validator of the response they are downloaded from.
+const validatorSuffix = ".validator"
+
+// rangeOpener is implemented by artifact repositories able to read an artifact from an
+// offset, which allows resuming interrupted downloads.
+type rangeOpener interface {
+	// openRange opens the artifact at path for reading from offset, if it has not changed
+	// since the response whose validator is given, such as an ETag. The returned content
+	// starts at start, which is 0 when the artifact changed, the repository ignored the
+	// offset or the validator is empty.
+	openRange(ctx context.Context, path string, offset int64, validator string) (*artifactContent, error)
+}
+
+type artifactContent struct {
+	body  io.ReadCloser
+	start int64
+	// size is the size of the whole artifact, -1 if unknown.
+	size int64
+	// digest is the checksum of the whole artifact, nil if unknown.
+	digest *artifactDigest
+	// validator identifies the version of the artifact, to resume reading it, empty if
+	// unknown.
+	validator string
+}
+
+type artifactDigest struct {
+	algorithm string
+	newHash   func() hash.Hash
+	sum       []byte
+}
+
+// DownloadFile downloads an artifact of the run to localPath. The artifact is first written
+// to localPath with a .part suffix, which is renamed to localPath once the download is
+// complete and its size and checksum, when the repository provides them, are verified.
+// When the repository supports it, an interrupted download is resumed from the content of
+// the .part file, unless the artifact changed since.
+func (s *ArtifactsService) DownloadFile(ctx context.Context, runID, path, localPath string) error {
+	run, err := s.client.Runs.Get(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	return s.downloadFile(ctx, run.Info.ArtifactUri, path, localPath, -1, nil)
+}
+
+// downloadFile downloads an artifact to localPath, see DownloadFile. size is the expected
+// size of the artifact, -1 if unknown. If set, progress is called with the number of bytes
+// written to the file, including the resumed ones.
+func (s *ArtifactsService) downloadFile(ctx context.Context, artifactURI, path, localPath string, size int64, progress func(int64)) error {
+	repo, err := s.Repository(ctx, artifactURI)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(localPath), 0o755)
+	if err != nil {
+		return err
+	}
+
+	partPath := localPath + partialDownloadSuffix
+	f, err := os.OpenFile(partPath, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	offset := info.Size()
+	if size >= 0 && offset > size {
+		offset = 0
+	}
+
+	// The .part file is only resumed if the artifact did not change since it was written,
+	// which the validator of the response it was written from proves.
+	validatorPath := partPath + validatorSuffix
+	validator := ""
+	if offset > 0 {
+		b, err := os.ReadFile(validatorPath)
+		if err == nil {
+			validator = string(b)
+		}
+	}
+
+	var digest *artifactDigest
+	if opener, ok := repo.(rangeOpener); ok {
+		content, err := opener.openRange(ctx, path, offset, validator)
+		var e *Error
+		if offset > 0 && errors.As(err, &e) && e.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			content, err = opener.openRange(ctx, path, 0, "")
+		}
+		if err != nil {
+			return err
+		}
+		defer content.body.Close()
+
+		if content.start == 0 {
+			err = writeValidator(validatorPath, content.validator)
+			if err != nil {
+				return err
+			}
+		}
+
+		if size < 0 {
+			size = content.size
+		}
+		digest = content.digest
+
+		err = f.Truncate(content.start)
+		if err != nil {
+			return err
+		}
+		_, err = f.Seek(content.start, io.SeekStart)
+		if err != nil {
+			return err
+		}
+		if progress != nil && content.start > 0 {
+			progress(content.start)
+		}
+		_, err = io.Copy(s.client.artifactLimiter.writer(ctx, &progressWriter{w: f, fn: progress}), content.body)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = f.Truncate(0)
+		if err != nil {
+			return err
+		}
+		err = repo.Get(ctx, path, s.client.artifactLimiter.writer(ctx, &progressWriter{w: f, fn: progress}))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	err = verifyDownload(partPath, size, digest)
+	if err != nil {
+		_ = os.Remove(partPath)
+		_ = os.Remove(validatorPath)
+		return fmt.Errorf("mlflow: download of artifact %q: %w", path, err)
+	}
+
+	err = os.Rename(partPath, localPath)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(validatorPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// writeValidator writes the validator of a partial download to path, or removes path if the
+// validator is empty.
+func writeValidator(path, validator string) error {
+	if validator == "" {
+		err := os.Remove(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return os.WriteFile(path, []byte(validator), 0o644)
+}
+
+// expectedSize returns the size of a listed file, -1 if unknown. Listings omit the size of
+// empty files and of files whose size the server does not know alike.
+func expectedSize(f *FileInfo) int64 {
+	if f.FileSize == 0 {
+		return -1
+	}
+	return f.FileSize
+}
+
+// verifyDownload verifies the size and checksum of a downloaded file, when known.
+func verifyDownload(path string, size int64, digest *artifactDigest) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if size >= 0 && info.Size() != size {
+		return fmt.Errorf("size mismatch: got %d bytes, expected %d", info.Size(), size)
+	}
+
+	if digest == nil {
+		return nil
+	}
+
+	ok, err := digest.matchesFile(path)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("checksum mismatch")
+	}
+
+	return nil
+}
+
+func (r *proxyRepository) openRange(ctx context.Context, path string, offset int64, validator string) (*artifactContent, error) {
+	header := http.Header{}
+	if offset > 0 && validator != "" {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		header.Set("If-Range", validator)
+	}
+
+	res, err := r.client.stream(ctx, "GET", r.client.artifactURL(joinArtifactPath(r.root, path)), nil, header, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	content := &artifactContent{
+		body:      res.Body,
+		size:      res.ContentLength,
+		digest:    parseDigest(res.Header),
+		validator: responseValidator(res.Header),
+	}
+
+	if res.StatusCode == http.StatusPartialContent {
+		// Servers ignoring If-Range may return the range of a changed artifact.
+		if content.validator != validator {
+			res.Body.Close()
+			return r.openRange(ctx, path, 0, "")
+		}
+
+		content.start = offset
+		content.size = -1
+		if i := strings.LastIndex(res.Header.Get("Content-Range"), "/"); i >= 0 {
+			if size, err := strconv.ParseInt(res.Header.Get("Content-Range")[i+1:], 10, 64); err == nil {
+				content.size = size
+			}
+		}
+		// Content-MD5 is the checksum of the partial content only.
+		if res.Header.Get("Digest") == "" {
+			content.digest = nil
+		}
+	}
+
+	return content, nil
+}
+
+// responseValidator returns the strong validator of a response usable in If-Range headers,
+// its ETag or else its Last-Modified date, empty if it has none. Weak ETags cannot be used.
+func responseValidator(header http.Header) string {
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return header.Get("Last-Modified")
+}
+
+// parseDigest returns the checksum of the artifact given by the Digest or Content-MD5
+// response headers, nil if there is none.
+func parseDigest(header http.Header) *artifactDigest {
+	for _, d := range strings.Split(header.Get("Digest"), ",") {
+		algorithm, value, ok := strings.Cut(strings.TrimSpace(d), "=")
+		if !ok {
+			continue
+		}
+
+		algorithm = strings.ReplaceAll(strings.ToLower(algorithm), "-", "")
+		newHash := digestAlgorithms[algorithm]
+		if newHash == nil {
+			continue
+		}
+
+		if sum, err := base64.StdEncoding.DecodeString(value); err == nil {
+			return &artifactDigest{algorithm: algorithm, newHash: newHash, sum: sum}
+		}
+	}
+
+	if value := header.Get("Content-MD5"); value != "" {
+		if sum, err := base64.StdEncoding.DecodeString(value); err == nil {
+			return &artifactDigest{algorithm: "md5", newHash: md5.New, sum: sum}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package mlflow_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// artifactServer serves a run whose model.bin artifact has content and etag, and aborts the
+// downloads after interruptAfter bytes, if positive.
+type artifactServer struct {
+	mu             sync.Mutex
+	content        string
+	etag           string
+	interruptAfter int
+	ignoreIfRange  bool
+	ranges         []string
+}
+
+func (s *artifactServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/api/2.0/mlflow/runs/get":
+		_ = json.NewEncoder(w).Encode(map[string]any{"run": &mlflow.Run{
+			Info: &mlflow.RunInfo{RunID: "r1", ArtifactUri: "mlflow-artifacts:/0/r1/artifacts"},
+		}})
+	case "/api/2.0/mlflow-artifacts/artifacts/0/r1/artifacts/model.bin":
+		s.ranges = append(s.ranges, r.Header.Get("Range"))
+		w.Header().Set("ETag", s.etag)
+		if s.interruptAfter > 0 {
+			w.Header().Set("Content-Length", "10")
+			_, _ = w.Write([]byte(s.content[:s.interruptAfter]))
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		if s.ignoreIfRange {
+			r.Header.Del("If-Range")
+		}
+		http.ServeContent(w, r, "model.bin", time.Time{}, bytes.NewReader([]byte(s.content)))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code": "ENDPOINT_NOT_FOUND"}`))
+	}
+}
+
+func TestArtifactsDownloadFileResume(t *testing.T) {
+	tests := []struct {
+		name          string
+		newContent    string
+		newETag       string
+		ignoreIfRange bool
+		wantRanges    []string
+	}{
+		{name: "unchanged", newContent: "0123456789", newETag: `"v1"`, wantRanges: []string{"bytes=4-"}},
+		// The server returns the whole artifact for the If-Range header of the request.
+		{name: "changed", newContent: "abcdefghij", newETag: `"v2"`, wantRanges: []string{"bytes=4-"}},
+		{name: "changed without If-Range support", newContent: "abcdefghij", newETag: `"v2"`, ignoreIfRange: true, wantRanges: []string{"bytes=4-", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := &artifactServer{content: "0123456789", etag: `"v1"`, interruptAfter: 4}
+			srv := httptest.NewServer(s)
+			defer srv.Close()
+			client, err := mlflow.NewClient(srv.Client(), srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			localPath := filepath.Join(t.TempDir(), "model.bin")
+
+			err = client.Artifacts.DownloadFile(ctx, "r1", "model.bin", localPath)
+			if err == nil {
+				t.Fatal("interrupted download succeeded")
+			}
+
+			s.mu.Lock()
+			s.content, s.etag, s.interruptAfter, s.ignoreIfRange, s.ranges = tt.newContent, tt.newETag, 0, tt.ignoreIfRange, nil
+			s.mu.Unlock()
+			err = client.Artifacts.DownloadFile(ctx, "r1", "model.bin", localPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := os.ReadFile(localPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.newContent {
+				t.Errorf("downloaded %q, want %q", b, tt.newContent)
+			}
+			if fmt.Sprint(s.ranges) != fmt.Sprint(tt.wantRanges) {
+				t.Errorf("got ranges %q, want %q", s.ranges, tt.wantRanges)
+			}
+			entries, _ := os.ReadDir(filepath.Dir(localPath))
+			if len(entries) != 1 {
+				t.Errorf("got %d files, want the downloaded file only", len(entries))
+			}
+		})
+	}
+}
+
+func TestArtifactsDownloadTreeRejectsEscapingPaths(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/2.0/mlflow/runs/get":
+			_ = json.NewEncoder(w).Encode(map[string]any{"run": &mlflow.Run{
+				Info: &mlflow.RunInfo{RunID: "r1", ArtifactUri: "mlflow-artifacts:/0/r1/artifacts"},
+			}})
+		case "/api/2.0/mlflow/artifacts/list":
+			_, _ = w.Write([]byte(`{"files": [{"path": "../escaped.txt", "file_size": 2}]}`))
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+	client, err := mlflow.NewClient(srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	err = client.Artifacts.DownloadTree(context.Background(), "r1", "", filepath.Join(dir, "out"), nil)
+	if err == nil {
+		t.Error("no error for an artifact path outside of the directory")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escaped.txt")); !os.IsNotExist(err) {
+		t.Errorf("the artifact was written outside of the directory: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package mlflow
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// ArtifactStatter is implemented by artifact repositories able to return the metadata of a
+// single artifact without listing its directory.
+type ArtifactStatter interface {
+	// Stat returns the metadata of the artifact at path.
+	Stat(ctx context.Context, path string) (*FileInfo, error)
+}
+
+// Stat returns the metadata of an artifact of the run, including its last modification time
+// and content hash when the run's artifact repository provides them.
+func (s *ArtifactsService) Stat(ctx context.Context, runID, path string) (*FileInfo, error) {
+	run, err := s.client.Runs.Get(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := s.Repository(ctx, run.Info.ArtifactUri)
+	if err != nil {
+		return nil, err
+	}
+
+	if statter, ok := repo.(ArtifactStatter); ok {
+		return statter.Stat(ctx, path)
+	}
+
+	files, err := repo.List(ctx, parentArtifactPath(path))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if f.Path == strings.Trim(path, "/") {
+			return f, nil
+		}
+	}
+
+	return nil, &Error{StatusCode: http.StatusNotFound, ErrorCode: ErrorResourceDoesNotExist, Message: fmt.Sprintf("artifact %q not found", path)}
+}
+
+func (r *proxyRepository) Stat(ctx context.Context, p string) (*FileInfo, error) {
+	res, err := r.client.stream(ctx, "HEAD", r.client.artifactURL(joinArtifactPath(r.root, p)), nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	res.Body.Close()
+
+	f := &FileInfo{Path: strings.Trim(p, "/")}
+	if res.ContentLength > 0 {
+		f.FileSize = res.ContentLength
+	}
+	if t, err := http.ParseTime(res.Header.Get("Last-Modified")); err == nil {
+		f.LastModified = t
+	}
+	if digest := parseDigest(res.Header); digest != nil {
+		f.ContentHash = digest.String()
+	}
+
+	return f, nil
+}
+
+// parentArtifactPath returns the directory of an artifact path, empty for the root.
+func parentArtifactPath(p string) string {
+	dir := path.Dir(strings.Trim(p, "/"))
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// String returns the digest as a FileInfo.ContentHash.
+func (d *artifactDigest) String() string {
+	return d.algorithm + ":" + hex.EncodeToString(d.sum)
+}
+
+// parseContentHash parses a FileInfo.ContentHash, it returns nil if the hash is empty or uses
+// an unknown algorithm.
+func parseContentHash(s string) *artifactDigest {
+	algorithm, value, ok := strings.Cut(s, ":")
+	if !ok {
+		return nil
+	}
+
+	newHash := digestAlgorithms[algorithm]
+	sum, err := hex.DecodeString(value)
+	if newHash == nil || err != nil {
+		return nil
+	}
+
+	return &artifactDigest{algorithm: algorithm, newHash: newHash, sum: sum}
+}
+
+var digestAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha256": sha256.New,
+}
+
+// matchesFile reports whether the content of the file at path has the digest.
+func (d *artifactDigest) matchesFile(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	h := d.newHash()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(h.Sum(nil), d.sum), nil
+}
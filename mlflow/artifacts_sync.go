@@ -0,0 +1,125 @@
+package mlflow
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+type SyncDirection int
+
+const (
+	// SyncUpload uploads the local files missing or changed in the artifacts of the run.
+	SyncUpload SyncDirection = iota
+	// SyncDownload downloads the artifacts of the run missing or changed locally.
+	SyncDownload
+)
+
+type SyncOptions struct {
+	Direction SyncDirection
+	// SizeOnly considers the files of the same size unchanged when the artifact repository
+	// does not provide their content hash, such as the artifact proxy of the tracking server.
+	// They are transferred otherwise.
+	SizeOnly bool
+	// Transfer configures the transfer of the changed files.
+	Transfer *TransferOptions
+}
+
+type SyncResult struct {
+	// Transferred lists the artifact paths of the transferred files.
+	Transferred []string
+	// Unchanged is the number of files which were identical on both sides.
+	Unchanged int
+}
+
+// Sync compares the files below localDir to the artifacts of the run below remotePath and
+// transfers the files that are missing or differ on the destination side, like rsync.
+// Files are compared by size and content hash, see FileInfo.ContentHash; the files whose
+// artifact repository does not provide the hash are transferred, unless opts.SizeOnly is set.
+// Files missing on the source side are left untouched.
+func (s *ArtifactsService) Sync(ctx context.Context, runID, localDir, remotePath string, opts *SyncOptions) (*SyncResult, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+
+	run, err := s.client.Runs.Get(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	// List through the repository, which provides the content hashes of the artifacts when
+	// the store has them.
+	repo, err := s.Repository(ctx, run.Info.ArtifactUri)
+	if err != nil {
+		return nil, err
+	}
+	remoteFiles, err := listRepositoryFiles(ctx, repo, remotePath)
+	if err != nil {
+		return nil, err
+	}
+
+	localFiles, err := listLocalFiles(localDir)
+	if err != nil && !(opts.Direction == SyncDownload && os.IsNotExist(err)) {
+		return nil, err
+	}
+
+	remote := make(map[string]*FileInfo, len(remoteFiles))
+	for _, f := range remoteFiles {
+		remote[relativeArtifactPath(remotePath, f.Path)] = f
+	}
+	local := make(map[string]*localFile, len(localFiles))
+	for _, f := range localFiles {
+		local[f.path] = f
+	}
+
+	res := &SyncResult{}
+
+	switch opts.Direction {
+	case SyncDownload:
+		var changed []*FileInfo
+		for path, f := range remote {
+			if l, ok := local[path]; ok && syncUnchanged(localDir, l, f, opts.SizeOnly) {
+				res.Unchanged++
+				continue
+			}
+			changed = append(changed, f)
+			res.Transferred = append(res.Transferred, f.Path)
+		}
+
+		err = s.downloadFiles(ctx, run.Info.ArtifactUri, remotePath, changed, localDir, opts.Transfer)
+
+	default:
+		var changed []*localFile
+		for path, l := range local {
+			if f, ok := remote[path]; ok && syncUnchanged(localDir, l, f, opts.SizeOnly) {
+				res.Unchanged++
+				continue
+			}
+			changed = append(changed, l)
+			res.Transferred = append(res.Transferred, joinArtifactPath(remotePath, l.path))
+		}
+
+		err = s.uploadFiles(ctx, run.Info.ArtifactUri, localDir, changed, remotePath, opts.Transfer)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// syncUnchanged reports whether a local file below localDir and an artifact are identical,
+// or only of the same size if sizeOnly is set and the artifact has no content hash.
+func syncUnchanged(localDir string, l *localFile, f *FileInfo, sizeOnly bool) bool {
+	if l.size != f.FileSize {
+		return false
+	}
+
+	digest := parseContentHash(f.ContentHash)
+	if digest == nil {
+		return sizeOnly
+	}
+
+	ok, err := digest.matchesFile(filepath.Join(localDir, filepath.FromSlash(l.path)))
+	return err == nil && ok
+}
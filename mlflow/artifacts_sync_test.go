@@ -0,0 +1,68 @@
+package mlflow_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+func TestArtifactsSyncSameSize(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	run, err := client.Runs.Create(ctx, "0", "synced", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.txt")
+	write := func(content string) {
+		t.Helper()
+		err := os.WriteFile(path, []byte(content), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("lr=0.1")
+	_, err = client.Artifacts.Sync(ctx, run.Info.RunID, dir, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The artifact proxy of the fake server, like the one of MLflow, has no content hashes.
+	write("lr=0.2")
+	tests := []struct {
+		name            string
+		opts            *mlflow.SyncOptions
+		wantTransferred int
+	}{
+		{name: "size only", opts: &mlflow.SyncOptions{SizeOnly: true}, wantTransferred: 0},
+		{name: "default", wantTransferred: 1},
+	}
+	for _, tt := range tests {
+		res, err := client.Artifacts.Sync(ctx, run.Info.RunID, dir, "", tt.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Transferred) != tt.wantTransferred {
+			t.Errorf("%s: transferred %v, want %d files", tt.name, res.Transferred, tt.wantTransferred)
+		}
+	}
+
+	r, err := client.Artifacts.Open(ctx, run.Info.RunID, "config.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "lr=0.2" {
+		t.Errorf("got artifact %q, want the edited file", b)
+	}
+}
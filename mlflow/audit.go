@@ -0,0 +1,159 @@
+package mlflow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// AuditEvent records a mutation of a user or a permission made by the client.
+type AuditEvent struct {
+	Time time.Time `json:"time"`
+	// Actor is the username of the client, see Users.Me, empty if unknown.
+	Actor  string `json:"actor,omitempty"`
+	Method string `json:"method"`
+	// Action is the path of the endpoint, such as "experiments/permissions/update".
+	Action string `json:"action"`
+	// Old is the user or permission before the mutation, nil if it did not exist.
+	Old json.RawMessage `json:"old,omitempty"`
+	// New is the request, passwords are redacted.
+	New map[string]any `json:"new,omitempty"`
+	// Error is the error of the mutation, empty if it succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// AuditSink records audit events. Errors of Audit are ignored, they do not fail the
+// mutations.
+type AuditSink interface {
+	Audit(ctx context.Context, event *AuditEvent) error
+}
+
+// AuditSinkFunc is an AuditSink calling a function.
+type AuditSinkFunc func(ctx context.Context, event *AuditEvent) error
+
+func (f AuditSinkFunc) Audit(ctx context.Context, event *AuditEvent) error {
+	return f(ctx, event)
+}
+
+// WithAuditSink records the mutations of users and permissions made by the client to sink.
+// The user or permission is fetched before each mutation, to record its old value.
+func WithAuditSink(sink AuditSink) ClientOption {
+	return func(c *Client) {
+		c.auditSink = sink
+	}
+}
+
+// auditLookups maps the audited endpoints to the endpoint returning the old value and the
+// request fields it takes.
+var auditLookups = map[string]struct {
+	path   string
+	fields []string
+}{
+	"users/create":                         {"users/get", []string{"username"}},
+	"users/update-password":                {"users/get", []string{"username"}},
+	"users/update-admin":                   {"users/get", []string{"username"}},
+	"users/delete":                         {"users/get", []string{"username"}},
+	"experiments/permissions/create":       {"experiments/permissions/get", []string{"experiment_id", "username"}},
+	"experiments/permissions/update":       {"experiments/permissions/get", []string{"experiment_id", "username"}},
+	"experiments/permissions/delete":       {"experiments/permissions/get", []string{"experiment_id", "username"}},
+	"registered-models/permissions/create": {"registered-models/permissions/get", []string{"name", "username"}},
+	"registered-models/permissions/update": {"registered-models/permissions/get", []string{"name", "username"}},
+	"registered-models/permissions/delete": {"registered-models/permissions/get", []string{"name", "username"}},
+}
+
+// audit calls do, recording an audit event if the request is an audited mutation.
+func (c *Client) audit(ctx context.Context, method, path string, body interface{}, do func() error) error {
+	lookup, ok := auditLookups[path]
+	if c.auditSink == nil || !ok {
+		return do()
+	}
+
+	event := &AuditEvent{Method: method, Action: path}
+	// The actor is informational, the mutation fails anyway if the credentials do.
+	event.Actor, _ = c.callerUsername(ctx)
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(b, &event.New)
+	if err != nil {
+		return err
+	}
+	if _, ok := event.New["password"]; ok {
+		event.New["password"] = "[REDACTED]"
+	}
+
+	params := url.Values{}
+	for _, field := range lookup.fields {
+		if v, ok := event.New[field].(string); ok {
+			params.Set(field, v)
+		}
+	}
+	var old json.RawMessage
+	_, err = c.Do(ctx, "GET", lookup.path, params, nil, &old)
+	if err == nil {
+		event.Old = old
+	}
+
+	err = do()
+	event.Time = time.Now()
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	_ = c.auditSink.Audit(ctx, event)
+
+	return err
+}
+
+// NewWriterAuditSink returns a sink writing the events to w as JSON lines, such as to an
+// audit log file.
+func NewWriterAuditSink(w io.Writer) AuditSink {
+	var mu sync.Mutex
+	enc := json.NewEncoder(w)
+
+	return AuditSinkFunc(func(ctx context.Context, event *AuditEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return enc.Encode(event)
+	})
+}
+
+// NewWebhookAuditSink returns a sink posting the events as JSON to endpoint, failing unless
+// the endpoint responds with a 2xx status. If httpClient is nil, http.DefaultClient is used.
+func NewWebhookAuditSink(httpClient *http.Client, endpoint string) AuditSink {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return AuditSinkFunc(func(ctx context.Context, event *AuditEvent) error {
+		b, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(b))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("content-type", "application/json")
+
+		res, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return fmt.Errorf("mlflow: audit webhook %s: %s", endpoint, res.Status)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,24 @@
+//go:build go1.21
+
+package mlflow
+
+import (
+	"context"
+	"log/slog"
+)
+
+// NewSlogAuditSink returns a sink logging the events to logger at the info level. It is
+// available with Go 1.21 and later, which have log/slog.
+func NewSlogAuditSink(logger *slog.Logger) AuditSink {
+	return AuditSinkFunc(func(ctx context.Context, event *AuditEvent) error {
+		logger.InfoContext(ctx, "mlflow audit",
+			slog.String("actor", event.Actor),
+			slog.String("method", event.Method),
+			slog.String("action", event.Action),
+			slog.String("old", string(event.Old)),
+			slog.Any("new", event.New),
+			slog.String("error", event.Error),
+		)
+		return nil
+	})
+}
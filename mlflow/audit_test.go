@@ -0,0 +1,25 @@
+package mlflow_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+func TestWebhookAuditSinkStatus(t *testing.T) {
+	for _, status := range []int{http.StatusOK, http.StatusNoContent, http.StatusMovedPermanently, http.StatusBadRequest, http.StatusServiceUnavailable} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		sink := mlflow.NewWebhookAuditSink(srv.Client(), srv.URL)
+		err := sink.Audit(context.Background(), &mlflow.AuditEvent{})
+		if ok := status < 300; (err == nil) != ok {
+			t.Errorf("status %d: got error %v", status, err)
+		}
+		srv.Close()
+	}
+}
@@ -0,0 +1,28 @@
+package mlflow
+
+import (
+	"context"
+
+	"github.com/codeocean/go-mlflow/internal/concurrency"
+)
+
+// defaultConcurrency bounds the number of requests bulk helpers issue concurrently.
+const defaultConcurrency = 8
+
+// forEach calls fn for every index in [0, n), running at most limit calls concurrently.
+// After the first error the remaining calls are skipped and that error is returned.
+func forEach(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) error {
+	return concurrency.ForEach(ctx, n, limit, fn)
+}
+
+// setTags calls set for every tag, issuing the requests concurrently.
+func setTags(ctx context.Context, tags map[string]string, set func(ctx context.Context, key, value string) error) error {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+
+	return forEach(ctx, len(keys), defaultConcurrency, func(ctx context.Context, i int) error {
+		return set(ctx, keys[i], tags[keys[i]])
+	})
+}
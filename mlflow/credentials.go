@@ -0,0 +1,98 @@
+package mlflow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Credentials authenticate the requests of a client, with a bearer token if Token is set and
+// with HTTP basic authentication otherwise.
+type Credentials struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
+
+// CredentialsProvider returns the credentials of the next request, such as credentials
+// stored in a secret store. It is called for every request and must be safe for concurrent
+// use.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (*Credentials, error)
+}
+
+// CredentialsProviderFunc is a CredentialsProvider calling a function.
+type CredentialsProviderFunc func(ctx context.Context) (*Credentials, error)
+
+func (f CredentialsProviderFunc) Credentials(ctx context.Context) (*Credentials, error) {
+	return f(ctx)
+}
+
+// WithCredentialsProvider authenticates the requests of the client with the credentials of
+// provider, see the vaultsecrets, awssecrets and gcpsecrets packages. It takes precedence
+// over WithBasicAuth and WithTokenProvider.
+func WithCredentialsProvider(provider CredentialsProvider) ClientOption {
+	return func(c *Client) {
+		c.credentialsProvider = provider
+	}
+}
+
+// CachingCredentialsProvider returns the credentials of provider, fetching them again once
+// they are older than ttl. If fetching them again fails, the previous credentials are
+// returned until they are twice as old as ttl, to ride out outages of the secret store.
+func CachingCredentialsProvider(provider CredentialsProvider, ttl time.Duration) CredentialsProvider {
+	var (
+		mu          sync.Mutex
+		credentials *Credentials
+		fetched     time.Time
+	)
+
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		age := time.Since(fetched)
+		if credentials != nil && age < ttl {
+			return credentials, nil
+		}
+
+		c, err := provider.Credentials(ctx)
+		if err != nil {
+			if credentials != nil && age < 2*ttl {
+				return credentials, nil
+			}
+			return nil, err
+		}
+		credentials = c
+		fetched = time.Now()
+
+		return credentials, nil
+	})
+}
+
+// ParseCredentials parses a secret holding credentials: a JSON object with username and
+// password or token fields, or a bare token.
+func ParseCredentials(secret []byte) (*Credentials, error) {
+	secret = bytes.TrimSpace(secret)
+	if len(secret) == 0 || string(secret) == "null" {
+		return nil, errors.New("mlflow: empty credentials secret")
+	}
+
+	if secret[0] != '{' {
+		return &Credentials{Token: string(secret)}, nil
+	}
+
+	var c Credentials
+	err := json.Unmarshal(secret, &c)
+	if err != nil {
+		return nil, err
+	}
+	if c.Token == "" && c.Username == "" {
+		return nil, errors.New("mlflow: credentials secret has neither token nor username")
+	}
+
+	return &c, nil
+}
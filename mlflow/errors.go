@@ -1,11 +1,16 @@
 package mlflow
 
+import "errors"
+
 const (
 	// ErrorResourceAlreadyExists indicates that a resource with the given name already exists.
 	ErrorResourceAlreadyExists = "RESOURCE_ALREADY_EXISTS"
 
 	// ErrorResourceDoesNotExist indicates that the requested resource does not exist.
 	ErrorResourceDoesNotExist = "RESOURCE_DOES_NOT_EXIST"
+
+	// ErrorInvalidParameterValue indicates that a parameter of the request is invalid.
+	ErrorInvalidParameterValue = "INVALID_PARAMETER_VALUE"
 )
 
 // Error represents an error returned by the MLflow API.
@@ -19,3 +24,17 @@ type Error struct {
 func (e *Error) Error() string {
 	return e.Message
 }
+
+// IsResourceDoesNotExist reports whether err is an MLflow API error indicating that
+// the requested resource does not exist.
+func IsResourceDoesNotExist(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.ErrorCode == ErrorResourceDoesNotExist
+}
+
+// IsResourceAlreadyExists reports whether err is an MLflow API error indicating that
+// a resource with the given name already exists.
+func IsResourceAlreadyExists(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.ErrorCode == ErrorResourceAlreadyExists
+}
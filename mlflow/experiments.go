@@ -11,17 +11,27 @@ type Experiment struct {
 	ExperimentID     string           `json:"experiment_id,omitempty"`
 	Name             string           `json:"name,omitempty"`
 	ArtifactLocation string           `json:"artifact_location,omitempty"`
-	LifecycleStage   string           `json:"lifecycle_stage,omitempty"`
+	LifecycleStage   LifecycleStage   `json:"lifecycle_stage,omitempty"`
 	LastUpdateTime   int64            `json:"last_update_time,omitempty"`
 	CreationTime     int64            `json:"creation_time,omitempty"`
 	Tags             []*ExperimentTag `json:"tags,omitempty"`
 }
 
+func (e *Experiment) IsDeleted() bool {
+	return e.LifecycleStage == LifecycleStageDeleted
+}
+
 type ExperimentTag struct {
 	Key   string `json:"key,omitempty"`
 	Value string `json:"value,omitempty"`
 }
 
+type ExperimentCreateOptions struct {
+	Name             string           `json:"name,omitempty"`
+	ArtifactLocation string           `json:"artifact_location,omitempty"`
+	Tags             []*ExperimentTag `json:"tags,omitempty"`
+}
+
 type ExperimentsSearchOptions struct {
 	Filter     string   `json:"filter,omitempty"`
 	ViewType   ViewType `json:"view_type,omitempty"`
@@ -36,17 +46,15 @@ type ExperimentsSearchResults struct {
 }
 
 func (s *ExperimentService) Create(ctx context.Context, name string) (string, error) {
-	opts := struct {
-		Name string `json:"name,omitempty"`
-	}{
-		Name: name,
-	}
+	return s.CreateWithOptions(ctx, &ExperimentCreateOptions{Name: name})
+}
 
+func (s *ExperimentService) CreateWithOptions(ctx context.Context, opts *ExperimentCreateOptions) (string, error) {
 	var res struct {
 		ExperimentID string `json:"experiment_id,omitempty"`
 	}
 
-	_, err := s.client.Do(ctx, "POST", "experiments/create", nil, &opts, &res)
+	_, err := s.client.Do(ctx, "POST", "experiments/create", nil, opts, &res)
 	if err != nil {
 		return "", err
 	}
@@ -104,6 +112,19 @@ func (s *ExperimentService) SetTag(ctx context.Context, id, key, value string) e
 	return err
 }
 
+func (s *ExperimentService) DeleteTag(ctx context.Context, id, key string) error {
+	opts := struct {
+		ExperimentID string `json:"experiment_id,omitempty"`
+		Key          string `json:"key,omitempty"`
+	}{
+		ExperimentID: id,
+		Key:          key,
+	}
+
+	_, err := s.client.Do(ctx, "POST", "experiments/delete-experiment-tag", nil, &opts, nil)
+	return err
+}
+
 func (s *ExperimentService) Get(ctx context.Context, id string) (*Experiment, error) {
 	var res struct {
 		Experiment *Experiment `json:"experiment,omitempty"`
@@ -146,3 +167,32 @@ func (s *ExperimentService) Search(ctx context.Context, opts *ExperimentsSearchO
 
 	return &res, nil
 }
+
+func (s *ExperimentService) Iterate(ctx context.Context, opts *ExperimentsSearchOptions) *Iterator[*Experiment] {
+	if opts == nil {
+		opts = &ExperimentsSearchOptions{}
+	}
+	o := *opts
+
+	return newIterator(ctx, o.PageToken, func(ctx context.Context, pageToken string) ([]*Experiment, string, error) {
+		o.PageToken = pageToken
+
+		res, err := s.Search(ctx, &o)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return res.Experiments, res.NextPageToken, nil
+	})
+}
+
+func (s *ExperimentService) SearchAll(ctx context.Context, opts *ExperimentsSearchOptions) ([]*Experiment, error) {
+	return s.Iterate(ctx, opts).All()
+}
+
+// SetTags sets multiple tags on an experiment, issuing the requests concurrently.
+func (s *ExperimentService) SetTags(ctx context.Context, id string, tags map[string]string) error {
+	return setTags(ctx, tags, func(ctx context.Context, key, value string) error {
+		return s.SetTag(ctx, id, key, value)
+	})
+}
@@ -0,0 +1,200 @@
+package mlflow
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Names of the files of an experiment export.
+const (
+	exportExperimentFile = "experiment.json"
+	exportRunFile        = "run.json"
+	exportArtifactsDir   = "artifacts"
+)
+
+type exportSystem struct {
+	PackageVersion    string `json:"package_version,omitempty"`
+	ExportTime        int64  `json:"export_time,omitempty"`
+	ExportTimeISO     string `json:"_export_time,omitempty"`
+	MLflowTrackingURI string `json:"mlflow_tracking_uri,omitempty"`
+}
+
+type exportedExperimentFile struct {
+	System exportSystem `json:"system"`
+	Info   struct {
+		NumTotalRuns  int      `json:"num_total_runs"`
+		NumOkRuns     int      `json:"num_ok_runs"`
+		NumFailedRuns int      `json:"num_failed_runs"`
+		FailedRuns    []string `json:"failed_runs"`
+	} `json:"info"`
+	MLflow struct {
+		Experiment *exportedExperiment `json:"experiment"`
+		Runs       []string            `json:"runs"`
+	} `json:"mlflow"`
+}
+
+type exportedExperiment struct {
+	ExperimentID     string            `json:"experiment_id"`
+	Name             string            `json:"name"`
+	ArtifactLocation string            `json:"artifact_location,omitempty"`
+	LifecycleStage   LifecycleStage    `json:"lifecycle_stage,omitempty"`
+	CreationTime     int64             `json:"creation_time,omitempty"`
+	LastUpdateTime   int64             `json:"last_update_time,omitempty"`
+	Tags             map[string]string `json:"tags"`
+}
+
+type exportedRunFile struct {
+	System exportSystem `json:"system"`
+	MLflow exportedRun  `json:"mlflow"`
+}
+
+type exportedRun struct {
+	Info    *RunInfo                    `json:"info"`
+	Params  map[string]string           `json:"params"`
+	Metrics map[string][]exportedMetric `json:"metrics"`
+	Tags    map[string]string           `json:"tags"`
+	Inputs  *RunInputs                  `json:"inputs,omitempty"`
+}
+
+type exportedMetric struct {
+	Value     float64 `json:"value"`
+	Timestamp int64   `json:"timestamp"`
+	Step      int64   `json:"step"`
+}
+
+// Export writes an experiment, its active runs, their full metric histories and their
+// artifacts to dir, using the layout of mlflow-export-import:
+//
+//	dir/
+//	  experiment.json        experiment metadata and the IDs of the exported runs
+//	  <run_id>/
+//	    run.json             run info, params, tags, inputs and metric histories
+//	    artifacts/           the artifacts of the run
+//
+// Artifacts are downloaded through the artifact repositories of the runs, see
+// ArtifactsService.Repository.
+func (s *ExperimentService) Export(ctx context.Context, id, dir string) error {
+	experiment, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	runs, err := s.client.Runs.SearchAll(ctx, &RunSearchOptions{ExperimentIDs: []string{id}})
+	if err != nil {
+		return err
+	}
+
+	system := s.client.exportSystem()
+
+	var out exportedExperimentFile
+	out.System = system
+	out.Info.FailedRuns = []string{}
+	out.MLflow.Experiment = &exportedExperiment{
+		ExperimentID:     experiment.ExperimentID,
+		Name:             experiment.Name,
+		ArtifactLocation: experiment.ArtifactLocation,
+		LifecycleStage:   experiment.LifecycleStage,
+		CreationTime:     experiment.CreationTime,
+		LastUpdateTime:   experiment.LastUpdateTime,
+		Tags:             map[string]string{},
+	}
+	for _, tag := range experiment.Tags {
+		out.MLflow.Experiment.Tags[tag.Key] = tag.Value
+	}
+	out.MLflow.Runs = []string{}
+
+	for _, run := range runs {
+		err = s.client.Runs.export(ctx, run, filepath.Join(dir, run.Info.RunID), system)
+		if err != nil {
+			return err
+		}
+		out.MLflow.Runs = append(out.MLflow.Runs, run.Info.RunID)
+	}
+	out.Info.NumTotalRuns = len(runs)
+	out.Info.NumOkRuns = len(runs)
+
+	return writeJSONFile(filepath.Join(dir, exportExperimentFile), &out)
+}
+
+func (s *RunService) export(ctx context.Context, run *Run, dir string, system exportSystem) error {
+	out := exportedRunFile{
+		System: system,
+		MLflow: exportedRun{
+			Info:    run.Info,
+			Params:  map[string]string{},
+			Metrics: map[string][]exportedMetric{},
+			Tags:    map[string]string{},
+			Inputs:  run.Inputs,
+		},
+	}
+
+	if run.Data != nil {
+		for _, param := range run.Data.Params {
+			out.MLflow.Params[param.Key] = param.Value
+		}
+		for _, tag := range run.Data.Tags {
+			out.MLflow.Tags[tag.Key] = tag.Value
+		}
+		for _, metric := range run.Data.Metrics {
+			history, err := s.client.Metrics.getFullHistory(ctx, run.Info.RunID, metric.Key)
+			if err != nil {
+				return err
+			}
+
+			values := make([]exportedMetric, 0, len(history))
+			for _, m := range history {
+				values = append(values, exportedMetric{Value: m.Value, Timestamp: m.Timestamp, Step: m.Step})
+			}
+			out.MLflow.Metrics[metric.Key] = values
+		}
+	}
+
+	files, err := s.client.Artifacts.listFiles(ctx, run.Info.RunID, "")
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		localPath, err := localArtifactPath(filepath.Join(dir, exportArtifactsDir), f.Path)
+		if err != nil {
+			return err
+		}
+		err = s.client.Artifacts.downloadFile(ctx, run.Info.ArtifactUri, f.Path, localPath, expectedSize(f), nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeJSONFile(filepath.Join(dir, exportRunFile), &out)
+}
+
+func (c *Client) exportSystem() exportSystem {
+	trackingURI := *c.baseURL
+	trackingURI.User = nil
+	trackingURI.Path = strings.TrimSuffix(trackingURI.Path, "api/2.0/mlflow/")
+
+	now := time.Now()
+	return exportSystem{
+		PackageVersion:    "go-mlflow",
+		ExportTime:        now.Unix(),
+		ExportTimeISO:     now.UTC().Format(time.RFC3339),
+		MLflowTrackingURI: trackingURI.String(),
+	}
+}
+
+func writeJSONFile(path string, v interface{}) error {
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0o644)
+}
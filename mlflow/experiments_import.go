@@ -0,0 +1,207 @@
+package mlflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Limits of a single runs/log-batch request.
+const (
+	logBatchMaxMetrics = 1000
+	logBatchMaxParams  = 100
+	logBatchMaxTags    = 100
+)
+
+type ImportResult struct {
+	ExperimentID string
+	// RunIDs maps the IDs of the exported runs to the IDs of the imported runs.
+	RunIDs map[string]string
+}
+
+// Import recreates an experiment exported by Export from dir.
+// Imported runs get new IDs and artifact locations: tag values referring to the exported
+// run IDs or artifact URIs, such as mlflow.parentRunId, are rewritten to the new ones.
+// Artifacts are uploaded through the artifact repositories of the new runs, see
+// ArtifactsService.Repository.
+func (s *ExperimentService) Import(ctx context.Context, dir string) (*ImportResult, error) {
+	var in exportedExperimentFile
+	err := readJSONFile(filepath.Join(dir, exportExperimentFile), &in)
+	if err != nil {
+		return nil, err
+	}
+	if in.MLflow.Experiment == nil {
+		return nil, fmt.Errorf("mlflow: %s does not describe an experiment", exportExperimentFile)
+	}
+
+	opts := &ExperimentCreateOptions{Name: in.MLflow.Experiment.Name}
+	for key, value := range in.MLflow.Experiment.Tags {
+		opts.Tags = append(opts.Tags, &ExperimentTag{Key: key, Value: value})
+	}
+
+	experimentID, err := s.CreateWithOptions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make([]string, len(in.MLflow.Runs))
+	for i, id := range in.MLflow.Runs {
+		dirs[i] = filepath.Join(dir, id)
+	}
+
+	runIDs, _, err := s.client.Runs.importRuns(ctx, experimentID, dirs)
+	return &ImportResult{ExperimentID: experimentID, RunIDs: runIDs}, err
+}
+
+// importRuns recreates the runs exported to dirs in an experiment. It returns the map of the
+// IDs of the exported runs to the IDs of the imported runs, which holds the runs imported
+// before an error, and the replacer of the IDs and artifact URIs of the exported runs by the
+// ones of the imported runs.
+func (s *RunService) importRuns(ctx context.Context, experimentID string, dirs []string) (map[string]string, *strings.Replacer, error) {
+	runIDs := map[string]string{}
+
+	runs := make([]*exportedRun, 0, len(dirs))
+	replacements := []string{}
+	newRuns := make([]*Run, 0, len(dirs))
+	for _, dir := range dirs {
+		var run exportedRunFile
+		err := readJSONFile(filepath.Join(dir, exportRunFile), &run)
+		if err != nil {
+			return runIDs, nil, err
+		}
+
+		if run.MLflow.Info == nil {
+			run.MLflow.Info = &RunInfo{}
+		}
+		id := run.MLflow.Info.RunID
+		if id == "" {
+			id = filepath.Base(dir)
+		}
+
+		newRun, err := s.Create(ctx, experimentID, run.MLflow.Info.RunName, run.MLflow.Info.StartTime, nil)
+		if err != nil {
+			return runIDs, nil, err
+		}
+
+		runs = append(runs, &run.MLflow)
+		newRuns = append(newRuns, newRun)
+		runIDs[id] = newRun.Info.RunID
+		if run.MLflow.Info.ArtifactUri != "" {
+			replacements = append(replacements, run.MLflow.Info.ArtifactUri, newRun.Info.ArtifactUri)
+		}
+		replacements = append(replacements, id, newRun.Info.RunID)
+	}
+	rewrite := strings.NewReplacer(replacements...)
+
+	for i, run := range runs {
+		err := s.importRun(ctx, run, newRuns[i], filepath.Join(dirs[i], exportArtifactsDir), rewrite)
+		if err != nil {
+			return runIDs, nil, err
+		}
+	}
+
+	return runIDs, rewrite, nil
+}
+
+func (s *RunService) importRun(ctx context.Context, run *exportedRun, newRun *Run, artifactsDir string, rewrite *strings.Replacer) error {
+	id := newRun.Info.RunID
+
+	var params []*Param
+	for key, value := range run.Params {
+		params = append(params, &Param{Key: key, Value: value})
+	}
+	for len(params) > 0 {
+		n := chunkSize(len(params), logBatchMaxParams)
+		err := s.LogBatch(ctx, id, &RunData{Params: params[:n]})
+		if err != nil {
+			return err
+		}
+		params = params[n:]
+	}
+
+	var tags []*RunTag
+	for key, value := range run.Tags {
+		tags = append(tags, &RunTag{Key: key, Value: rewrite.Replace(value)})
+	}
+	for len(tags) > 0 {
+		n := chunkSize(len(tags), logBatchMaxTags)
+		err := s.LogBatch(ctx, id, &RunData{Tags: tags[:n]})
+		if err != nil {
+			return err
+		}
+		tags = tags[n:]
+	}
+
+	var metrics []*Metric
+	for key, values := range run.Metrics {
+		for _, m := range values {
+			metrics = append(metrics, &Metric{Key: key, Value: m.Value, Timestamp: m.Timestamp, Step: m.Step})
+		}
+	}
+	for len(metrics) > 0 {
+		n := chunkSize(len(metrics), logBatchMaxMetrics)
+		err := s.LogBatch(ctx, id, &RunData{Metrics: metrics[:n]})
+		if err != nil {
+			return err
+		}
+		metrics = metrics[n:]
+	}
+
+	if run.Inputs != nil && len(run.Inputs.DatasetInputs) > 0 {
+		err := s.LogInputs(ctx, id, run.Inputs.DatasetInputs)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := filepath.WalkDir(artifactsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(artifactsDir, path)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		return s.client.Artifacts.upload(ctx, newRun.Info.ArtifactUri, filepath.ToSlash(rel), f)
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if run.Info.Status != "" && run.Info.Status != RunStatusRunning {
+		_, err = s.Update(ctx, id, "", run.Info.Status, run.Info.EndTime)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readJSONFile(path string, v interface{}) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+func chunkSize(n, max int) int {
+	if n < max {
+		return n
+	}
+	return max
+}
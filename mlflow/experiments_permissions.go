@@ -39,11 +39,19 @@ func (s *ExperimentService) CreatePermission(ctx context.Context, id, username s
 	if err != nil {
 		return nil, err
 	}
+	s.client.permissionCache.evict(permissionCacheKey{"experiment", id, username})
 
 	return res.ExperimentPermission, nil
 }
 
 func (s *ExperimentService) GetPermission(ctx context.Context, id, username string) (*ExperimentPermission, error) {
+	key := permissionCacheKey{"experiment", id, username}
+	return cachedPermission(s.client.permissionCache, key, func() (*ExperimentPermission, error) {
+		return s.getPermission(ctx, id, username)
+	})
+}
+
+func (s *ExperimentService) getPermission(ctx context.Context, id, username string) (*ExperimentPermission, error) {
 	var res struct {
 		ExperimentPermission *ExperimentPermission `json:"experiment_permission,omitempty"`
 	}
@@ -72,7 +80,12 @@ func (s *ExperimentService) UpdatePermission(ctx context.Context, id, username s
 	}
 
 	_, err := s.client.Do(ctx, "PATCH", "experiments/permissions/update", nil, &opts, nil)
-	return err
+	if err != nil {
+		return err
+	}
+	s.client.permissionCache.evict(permissionCacheKey{"experiment", id, username})
+
+	return nil
 }
 
 func (s *ExperimentService) DeletePermission(ctx context.Context, id, username string) error {
@@ -85,5 +98,78 @@ func (s *ExperimentService) DeletePermission(ctx context.Context, id, username s
 	}
 
 	_, err := s.client.Do(ctx, "DELETE", "experiments/permissions/delete", nil, &opts, nil)
+	if err != nil {
+		return err
+	}
+	s.client.permissionCache.evict(permissionCacheKey{"experiment", id, username})
+
+	return nil
+}
+
+// ListPermissions returns the explicit permissions the given users have on an experiment.
+// The MLflow authentication API cannot enumerate users, so the users to look up must be
+// given; users without an explicit permission on the experiment are omitted.
+func (s *ExperimentService) ListPermissions(ctx context.Context, id string, usernames []string) ([]*ExperimentPermission, error) {
+	permissions := make([]*ExperimentPermission, len(usernames))
+
+	err := forEach(ctx, len(usernames), defaultConcurrency, func(ctx context.Context, i int) error {
+		permission, err := s.GetPermission(ctx, id, usernames[i])
+		if IsResourceDoesNotExist(err) {
+			return nil
+		}
+		permissions[i] = permission
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ExperimentPermission, 0, len(permissions))
+	for _, permission := range permissions {
+		if permission != nil {
+			res = append(res, permission)
+		}
+	}
+
+	return res, nil
+}
+
+// GrantPermission gives a user a permission on an experiment, creating the permission or
+// updating the existing one.
+func (s *ExperimentService) GrantPermission(ctx context.Context, id, username string, permission Permission) error {
+	_, err := s.CreatePermission(ctx, id, username, permission)
+	if IsResourceAlreadyExists(err) {
+		return s.UpdatePermission(ctx, id, username, permission)
+	}
 	return err
 }
+
+// GrantPermissions gives a user a permission on several experiments, see GrantPermission,
+// issuing the requests concurrently. Failures do not stop the other grants; they are
+// returned keyed by experiment ID, the result is nil if all the grants succeeded.
+func (s *ExperimentService) GrantPermissions(ctx context.Context, ids []string, username string, permission Permission) map[string]error {
+	errs := make([]error, len(ids))
+	started := make([]bool, len(ids))
+
+	_ = forEach(ctx, len(ids), defaultConcurrency, func(ctx context.Context, i int) error {
+		started[i] = true
+		errs[i] = s.GrantPermission(ctx, ids[i], username, permission)
+		return nil
+	})
+
+	var res map[string]error
+	for i, err := range errs {
+		if !started[i] {
+			// Skipped after ctx was canceled.
+			err = ctx.Err()
+		}
+		if err != nil {
+			if res == nil {
+				res = map[string]error{}
+			}
+			res[ids[i]] = err
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,56 @@
+package mlflow
+
+import "context"
+
+type ExperimentSummary struct {
+	ExperimentID string
+	NumRuns      int
+	RunsByStatus map[RunStatus]int
+	// LatestRun is the run that started last, nil if the experiment has no runs.
+	LatestRun *Run
+	// BestRun is the run with the best latest value of the summarized metric,
+	// nil if no run logged the metric.
+	BestRun   *Run
+	BestValue float64
+}
+
+// Summary summarizes the active runs of an experiment. The best run is the run with
+// the highest latest value of metricKey if higherIsBetter, or the lowest otherwise.
+func (s *ExperimentService) Summary(ctx context.Context, id, metricKey string, higherIsBetter bool) (*ExperimentSummary, error) {
+	res := &ExperimentSummary{
+		ExperimentID: id,
+		RunsByStatus: map[RunStatus]int{},
+	}
+
+	it := s.client.Runs.Iterate(ctx, &RunSearchOptions{ExperimentIDs: []string{id}})
+	for it.Next() {
+		run := it.Value()
+		if run.Info == nil {
+			continue
+		}
+
+		res.NumRuns++
+		res.RunsByStatus[run.Info.Status]++
+
+		if res.LatestRun == nil || run.Info.StartTime > res.LatestRun.Info.StartTime {
+			res.LatestRun = run
+		}
+
+		if metricKey == "" {
+			continue
+		}
+		value, ok := run.Data.LatestMetrics()[metricKey]
+		if !ok {
+			continue
+		}
+		if res.BestRun == nil || (higherIsBetter && value > res.BestValue) || (!higherIsBetter && value < res.BestValue) {
+			res.BestRun = run
+			res.BestValue = value
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,105 @@
+package mlflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeocean/go-mlflow/internal/filterstring"
+)
+
+// ExperimentFilter builds filter expressions for ExperimentsSearchOptions.Filter, quoting
+// values and keys as needed. Conditions are combined with AND, the only conjunction
+// supported by MLflow search.
+//
+//	filter := mlflow.NewExperimentFilter().
+//		NameLike("fraud-%").
+//		Tag("team", "risk").
+//		CreatedAfter(time.Now().AddDate(0, -1, 0))
+//	opts := &mlflow.ExperimentsSearchOptions{Filter: filter.String()}
+type ExperimentFilter struct {
+	clauses []string
+}
+
+func NewExperimentFilter() *ExperimentFilter {
+	return &ExperimentFilter{}
+}
+
+func (f *ExperimentFilter) NameEquals(name string) *ExperimentFilter {
+	return f.add("name", "=", quoteFilterString(name))
+}
+
+func (f *ExperimentFilter) NameNotEquals(name string) *ExperimentFilter {
+	return f.add("name", "!=", quoteFilterString(name))
+}
+
+// NameLike matches names against a case-sensitive SQL LIKE pattern.
+func (f *ExperimentFilter) NameLike(pattern string) *ExperimentFilter {
+	return f.add("name", "LIKE", quoteFilterString(pattern))
+}
+
+// NameILike matches names against a case-insensitive SQL LIKE pattern.
+func (f *ExperimentFilter) NameILike(pattern string) *ExperimentFilter {
+	return f.add("name", "ILIKE", quoteFilterString(pattern))
+}
+
+func (f *ExperimentFilter) Tag(key, value string) *ExperimentFilter {
+	return f.add(filterKey("tags", key), "=", quoteFilterString(value))
+}
+
+func (f *ExperimentFilter) TagNotEquals(key, value string) *ExperimentFilter {
+	return f.add(filterKey("tags", key), "!=", quoteFilterString(value))
+}
+
+// TagLike matches the value of a tag against a case-sensitive SQL LIKE pattern.
+func (f *ExperimentFilter) TagLike(key, pattern string) *ExperimentFilter {
+	return f.add(filterKey("tags", key), "LIKE", quoteFilterString(pattern))
+}
+
+// TagILike matches the value of a tag against a case-insensitive SQL LIKE pattern.
+func (f *ExperimentFilter) TagILike(key, pattern string) *ExperimentFilter {
+	return f.add(filterKey("tags", key), "ILIKE", quoteFilterString(pattern))
+}
+
+func (f *ExperimentFilter) CreatedAfter(t time.Time) *ExperimentFilter {
+	return f.add("creation_time", ">", fmt.Sprint(t.UnixMilli()))
+}
+
+func (f *ExperimentFilter) CreatedBefore(t time.Time) *ExperimentFilter {
+	return f.add("creation_time", "<", fmt.Sprint(t.UnixMilli()))
+}
+
+func (f *ExperimentFilter) UpdatedAfter(t time.Time) *ExperimentFilter {
+	return f.add("last_update_time", ">", fmt.Sprint(t.UnixMilli()))
+}
+
+func (f *ExperimentFilter) UpdatedBefore(t time.Time) *ExperimentFilter {
+	return f.add("last_update_time", "<", fmt.Sprint(t.UnixMilli()))
+}
+
+// String returns the filter expression.
+func (f *ExperimentFilter) String() string {
+	return strings.Join(f.clauses, " AND ")
+}
+
+func (f *ExperimentFilter) add(key, comparator, value string) *ExperimentFilter {
+	f.clauses = append(f.clauses, key+" "+comparator+" "+value)
+	return f
+}
+
+var filterIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// filterKey returns an entity key such as tags.team, quoting the key with backticks
+// when it is not a plain identifier.
+func filterKey(entity, key string) string {
+	if filterIdentifier.MatchString(key) {
+		return entity + "." + key
+	}
+	return entity + ".`" + key + "`"
+}
+
+// quoteFilterString returns s as a string literal of the MLflow search syntax.
+func quoteFilterString(s string) string {
+	return filterstring.Quote(s)
+}
@@ -0,0 +1,71 @@
+package mlflow
+
+import "context"
+
+// Iterator iterates over the results of a paginated API, fetching pages as they are needed.
+//
+//	it := client.Experiments.Iterate(ctx, opts)
+//	for it.Next() {
+//		experiment := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, pageToken string) ([]T, string, error)
+	page  []T
+	cur   T
+	token string
+	done  bool
+	err   error
+}
+
+func newIterator[T any](ctx context.Context, pageToken string, fetch func(ctx context.Context, pageToken string) ([]T, string, error)) *Iterator[T] {
+	return &Iterator[T]{
+		ctx:   ctx,
+		fetch: fetch,
+		token: pageToken,
+	}
+}
+
+// Next advances the iterator to the next value, returning false when there are no more
+// values or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		it.page, it.token, it.err = it.fetch(it.ctx, it.token)
+		if it.err != nil {
+			return false
+		}
+		if it.token == "" {
+			it.done = true
+		}
+	}
+
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current value of the iterator.
+func (it *Iterator[T]) Value() T {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All consumes the iterator and returns the remaining values.
+func (it *Iterator[T]) All() ([]T, error) {
+	var res []T
+	for it.Next() {
+		res = append(res, it.Value())
+	}
+
+	return res, it.Err()
+}
@@ -0,0 +1,188 @@
+package mlflow
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// TagLogModelHistory is the run tag listing the models logged to a run.
+const TagLogModelHistory = "mlflow.log-model.history"
+
+// RunLoggedModel is an entry of the TagLogModelHistory tag of a run.
+type RunLoggedModel struct {
+	RunID          string                     `json:"run_id,omitempty"`
+	ArtifactPath   string                     `json:"artifact_path,omitempty"`
+	UTCTimeCreated string                     `json:"utc_time_created,omitempty"`
+	ModelUUID      string                     `json:"model_uuid,omitempty"`
+	Flavors        map[string]json.RawMessage `json:"flavors,omitempty"`
+}
+
+type LineageNodeType string
+
+const (
+	LineageNodeRun          LineageNodeType = "run"
+	LineageNodeDataset      LineageNodeType = "dataset"
+	LineageNodeLoggedModel  LineageNodeType = "logged_model"
+	LineageNodeModelVersion LineageNodeType = "model_version"
+)
+
+// LineageNode is an entity of a lineage graph, only the field matching its type is set.
+type LineageNode struct {
+	Type LineageNodeType
+	// ID identifies the node in the graph: the run ID for runs, name@digest for datasets,
+	// run_id/artifact_path for logged models and name/version for model versions.
+	ID string
+
+	Run          *Run
+	Dataset      *Dataset
+	LoggedModel  *RunLoggedModel
+	ModelVersion *ModelVersion
+}
+
+// LineageEdge links an entity to an entity derived from it, such as a dataset to the run
+// which used it.
+type LineageEdge struct {
+	From string
+	To   string
+}
+
+// LineageGraph links the datasets used by a run, the models it logged and the model versions
+// registered from them.
+type LineageGraph struct {
+	Nodes []*LineageNode
+	Edges []*LineageEdge
+}
+
+// Node returns the node with the given ID, nil if there is none.
+func (g *LineageGraph) Node(id string) *LineageNode {
+	for _, n := range g.Nodes {
+		if n.ID == id {
+			return n
+		}
+	}
+	return nil
+}
+
+// Inputs returns the nodes the node with the given ID is derived from.
+func (g *LineageGraph) Inputs(id string) []*LineageNode {
+	var res []*LineageNode
+	for _, e := range g.Edges {
+		if e.To == id {
+			res = append(res, g.Node(e.From))
+		}
+	}
+	return res
+}
+
+// Outputs returns the nodes derived from the node with the given ID.
+func (g *LineageGraph) Outputs(id string) []*LineageNode {
+	var res []*LineageNode
+	for _, e := range g.Edges {
+		if e.From == id {
+			res = append(res, g.Node(e.To))
+		}
+	}
+	return res
+}
+
+func (g *LineageGraph) add(n *LineageNode) {
+	if g.Node(n.ID) == nil {
+		g.Nodes = append(g.Nodes, n)
+	}
+}
+
+func (g *LineageGraph) link(from, to string) {
+	g.Edges = append(g.Edges, &LineageEdge{From: from, To: to})
+}
+
+// RunLineage returns the lineage graph of a run: the datasets it used, the models it logged,
+// listed by its TagLogModelHistory tag, and the model versions registered from the run.
+func (c *Client) RunLineage(ctx context.Context, runID string) (*LineageGraph, error) {
+	run, err := c.Runs.Get(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &LineageGraph{}
+	g.add(&LineageNode{Type: LineageNodeRun, ID: runID, Run: run})
+
+	if run.Inputs != nil {
+		for _, input := range run.Inputs.DatasetInputs {
+			if input.Dataset == nil {
+				continue
+			}
+			id := input.Dataset.Name + "@" + input.Dataset.Digest
+			g.add(&LineageNode{Type: LineageNodeDataset, ID: id, Dataset: input.Dataset})
+			g.link(id, runID)
+		}
+	}
+
+	var models []*RunLoggedModel
+	if run.Data != nil {
+		for _, tag := range run.Data.Tags {
+			if tag.Key == TagLogModelHistory {
+				// The tag is informational, ignore it if it is malformed.
+				_ = json.Unmarshal([]byte(tag.Value), &models)
+			}
+		}
+	}
+	for _, m := range models {
+		id := joinArtifactPath(runID, m.ArtifactPath)
+		g.add(&LineageNode{Type: LineageNodeLoggedModel, ID: id, LoggedModel: m})
+		g.link(runID, id)
+	}
+
+	versions, err := c.ModelVersions.SearchAll(ctx, &ModelVersionsSearchOptions{
+		Filter: "run_id = " + quoteFilterString(runID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		id := v.Name + "/" + v.Version
+		g.add(&LineageNode{Type: LineageNodeModelVersion, ID: id, ModelVersion: v})
+		g.link(versionParent(runID, models, v), id)
+	}
+
+	return g, nil
+}
+
+// ModelVersionLineage returns the lineage graph of the run a model version was registered
+// from, see RunLineage, which includes the model version.
+func (c *Client) ModelVersionLineage(ctx context.Context, name, version string) (*LineageGraph, error) {
+	v, err := c.ModelVersions.Get(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &LineageGraph{}
+	if v.RunID != "" {
+		g, err = c.RunLineage(ctx, v.RunID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	id := v.Name + "/" + v.Version
+	if g.Node(id) == nil {
+		g.add(&LineageNode{Type: LineageNodeModelVersion, ID: id, ModelVersion: v})
+		if v.RunID != "" {
+			g.link(v.RunID, id)
+		}
+	}
+
+	return g, nil
+}
+
+// versionParent returns the ID of the logged model a model version was registered from, or
+// of the run if its source matches none of the logged models.
+func versionParent(runID string, models []*RunLoggedModel, v *ModelVersion) string {
+	source := strings.TrimSuffix(v.Source, "/")
+	for _, m := range models {
+		if p := strings.Trim(m.ArtifactPath, "/"); p != "" && strings.HasSuffix(source, "/"+p) {
+			return joinArtifactPath(runID, m.ArtifactPath)
+		}
+	}
+	return runID
+}
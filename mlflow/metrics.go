@@ -1,6 +1,12 @@
 package mlflow
 
-import "context"
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
 
 type MetricsService service
 
@@ -9,6 +15,13 @@ type MetricHistoryOptions struct {
 	MetricKey  string `json:"metric_key,omitempty"`
 	MaxResults int32  `json:"max_results,omitempty"`
 	PageToken  string `json:"page_token,omitempty"`
+
+	// MinStep and MaxStep, when set, restrict the returned metrics to the steps in
+	// [MinStep, MaxStep]. The server has no step filter, so full pages are still
+	// transferred and filtered client-side; pages may thus contain fewer than MaxResults
+	// metrics, or none at all, while NextPageToken is set.
+	MinStep *int64 `json:"-"`
+	MaxStep *int64 `json:"-"`
 }
 
 type MetricHistory struct {
@@ -17,12 +30,170 @@ type MetricHistory struct {
 }
 
 func (s *MetricsService) GetHistory(ctx context.Context, opts *MetricHistoryOptions) (*MetricHistory, error) {
+	if opts == nil {
+		opts = &MetricHistoryOptions{}
+	}
+
 	var res MetricHistory
 
-	_, err := s.client.Do(ctx, "GET", "metrics/get-history", nil, opts, &res)
+	params := url.Values{}
+	params.Set("run_id", opts.RunID)
+	params.Set("metric_key", opts.MetricKey)
+	if opts.MaxResults != 0 {
+		params.Set("max_results", strconv.FormatInt(int64(opts.MaxResults), 10))
+	}
+	if opts.PageToken != "" {
+		params.Set("page_token", opts.PageToken)
+	}
+
+	_, err := s.client.Do(ctx, "GET", "metrics/get-history", params, nil, &res)
 	if err != nil {
 		return nil, err
 	}
 
+	if opts.MinStep != nil || opts.MaxStep != nil {
+		res.Metrics = res.filterSteps(opts.MinStep, opts.MaxStep)
+	}
+
 	return &res, nil
 }
+
+// StepRange returns the metrics of the history with a step in [minStep, maxStep].
+func (h *MetricHistory) StepRange(minStep, maxStep int64) *MetricHistory {
+	return &MetricHistory{Metrics: h.filterSteps(&minStep, &maxStep)}
+}
+
+func (h *MetricHistory) filterSteps(minStep, maxStep *int64) []*Metric {
+	metrics := []*Metric{}
+	for _, m := range h.Metrics {
+		if (minStep == nil || m.Step >= *minStep) && (maxStep == nil || m.Step <= *maxStep) {
+			metrics = append(metrics, m)
+		}
+	}
+
+	return metrics
+}
+
+// ByDataset splits the history by the dataset the metrics were computed on, keyed by
+// name@digest. Metrics without dataset are keyed by the empty string. Metric datasets are
+// only returned by MLflow 3 servers.
+func (h *MetricHistory) ByDataset() map[string]*MetricHistory {
+	res := map[string]*MetricHistory{}
+	for _, m := range h.Metrics {
+		key := ""
+		if m.DatasetName != "" || m.DatasetDigest != "" {
+			key = m.DatasetName + "@" + m.DatasetDigest
+		}
+
+		if res[key] == nil {
+			res[key] = &MetricHistory{}
+		}
+		res[key].Metrics = append(res[key].Metrics, m)
+	}
+
+	return res
+}
+
+// getFullHistory returns the full history of a metric, following page tokens.
+func (s *MetricsService) getFullHistory(ctx context.Context, runID, key string) ([]*Metric, error) {
+	var metrics []*Metric
+
+	opts := &MetricHistoryOptions{RunID: runID, MetricKey: key}
+	for {
+		res, err := s.GetHistory(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, res.Metrics...)
+
+		if res.NextPageToken == "" {
+			return metrics, nil
+		}
+		opts.PageToken = res.NextPageToken
+	}
+}
+
+// defaultWatchInterval is the interval of Watch when the given one is not positive.
+const defaultWatchInterval = 5 * time.Second
+
+// Watch polls the history of a metric every interval, 5 seconds if not positive, and sends
+// the points logged after the last point it sent, ordered by step then timestamp, on the
+// returned channel, starting with the history logged so far. Points logged later at a lower
+// step, or at the same step with an earlier timestamp, are not sent. Failed polls are
+// retried at the next interval. The channel is closed when ctx is done.
+func (s *MetricsService) Watch(ctx context.Context, runID, key string, interval time.Duration) <-chan *Metric {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	ch := make(chan *Metric)
+
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		// last is the last point sent, nil until the first one is.
+		var last *Metric
+		for {
+			history, err := s.getFullHistory(ctx, runID, key)
+			if err == nil {
+				sort.SliceStable(history, func(i, j int) bool {
+					return metricBefore(history[i], history[j])
+				})
+				i := 0
+				for i < len(history) && last != nil && !metricBefore(last, history[i]) {
+					i++
+				}
+
+				for _, m := range history[i:] {
+					select {
+					case ch <- m:
+						last = m
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// metricBefore reports whether a was logged at a lower step than b, or at the same step
+// with an earlier timestamp.
+func metricBefore(a, b *Metric) bool {
+	if a.Step != b.Step {
+		return a.Step < b.Step
+	}
+	return a.Timestamp < b.Timestamp
+}
+
+// GetHistories returns the full histories of several metrics of a run, keyed by metric key.
+// The histories are fetched concurrently.
+func (s *MetricsService) GetHistories(ctx context.Context, runID string, keys []string) (map[string]*MetricHistory, error) {
+	histories := make([]*MetricHistory, len(keys))
+
+	err := forEach(ctx, len(keys), defaultConcurrency, func(ctx context.Context, i int) error {
+		metrics, err := s.getFullHistory(ctx, runID, keys[i])
+		histories[i] = &MetricHistory{Metrics: metrics}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*MetricHistory, len(keys))
+	for i, key := range keys {
+		res[key] = histories[i]
+	}
+
+	return res, nil
+}
@@ -0,0 +1,101 @@
+package mlflow
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// WriteCSV writes the metric history as CSV with step, timestamp and value columns.
+func (h *MetricHistory) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write([]string{"step", "timestamp", "value"})
+	if err != nil {
+		return err
+	}
+
+	for _, m := range h.Metrics {
+		err = cw.Write([]string{
+			strconv.FormatInt(m.Step, 10),
+			strconv.FormatInt(m.Timestamp, 10),
+			formatMetricValue(m.Value),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteMetricsCSV writes several metric histories as wide-format CSV: one row per step and
+// one column per metric key, in key order. When a metric has several values at a step the
+// most recent one is written; cells of metrics without a value at a step are left empty.
+func WriteMetricsCSV(w io.Writer, histories map[string]*MetricHistory) error {
+	keys := make([]string, 0, len(histories))
+	for key := range histories {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	type point struct {
+		value     float64
+		timestamp int64
+	}
+	rows := map[int64]map[string]point{}
+	for key, h := range histories {
+		if h == nil {
+			continue
+		}
+
+		for _, m := range h.Metrics {
+			row, ok := rows[m.Step]
+			if !ok {
+				row = map[string]point{}
+				rows[m.Step] = row
+			}
+			if p, ok := row[key]; !ok || m.Timestamp >= p.timestamp {
+				row[key] = point{value: m.Value, timestamp: m.Timestamp}
+			}
+		}
+	}
+
+	steps := make([]int64, 0, len(rows))
+	for step := range rows {
+		steps = append(steps, step)
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i] < steps[j] })
+
+	cw := csv.NewWriter(w)
+
+	err := cw.Write(append([]string{"step"}, keys...))
+	if err != nil {
+		return err
+	}
+
+	record := make([]string, len(keys)+1)
+	for _, step := range steps {
+		record[0] = strconv.FormatInt(step, 10)
+		for i, key := range keys {
+			record[i+1] = ""
+			if p, ok := rows[step][key]; ok {
+				record[i+1] = formatMetricValue(p.value)
+			}
+		}
+
+		err = cw.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatMetricValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,63 @@
+package mlflow
+
+import "time"
+
+// Series returns the steps, values and timestamps of the history as parallel slices.
+func (h *MetricHistory) Series() (steps []int64, values []float64, times []time.Time) {
+	steps = make([]int64, len(h.Metrics))
+	values = make([]float64, len(h.Metrics))
+	times = make([]time.Time, len(h.Metrics))
+
+	for i, m := range h.Metrics {
+		steps[i] = m.Step
+		values[i] = m.Value
+		times[i] = time.UnixMilli(m.Timestamp)
+	}
+
+	return steps, values, times
+}
+
+// Values returns the values of the history, as used by gonum's floats and stat packages.
+func (h *MetricHistory) Values() []float64 {
+	values := make([]float64, len(h.Metrics))
+	for i, m := range h.Metrics {
+		values[i] = m.Value
+	}
+
+	return values
+}
+
+// StepXYs returns the history as (step, value) points.
+func (h *MetricHistory) StepXYs() MetricXYs {
+	xys := make(MetricXYs, len(h.Metrics))
+	for i, m := range h.Metrics {
+		xys[i].X = float64(m.Step)
+		xys[i].Y = m.Value
+	}
+
+	return xys
+}
+
+// TimeXYs returns the history as (time, value) points, with times in seconds since the
+// Unix epoch.
+func (h *MetricHistory) TimeXYs() MetricXYs {
+	xys := make(MetricXYs, len(h.Metrics))
+	for i, m := range h.Metrics {
+		xys[i].X = float64(m.Timestamp) / 1000
+		xys[i].Y = m.Value
+	}
+
+	return xys
+}
+
+// MetricXYs is a slice of points which implements gonum's plotter.XYer interface,
+// so metric histories can be plotted directly.
+type MetricXYs []struct{ X, Y float64 }
+
+func (xys MetricXYs) Len() int {
+	return len(xys)
+}
+
+func (xys MetricXYs) XY(i int) (x, y float64) {
+	return xys[i].X, xys[i].Y
+}
@@ -0,0 +1,69 @@
+package mlflow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+func TestMetricsWatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client := newTestClient(t)
+
+	run, err := client.Runs.Create(ctx, "0", "watched", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := run.Info.RunID
+	logMetric := func(value float64, timestamp, step int64) {
+		t.Helper()
+		err := client.Runs.LogMetric(ctx, id, "loss", value, timestamp, step)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	receive := func(ch <-chan *mlflow.Metric, want ...float64) {
+		t.Helper()
+		for _, v := range want {
+			select {
+			case m := <-ch:
+				if m.Value != v {
+					t.Fatalf("got loss %v at step %d, want %v", m.Value, m.Step, v)
+				}
+			case <-ctx.Done():
+				t.Fatalf("no loss %v: %v", v, ctx.Err())
+			}
+		}
+	}
+
+	logMetric(0.9, 2000, 1)
+	logMetric(1, 1000, 0)
+
+	// A zero interval polls every few seconds.
+	watchCtx, stop := context.WithCancel(ctx)
+	receive(client.Metrics.Watch(watchCtx, id, "loss", 0), 1, 0.9)
+	stop()
+
+	ch := client.Metrics.Watch(ctx, id, "loss", 10*time.Millisecond)
+	receive(ch, 1, 0.9)
+	logMetric(0.8, 3000, 2)
+	logMetric(0.85, 1500, 1)
+	logMetric(0.7, 4000, 2)
+	receive(ch, 0.8, 0.7)
+	logMetric(0.6, 5000, 3)
+	receive(ch, 0.6)
+}
+
+func TestMetricsGetHistoryNilOptions(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.Metrics.GetHistory(context.Background(), nil)
+	var e *mlflow.Error
+	if !errors.As(err, &e) || e.ErrorCode != "INVALID_PARAMETER_VALUE" {
+		t.Errorf("got error %v, want INVALID_PARAMETER_VALUE", err)
+	}
+}
@@ -11,8 +11,22 @@ import (
 )
 
 type Client struct {
-	client  *http.Client
-	baseURL *url.URL
+	client       *http.Client
+	baseURL      *url.URL
+	artifactsURL *url.URL
+
+	username      string
+	password      string
+	tokenProvider TokenProvider
+
+	credentialsProvider CredentialsProvider
+
+	generateRunNames     bool
+	artifactRepositories map[string]ArtifactRepositoryFactory
+	artifactLimiter      *rateLimiter
+	artifactCache        *artifactCache
+	auditSink            AuditSink
+	permissionCache      *permissionCache
 
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
@@ -22,7 +36,9 @@ type Client struct {
 	Metrics          *MetricsService
 	ModelVersions    *ModelVersionService
 	RegisteredModels *RegisteredModelService
+	RegistryWebhooks *RegistryWebhooksService
 	Runs             *RunService
+	Traces           *TracesService
 	Users            *UserService
 }
 
@@ -30,7 +46,18 @@ type service struct {
 	client *Client
 }
 
-func NewClient(httpClient *http.Client, baseURL string) (*Client, error) {
+// ClientOption configures optional behavior of a Client.
+type ClientOption func(*Client)
+
+// WithGeneratedRunNames makes Runs.Create generate a random adjective-noun run name,
+// like the MLflow Python client does, when it is called with an empty name.
+func WithGeneratedRunNames() ClientOption {
+	return func(c *Client) {
+		c.generateRunNames = true
+	}
+}
+
+func NewClient(httpClient *http.Client, baseURL string, opts ...ClientOption) (*Client, error) {
 	parsedURL, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, err
@@ -39,6 +66,8 @@ func NewClient(httpClient *http.Client, baseURL string) (*Client, error) {
 	if !strings.HasSuffix(parsedURL.Path, "/") {
 		parsedURL.Path += "/"
 	}
+	artifactsURL := *parsedURL
+	artifactsURL.Path += "api/2.0/mlflow-artifacts/artifacts/"
 	parsedURL.Path += "api/2.0/mlflow/"
 
 	if httpClient == nil {
@@ -47,20 +76,31 @@ func NewClient(httpClient *http.Client, baseURL string) (*Client, error) {
 	httpClient2 := *httpClient
 
 	c := &Client{
-		client:  &httpClient2,
-		baseURL: parsedURL,
+		client:       &httpClient2,
+		baseURL:      parsedURL,
+		artifactsURL: &artifactsURL,
 	}
 
+	c.initServices()
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+func (c *Client) initServices() {
 	c.common.client = c
 	c.Artifacts = (*ArtifactsService)(&c.common)
 	c.Experiments = (*ExperimentService)(&c.common)
 	c.Metrics = (*MetricsService)(&c.common)
 	c.ModelVersions = (*ModelVersionService)(&c.common)
 	c.RegisteredModels = (*RegisteredModelService)(&c.common)
+	c.RegistryWebhooks = (*RegistryWebhooksService)(&c.common)
 	c.Runs = (*RunService)(&c.common)
+	c.Traces = (*TracesService)(&c.common)
 	c.Users = (*UserService)(&c.common)
-
-	return c, nil
 }
 
 func (c *Client) Do(ctx context.Context, method string, path string, params url.Values, body interface{}, response interface{}) (*http.Response, error) {
@@ -69,6 +109,38 @@ func (c *Client) Do(ctx context.Context, method string, path string, params url.
 		return nil, err
 	}
 
+	var res *http.Response
+	err = c.audit(ctx, method, path, body, func() error {
+		res, err = c.do(ctx, method, u, params, body, response)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) do(ctx context.Context, method string, u *url.URL, params url.Values, body interface{}, response interface{}) (*http.Response, error) {
+	res, err := c.stream(ctx, method, u, params, nil, body)
+	if err != nil {
+		return res, err
+	}
+	defer res.Body.Close()
+
+	switch v := response.(type) {
+	case nil:
+	case io.Writer:
+		_, err = io.Copy(v, res.Body)
+	default:
+		err = json.NewDecoder(res.Body).Decode(v)
+		if err == io.EOF {
+			err = nil // ignore EOF errors caused by empty response body
+		}
+	}
+
+	return res, err
+}
+
+// stream sends a request and returns the response with its body still open, the caller
+// must close it.
+func (c *Client) stream(ctx context.Context, method string, u *url.URL, params url.Values, header http.Header, body interface{}) (*http.Response, error) {
 	if params != nil {
 		u.RawQuery = params.Encode()
 	}
@@ -84,15 +156,27 @@ func (c *Client) Do(ctx context.Context, method string, path string, params url.
 	}
 	req := r.WithContext(ctx)
 
-	req.Header.Set("content-type", "application/json")
+	if _, ok := body.(io.Reader); ok {
+		req.Header.Set("content-type", "application/octet-stream")
+	} else {
+		req.Header.Set("content-type", "application/json")
+	}
+	err = c.authenticate(req)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
 
 	res, err := c.client.Do(req)
 	if err != nil {
 		return res, err
 	}
-	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
+		defer res.Body.Close()
+
 		e := Error{StatusCode: res.StatusCode}
 		err = json.NewDecoder(res.Body).Decode(&e)
 		if err != nil {
@@ -103,23 +187,15 @@ func (c *Client) Do(ctx context.Context, method string, path string, params url.
 		return res, &e
 	}
 
-	switch v := response.(type) {
-	case nil:
-	case io.Writer:
-		_, err = io.Copy(v, res.Body)
-	default:
-		err = json.NewDecoder(res.Body).Decode(v)
-		if err == io.EOF {
-			err = nil // ignore EOF errors caused by empty response body
-		}
-	}
-
-	return res, err
+	return res, nil
 }
 
 func (c *Client) encodeBody(body interface{}) (io.Reader, error) {
-	if body == nil {
+	switch v := body.(type) {
+	case nil:
 		return nil, nil
+	case io.Reader:
+		return v, nil
 	}
 
 	b, err := json.Marshal(body)
@@ -129,3 +205,11 @@ func (c *Client) encodeBody(body interface{}) (io.Reader, error) {
 
 	return bytes.NewBuffer(b), nil
 }
+
+// artifactURL returns the URL of the given path on the mlflow-artifacts proxy.
+func (c *Client) artifactURL(path string) *url.URL {
+	u := *c.artifactsURL
+	u.Path += strings.TrimPrefix(path, "/")
+	u.RawPath = ""
+	return &u
+}
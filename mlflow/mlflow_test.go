@@ -0,0 +1,19 @@
+package mlflow_test
+
+import (
+	"fmt"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+func errorf(format string, args ...any) error {
+	return fmt.Errorf(format, args...)
+}
+
+// expectNotFound returns an error unless err is a RESOURCE_DOES_NOT_EXIST error.
+func expectNotFound(err error) error {
+	if !mlflow.IsResourceDoesNotExist(err) {
+		return fmt.Errorf("got error %v, want RESOURCE_DOES_NOT_EXIST", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package mlflow
+
+import (
+	"context"
+)
+
+// DownloadModel downloads the artifacts of the model at uri, such as models:/name@alias,
+// models:/name/version, models:/name/stage or runs:/run_id/path, into localDir. The URI is
+// resolved to the location of the artifacts with ArtifactsService.ResolveURI.
+func (c *Client) DownloadModel(ctx context.Context, uri, localDir string) error {
+	source, err := c.Artifacts.ResolveURI(ctx, uri)
+	if err != nil {
+		return err
+	}
+
+	repo, err := c.Artifacts.Repository(ctx, source)
+	if err != nil {
+		return err
+	}
+
+	files, err := listRepositoryFiles(ctx, repo, "")
+	if err != nil {
+		return err
+	}
+
+	return c.Artifacts.downloadFiles(ctx, source, "", files, localDir, nil)
+}
+
+// listRepositoryFiles lists the files below path in repo recursively.
+func listRepositoryFiles(ctx context.Context, repo ArtifactRepository, path string) ([]*FileInfo, error) {
+	entries, err := repo.List(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*FileInfo
+	for _, f := range entries {
+		if !f.IsDir {
+			files = append(files, f)
+			continue
+		}
+
+		below, err := listRepositoryFiles(ctx, repo, f.Path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, below...)
+	}
+
+	return files, nil
+}
@@ -2,6 +2,10 @@ package mlflow
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
 )
 
 type ModelVersionService service
@@ -14,6 +18,15 @@ const (
 	ModelVersionStatusReady   ModelVersionStatus = "READY"
 )
 
+type ModelVersionStage string
+
+const (
+	ModelVersionStageNone       ModelVersionStage = "None"
+	ModelVersionStageStaging    ModelVersionStage = "Staging"
+	ModelVersionStageProduction ModelVersionStage = "Production"
+	ModelVersionStageArchived   ModelVersionStage = "Archived"
+)
+
 type ModelVersion struct {
 	Name                 string             `json:"name,omitempty"`
 	Version              string             `json:"version,omitempty"`
@@ -52,3 +65,217 @@ func (s *ModelVersionService) SetTag(ctx context.Context, name, version, key, va
 	_, err := s.client.Do(ctx, "POST", "model-versions/set-tag", nil, &opts, nil)
 	return err
 }
+
+// SetTags sets multiple tags on a model version, issuing the requests concurrently.
+func (s *ModelVersionService) SetTags(ctx context.Context, name, version string, tags map[string]string) error {
+	return setTags(ctx, tags, func(ctx context.Context, key, value string) error {
+		return s.SetTag(ctx, name, version, key, value)
+	})
+}
+
+func (s *ModelVersionService) DeleteTag(ctx context.Context, name, version, key string) error {
+	opts := struct {
+		Name    string `json:"name,omitempty"`
+		Version string `json:"version,omitempty"`
+		Key     string `json:"key,omitempty"`
+	}{
+		Name:    name,
+		Version: version,
+		Key:     key,
+	}
+
+	_, err := s.client.Do(ctx, "DELETE", "model-versions/delete-tag", nil, &opts, nil)
+	return err
+}
+
+func (s *ModelVersionService) Get(ctx context.Context, name, version string) (*ModelVersion, error) {
+	var res struct {
+		ModelVersion *ModelVersion `json:"model_version,omitempty"`
+	}
+
+	params := url.Values{}
+	params.Set("name", name)
+	params.Set("version", version)
+
+	_, err := s.client.Do(ctx, "GET", "model-versions/get", params, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.ModelVersion, nil
+}
+
+func (s *ModelVersionService) Update(ctx context.Context, name, version, description string) (*ModelVersion, error) {
+	opts := struct {
+		Name        string `json:"name,omitempty"`
+		Version     string `json:"version,omitempty"`
+		Description string `json:"description"`
+	}{
+		Name:        name,
+		Version:     version,
+		Description: description,
+	}
+
+	var res struct {
+		ModelVersion *ModelVersion `json:"model_version,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "PATCH", "model-versions/update", nil, &opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.ModelVersion, nil
+}
+
+func (s *ModelVersionService) Delete(ctx context.Context, name, version string) error {
+	opts := struct {
+		Name    string `json:"name,omitempty"`
+		Version string `json:"version,omitempty"`
+	}{
+		Name:    name,
+		Version: version,
+	}
+
+	_, err := s.client.Do(ctx, "DELETE", "model-versions/delete", nil, &opts, nil)
+	return err
+}
+
+// TransitionStage moves a model version to stage. If archiveExisting is set, the other versions
+// of the model in stage are moved to the Archived stage.
+func (s *ModelVersionService) TransitionStage(ctx context.Context, name, version string, stage ModelVersionStage, archiveExisting bool) (*ModelVersion, error) {
+	opts := struct {
+		Name                    string            `json:"name,omitempty"`
+		Version                 string            `json:"version,omitempty"`
+		Stage                   ModelVersionStage `json:"stage,omitempty"`
+		ArchiveExistingVersions bool              `json:"archive_existing_versions"`
+	}{
+		Name:                    name,
+		Version:                 version,
+		Stage:                   stage,
+		ArchiveExistingVersions: archiveExisting,
+	}
+
+	var res struct {
+		ModelVersion *ModelVersion `json:"model_version,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "POST", "model-versions/transition-stage", nil, &opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.ModelVersion, nil
+}
+
+// GetDownloadURI returns the URI of the artifacts of a model version in the artifact store.
+func (s *ModelVersionService) GetDownloadURI(ctx context.Context, name, version string) (string, error) {
+	var res struct {
+		ArtifactURI string `json:"artifact_uri,omitempty"`
+	}
+
+	params := url.Values{}
+	params.Set("name", name)
+	params.Set("version", version)
+
+	_, err := s.client.Do(ctx, "GET", "model-versions/get-download-uri", params, nil, &res)
+	if err != nil {
+		return "", err
+	}
+
+	return res.ArtifactURI, nil
+}
+
+// defaultPollInterval is the interval of WaitUntilReady when the given one is not positive.
+const defaultPollInterval = time.Second
+
+// WaitUntilReady polls a model version every pollInterval, a second if not positive, until
+// its registration completes, and returns it. It returns an error if the registration failed.
+func (s *ModelVersionService) WaitUntilReady(ctx context.Context, name, version string, pollInterval time.Duration) (*ModelVersion, error) {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		v, err := s.Get(ctx, name, version)
+		if err != nil {
+			return nil, err
+		}
+
+		switch v.Status {
+		case ModelVersionStatusReady:
+			return v, nil
+		case ModelVersionStatusFailed:
+			return v, fmt.Errorf("mlflow: registration of version %s of model %q failed: %s", version, name, v.StatusMessage)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+type ModelVersionsSearchOptions struct {
+	Filter     string
+	MaxResults int64
+	OrderBy    []string
+	PageToken  string
+}
+
+type ModelVersionsSearchResults struct {
+	ModelVersions []*ModelVersion `json:"model_versions,omitempty"`
+	NextPageToken string          `json:"next_page_token,omitempty"`
+}
+
+func (s *ModelVersionService) Search(ctx context.Context, opts *ModelVersionsSearchOptions) (*ModelVersionsSearchResults, error) {
+	var res ModelVersionsSearchResults
+
+	params := url.Values{}
+	if opts != nil {
+		if opts.Filter != "" {
+			params.Set("filter", opts.Filter)
+		}
+		if opts.MaxResults > 0 {
+			params.Set("max_results", strconv.FormatInt(opts.MaxResults, 10))
+		}
+		for _, orderBy := range opts.OrderBy {
+			params.Add("order_by", orderBy)
+		}
+		if opts.PageToken != "" {
+			params.Set("page_token", opts.PageToken)
+		}
+	}
+
+	_, err := s.client.Do(ctx, "GET", "model-versions/search", params, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *ModelVersionService) Iterate(ctx context.Context, opts *ModelVersionsSearchOptions) *Iterator[*ModelVersion] {
+	if opts == nil {
+		opts = &ModelVersionsSearchOptions{}
+	}
+	o := *opts
+
+	return newIterator(ctx, o.PageToken, func(ctx context.Context, pageToken string) ([]*ModelVersion, string, error) {
+		o.PageToken = pageToken
+
+		res, err := s.Search(ctx, &o)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return res.ModelVersions, res.NextPageToken, nil
+	})
+}
+
+func (s *ModelVersionService) SearchAll(ctx context.Context, opts *ModelVersionsSearchOptions) ([]*ModelVersion, error) {
+	return s.Iterate(ctx, opts).All()
+}
@@ -0,0 +1,72 @@
+package mlflow
+
+import (
+	"context"
+	"net/url"
+)
+
+// Model version comments are a Databricks model registry feature, the open source tracking
+// server does not implement these endpoints.
+
+type ModelVersionComment struct {
+	ID                   string `json:"id,omitempty"`
+	UserID               string `json:"user_id,omitempty"`
+	CreationTimestamp    int64  `json:"creation_timestamp,omitempty"`
+	LastUpdatedTimestamp int64  `json:"last_updated_timestamp,omitempty"`
+	Comment              string `json:"comment,omitempty"`
+	// AvailableActions lists the actions the caller may take on the comment, such as
+	// "EDIT_COMMENT".
+	AvailableActions []string `json:"available_actions,omitempty"`
+}
+
+func (s *ModelVersionService) CreateComment(ctx context.Context, name, version, comment string) (*ModelVersionComment, error) {
+	opts := struct {
+		Name    string `json:"name,omitempty"`
+		Version string `json:"version,omitempty"`
+		Comment string `json:"comment,omitempty"`
+	}{
+		Name:    name,
+		Version: version,
+		Comment: comment,
+	}
+
+	var res struct {
+		Comment *ModelVersionComment `json:"comment,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "POST", "comments/create", nil, &opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Comment, nil
+}
+
+func (s *ModelVersionService) UpdateComment(ctx context.Context, id, comment string) (*ModelVersionComment, error) {
+	opts := struct {
+		ID      string `json:"id,omitempty"`
+		Comment string `json:"comment,omitempty"`
+	}{
+		ID:      id,
+		Comment: comment,
+	}
+
+	var res struct {
+		Comment *ModelVersionComment `json:"comment,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "PATCH", "comments/update", nil, &opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Comment, nil
+}
+
+func (s *ModelVersionService) DeleteComment(ctx context.Context, id string) error {
+	params := url.Values{}
+	params.Set("id", id)
+
+	_, err := s.client.Do(ctx, "DELETE", "comments/delete", params, nil, nil)
+	return err
+}
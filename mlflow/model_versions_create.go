@@ -0,0 +1,132 @@
+package mlflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type ModelVersionCreateOptions struct {
+	Name string `json:"name,omitempty"`
+	// Source is the URI of the model artifacts, such as runs:/<run_id>/model or the
+	// artifact location of the model.
+	Source      string             `json:"source,omitempty"`
+	RunID       string             `json:"run_id,omitempty"`
+	Tags        []*ModelVersionTag `json:"tags,omitempty"`
+	RunLink     string             `json:"run_link,omitempty"`
+	Description string             `json:"description,omitempty"`
+}
+
+func (s *ModelVersionService) Create(ctx context.Context, opts *ModelVersionCreateOptions) (*ModelVersion, error) {
+	var res struct {
+		ModelVersion *ModelVersion `json:"model_version,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "POST", "model-versions/create", nil, opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.ModelVersion, nil
+}
+
+// Copy creates a version of the registered model dstName, which is created if needed, from the
+// model version at srcModelURI, such as models:/name/version or models:/name@alias. The new
+// version has the description, tags and run of the source version. Its source is srcModelURI
+// resolved to a version, which makes the server copy the artifacts, or the artifact location
+// of the source version on servers not accepting models:/ sources.
+func (s *ModelVersionService) Copy(ctx context.Context, srcModelURI, dstName string) (*ModelVersion, error) {
+	u, err := ParseArtifactURI(srcModelURI)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "models" || u.Path != "" {
+		return nil, fmt.Errorf("mlflow: %q is not a model version URI", srcModelURI)
+	}
+
+	version, err := s.resolveModelURI(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	src, err := s.Get(ctx, u.Location, version)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.client.RegisteredModels.Create(ctx, &RegisteredModelCreateOptions{Name: dstName})
+	if err != nil && !IsResourceAlreadyExists(err) {
+		return nil, err
+	}
+
+	opts := &ModelVersionCreateOptions{
+		Name:        dstName,
+		Source:      (&ArtifactURI{Scheme: "models", Location: src.Name, Version: src.Version}).String(),
+		RunID:       src.RunID,
+		Tags:        src.Tags,
+		RunLink:     src.RunLink,
+		Description: src.Description,
+	}
+
+	dst, err := s.Create(ctx, opts)
+	var e *Error
+	if errors.As(err, &e) && e.ErrorCode == ErrorInvalidParameterValue {
+		opts.Source, err = s.GetDownloadURI(ctx, src.Name, src.Version)
+		if err != nil {
+			return nil, err
+		}
+		dst, err = s.Create(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+// promotePollInterval is the interval at which Promote polls the status of the new version.
+const promotePollInterval = time.Second
+
+// Promote registers the model at source, such as runs:/<run_id>/model, as a new version of the
+// registered model name, which is created if needed, waits for the registration to complete
+// and points alias, if not empty, to the new version.
+func (s *ModelVersionService) Promote(ctx context.Context, source, name, alias string) (*ModelVersion, error) {
+	u, err := ParseArtifactURI(source)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &ModelVersionCreateOptions{Name: name}
+	if u.Scheme == "runs" {
+		opts.RunID = u.Location
+	}
+	opts.Source, err = s.client.Artifacts.ResolveURI(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.client.RegisteredModels.Create(ctx, &RegisteredModelCreateOptions{Name: name})
+	if err != nil && !IsResourceAlreadyExists(err) {
+		return nil, err
+	}
+
+	v, err := s.Create(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err = s.WaitUntilReady(ctx, name, v.Version, promotePollInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	if alias != "" {
+		err = s.client.RegisteredModels.SetAlias(ctx, name, alias, v.Version)
+		if err != nil {
+			return nil, err
+		}
+		v.Aliases = append(v.Aliases, alias)
+	}
+
+	return v, nil
+}
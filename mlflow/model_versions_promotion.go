@@ -0,0 +1,172 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+)
+
+// PreviousVersionsAction is what PromoteVersion does to the previously promoted versions.
+type PreviousVersionsAction int
+
+const (
+	// PreviousVersionsKeep leaves the previous versions as they are, except for the alias
+	// moved to the promoted version.
+	PreviousVersionsKeep PreviousVersionsAction = iota
+	// PreviousVersionsArchive moves the previous versions to the Archived stage.
+	PreviousVersionsArchive
+	// PreviousVersionsUnalias deletes all the aliases of the previous versions.
+	PreviousVersionsUnalias
+)
+
+// PromotionPolicy configures PromoteVersion.
+type PromotionPolicy struct {
+	// Stage, if set, is the stage the version is moved to.
+	Stage ModelVersionStage
+	// Alias, if set, is pointed to the version.
+	Alias string
+	// Previous is applied to the versions in Stage and the version Alias pointed to.
+	Previous PreviousVersionsAction
+	// PreviousAlias, if set, is pointed to the version Alias pointed to, to keep track of
+	// the version to roll back to.
+	PreviousAlias string
+	// DryRun only reports the changes, without applying them.
+	DryRun bool
+}
+
+type PromotionChangeType string
+
+const (
+	PromotionChangeTransition  PromotionChangeType = "transition"
+	PromotionChangeSetAlias    PromotionChangeType = "set_alias"
+	PromotionChangeDeleteAlias PromotionChangeType = "delete_alias"
+)
+
+// PromotionChange is a change made, or to be made for dry runs, by PromoteVersion.
+type PromotionChange struct {
+	Type    PromotionChangeType
+	Version string
+	// Stage is the stage of transitions.
+	Stage ModelVersionStage
+	// Alias is the alias set or deleted.
+	Alias string
+}
+
+func (c *PromotionChange) String() string {
+	switch c.Type {
+	case PromotionChangeTransition:
+		return fmt.Sprintf("transition version %s to %s", c.Version, c.Stage)
+	case PromotionChangeSetAlias:
+		return fmt.Sprintf("point alias %q to version %s", c.Alias, c.Version)
+	default:
+		return fmt.Sprintf("delete alias %q of version %s", c.Alias, c.Version)
+	}
+}
+
+// PromoteVersion promotes a version of a registered model to the stage and alias of the
+// policy, and applies the policy to the previously promoted versions. It returns the
+// changes, in the order they are applied; with DryRun, nothing is changed.
+func (s *ModelVersionService) PromoteVersion(ctx context.Context, name, version string, policy *PromotionPolicy) ([]*PromotionChange, error) {
+	if policy == nil {
+		policy = &PromotionPolicy{}
+	}
+
+	target, err := s.Get(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := map[string]*ModelVersion{}
+	var order []string
+	addPrevious := func(v *ModelVersion) {
+		if v == nil || v.Version == version {
+			return
+		}
+		if _, ok := previous[v.Version]; !ok {
+			order = append(order, v.Version)
+		}
+		previous[v.Version] = v
+	}
+
+	if policy.Stage != "" {
+		versions, err := s.SearchAll(ctx, &ModelVersionsSearchOptions{Filter: "name = " + quoteFilterString(name)})
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range versions {
+			if ModelVersionStage(v.CurrentStage) == policy.Stage {
+				addPrevious(v)
+			}
+		}
+	}
+
+	var aliased *ModelVersion
+	if policy.Alias != "" {
+		aliased, err = s.client.RegisteredModels.GetModelVersionByAlias(ctx, name, policy.Alias)
+		if err != nil && !IsResourceDoesNotExist(err) {
+			return nil, err
+		}
+		if aliased != nil && aliased.Version != version {
+			addPrevious(aliased)
+		} else {
+			aliased = nil
+		}
+	}
+
+	var changes []*PromotionChange
+	if policy.Stage != "" && ModelVersionStage(target.CurrentStage) != policy.Stage {
+		changes = append(changes, &PromotionChange{Type: PromotionChangeTransition, Version: version, Stage: policy.Stage})
+	}
+	if policy.Alias != "" && !hasAlias(target, policy.Alias) {
+		changes = append(changes, &PromotionChange{Type: PromotionChangeSetAlias, Version: version, Alias: policy.Alias})
+	}
+	if policy.PreviousAlias != "" && aliased != nil {
+		changes = append(changes, &PromotionChange{Type: PromotionChangeSetAlias, Version: aliased.Version, Alias: policy.PreviousAlias})
+	}
+
+	for _, v := range order {
+		prev := previous[v]
+		switch policy.Previous {
+		case PreviousVersionsArchive:
+			if ModelVersionStage(prev.CurrentStage) != ModelVersionStageArchived {
+				changes = append(changes, &PromotionChange{Type: PromotionChangeTransition, Version: v, Stage: ModelVersionStageArchived})
+			}
+		case PreviousVersionsUnalias:
+			for _, alias := range prev.Aliases {
+				// The alias moved to the promoted version, and the rollback alias.
+				if alias == policy.Alias || alias == policy.PreviousAlias {
+					continue
+				}
+				changes = append(changes, &PromotionChange{Type: PromotionChangeDeleteAlias, Version: v, Alias: alias})
+			}
+		}
+	}
+
+	if policy.DryRun {
+		return changes, nil
+	}
+
+	for i, c := range changes {
+		switch c.Type {
+		case PromotionChangeTransition:
+			_, err = s.TransitionStage(ctx, name, c.Version, c.Stage, false)
+		case PromotionChangeSetAlias:
+			err = s.client.RegisteredModels.SetAlias(ctx, name, c.Alias, c.Version)
+		case PromotionChangeDeleteAlias:
+			err = s.client.RegisteredModels.DeleteAlias(ctx, name, c.Alias)
+		}
+		if err != nil {
+			return changes[:i], err
+		}
+	}
+
+	return changes, nil
+}
+
+func hasAlias(v *ModelVersion, alias string) bool {
+	for _, a := range v.Aliases {
+		if a == alias {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package mlflow_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+func TestModelVersionsWaitUntilReadyDefaultInterval(t *testing.T) {
+	client := newTestClient(t)
+	createVersions(t, client, "m", 1)
+
+	v, err := client.ModelVersions.WaitUntilReady(context.Background(), "m", "1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Status != mlflow.ModelVersionStatusReady {
+		t.Errorf("got status %s, want READY", v.Status)
+	}
+}
@@ -0,0 +1,161 @@
+package mlflow
+
+import (
+	"context"
+	"net/url"
+)
+
+// Stage transition requests are a Databricks model registry feature, the open source tracking
+// server does not implement these endpoints.
+
+type TransitionRequest struct {
+	CreationTimestamp int64             `json:"creation_timestamp,omitempty"`
+	ToStage           ModelVersionStage `json:"to_stage,omitempty"`
+	UserID            string            `json:"user_id,omitempty"`
+	Comment           string            `json:"comment,omitempty"`
+	// AvailableActions lists the actions the caller may take on the request, such as
+	// "APPROVE_TRANSITION_REQUEST".
+	AvailableActions []string `json:"available_actions,omitempty"`
+}
+
+type ActivityType string
+
+const (
+	ActivityTypeAppliedTransition   ActivityType = "APPLIED_TRANSITION"
+	ActivityTypeRequestedTransition ActivityType = "REQUESTED_TRANSITION"
+	ActivityTypeSystemTransition    ActivityType = "SYSTEM_TRANSITION"
+	ActivityTypeApprovedRequest     ActivityType = "APPROVED_REQUEST"
+	ActivityTypeRejectedRequest     ActivityType = "REJECTED_REQUEST"
+	ActivityTypeCancelledRequest    ActivityType = "CANCELLED_REQUEST"
+	ActivityTypeNewComment          ActivityType = "NEW_COMMENT"
+)
+
+// Activity is an event in the history of a model version, such as a transition request or
+// its approval.
+type Activity struct {
+	ID                   string            `json:"id,omitempty"`
+	CreationTimestamp    int64             `json:"creation_timestamp,omitempty"`
+	LastUpdatedTimestamp int64             `json:"last_updated_timestamp,omitempty"`
+	UserID               string            `json:"user_id,omitempty"`
+	ActivityType         ActivityType      `json:"activity_type,omitempty"`
+	FromStage            ModelVersionStage `json:"from_stage,omitempty"`
+	ToStage              ModelVersionStage `json:"to_stage,omitempty"`
+	Comment              string            `json:"comment,omitempty"`
+	SystemComment        string            `json:"system_comment,omitempty"`
+}
+
+// RequestTransition requests the transition of a model version to stage, to be approved by a
+// user with the permission to manage the model.
+func (s *ModelVersionService) RequestTransition(ctx context.Context, name, version string, stage ModelVersionStage, comment string) (*TransitionRequest, error) {
+	opts := struct {
+		Name    string            `json:"name,omitempty"`
+		Version string            `json:"version,omitempty"`
+		Stage   ModelVersionStage `json:"stage,omitempty"`
+		Comment string            `json:"comment,omitempty"`
+	}{
+		Name:    name,
+		Version: version,
+		Stage:   stage,
+		Comment: comment,
+	}
+
+	var res struct {
+		Request *TransitionRequest `json:"request,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "POST", "transition-requests/create", nil, &opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Request, nil
+}
+
+// ListTransitionRequests lists the open transition requests of a model version.
+func (s *ModelVersionService) ListTransitionRequests(ctx context.Context, name, version string) ([]*Activity, error) {
+	var res struct {
+		Requests []*Activity `json:"requests,omitempty"`
+	}
+
+	params := url.Values{}
+	params.Set("name", name)
+	params.Set("version", version)
+
+	_, err := s.client.Do(ctx, "GET", "transition-requests/list", params, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Requests, nil
+}
+
+// ApproveTransitionRequest approves the request to transition a model version to stage, and
+// applies the transition. If archiveExisting is set, the other versions of the model in
+// stage are moved to the Archived stage.
+func (s *ModelVersionService) ApproveTransitionRequest(ctx context.Context, name, version string, stage ModelVersionStage, archiveExisting bool, comment string) (*Activity, error) {
+	opts := struct {
+		Name                    string            `json:"name,omitempty"`
+		Version                 string            `json:"version,omitempty"`
+		Stage                   ModelVersionStage `json:"stage,omitempty"`
+		ArchiveExistingVersions bool              `json:"archive_existing_versions"`
+		Comment                 string            `json:"comment,omitempty"`
+	}{
+		Name:                    name,
+		Version:                 version,
+		Stage:                   stage,
+		ArchiveExistingVersions: archiveExisting,
+		Comment:                 comment,
+	}
+
+	return s.transitionRequestActivity(ctx, "transition-requests/approve", &opts)
+}
+
+func (s *ModelVersionService) RejectTransitionRequest(ctx context.Context, name, version string, stage ModelVersionStage, comment string) (*Activity, error) {
+	opts := struct {
+		Name    string            `json:"name,omitempty"`
+		Version string            `json:"version,omitempty"`
+		Stage   ModelVersionStage `json:"stage,omitempty"`
+		Comment string            `json:"comment,omitempty"`
+	}{
+		Name:    name,
+		Version: version,
+		Stage:   stage,
+		Comment: comment,
+	}
+
+	return s.transitionRequestActivity(ctx, "transition-requests/reject", &opts)
+}
+
+// CancelTransitionRequest cancels the request of creator, a user name, to transition a model
+// version to stage.
+func (s *ModelVersionService) CancelTransitionRequest(ctx context.Context, name, version string, stage ModelVersionStage, creator, comment string) error {
+	opts := struct {
+		Name    string            `json:"name,omitempty"`
+		Version string            `json:"version,omitempty"`
+		Stage   ModelVersionStage `json:"stage,omitempty"`
+		Creator string            `json:"creator,omitempty"`
+		Comment string            `json:"comment,omitempty"`
+	}{
+		Name:    name,
+		Version: version,
+		Stage:   stage,
+		Creator: creator,
+		Comment: comment,
+	}
+
+	_, err := s.client.Do(ctx, "DELETE", "transition-requests/delete", nil, &opts, nil)
+	return err
+}
+
+func (s *ModelVersionService) transitionRequestActivity(ctx context.Context, path string, opts interface{}) (*Activity, error) {
+	var res struct {
+		Activity *Activity `json:"activity,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "POST", path, nil, opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Activity, nil
+}
@@ -0,0 +1,95 @@
+package mlflow
+
+import (
+	"sync"
+	"time"
+)
+
+// WithPermissionCache caches the results of the GetPermission calls of experiments and
+// registered models for ttl, including the errors reporting that users have no permission,
+// for authorization proxies checking the same permissions on every request. The permissions
+// changed through the client are evicted; changes made by other clients are only seen once
+// the cached results expire.
+func WithPermissionCache(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.permissionCache = &permissionCache{ttl: ttl, entries: map[permissionCacheKey]*permissionCacheEntry{}}
+	}
+}
+
+// permissionCacheMaxEntries is the number of entries above which expired entries are
+// evicted.
+const permissionCacheMaxEntries = 10000
+
+type permissionCacheKey struct {
+	kind     string
+	resource string
+	username string
+}
+
+type permissionCacheEntry struct {
+	value   any
+	err     error
+	expires time.Time
+}
+
+type permissionCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[permissionCacheKey]*permissionCacheEntry
+}
+
+// cachedPermission returns the cached result of fetch for key, calling it on misses. A nil
+// cache calls fetch. The cache holds copies of the permissions, and returns copies, which
+// callers may modify.
+func cachedPermission[T any](c *permissionCache, key permissionCacheKey, fetch func() (*T, error)) (*T, error) {
+	if c == nil {
+		return fetch()
+	}
+
+	c.mu.Lock()
+	e := c.entries[key]
+	c.mu.Unlock()
+	if e != nil && time.Now().Before(e.expires) {
+		v, _ := e.value.(*T)
+		return clonePermission(v), e.err
+	}
+
+	v, err := fetch()
+	if err != nil && !IsResourceDoesNotExist(err) {
+		return v, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= permissionCacheMaxEntries {
+		now := time.Now()
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	c.entries[key] = &permissionCacheEntry{value: clonePermission(v), err: err, expires: time.Now().Add(c.ttl)}
+
+	return v, err
+}
+
+// clonePermission returns a copy of a permission, nil if it is nil.
+func clonePermission[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+func (c *permissionCache) evict(key permissionCacheKey) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
@@ -0,0 +1,39 @@
+package mlflow_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+func TestPermissionCacheReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"experiment_permission": {"experiment_id": "1", "user_id": 2, "permission": "READ"}}`))
+	}))
+	defer srv.Close()
+	client, err := mlflow.NewClient(srv.Client(), srv.URL, mlflow.WithPermissionCache(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		p, err := client.Experiments.GetPermission(ctx, "1", "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Permission != mlflow.PermissionRead {
+			t.Fatalf("got permission %s, want READ", p.Permission)
+		}
+		p.Permission = mlflow.PermissionManage
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want 1", requests)
+	}
+}
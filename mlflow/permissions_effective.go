@@ -0,0 +1,87 @@
+package mlflow
+
+import "context"
+
+// CanRead reports whether the permission allows reading the resource.
+func (p Permission) CanRead() bool {
+	return p == PermissionRead || p == PermissionEdit || p == PermissionManage
+}
+
+// CanUpdate reports whether the permission allows updating the resource.
+func (p Permission) CanUpdate() bool {
+	return p == PermissionEdit || p == PermissionManage
+}
+
+// CanManage reports whether the permission allows deleting the resource and managing its
+// permissions.
+func (p Permission) CanManage() bool {
+	return p == PermissionManage
+}
+
+// EffectivePermission returns the permission the server enforces for a user with the explicit
+// permission on a resource, empty if the user has none: admins may manage all resources, and
+// users without explicit permission get defaultPermission, the default_permission of the
+// server configuration, READ by default.
+func EffectivePermission(user *User, explicit, defaultPermission Permission) Permission {
+	switch {
+	case user != nil && user.IsAdmin:
+		return PermissionManage
+	case explicit != "":
+		return explicit
+	case defaultPermission != "":
+		return defaultPermission
+	default:
+		return PermissionRead
+	}
+}
+
+// Effective returns the effective permission of the access, see EffectivePermission.
+func (a *ResourceAccess) Effective(defaultPermission Permission) Permission {
+	return EffectivePermission(nil, a.Permission, defaultPermission)
+}
+
+// EffectivePermission returns the effective permission of a user on an experiment, see
+// EffectivePermission.
+func (s *ExperimentService) EffectivePermission(ctx context.Context, id, username string, defaultPermission Permission) (Permission, error) {
+	user, err := s.client.Users.Get(ctx, username)
+	if err != nil {
+		return "", err
+	}
+	if user.IsAdmin {
+		return PermissionManage, nil
+	}
+
+	var explicit Permission
+	permission, err := s.GetPermission(ctx, id, username)
+	if err != nil && !IsResourceDoesNotExist(err) {
+		return "", err
+	}
+	if permission != nil {
+		explicit = permission.Permission
+	}
+
+	return EffectivePermission(user, explicit, defaultPermission), nil
+}
+
+// EffectivePermission returns the effective permission of a user on a registered model, see
+// EffectivePermission.
+func (s *RegisteredModelService) EffectivePermission(ctx context.Context, name, username string, defaultPermission Permission) (Permission, error) {
+	user, err := s.client.Users.Get(ctx, username)
+	if err != nil {
+		return "", err
+	}
+	if user.IsAdmin {
+		return PermissionManage, nil
+	}
+
+	var explicit Permission
+	permission, err := s.GetPermission(ctx, name, username)
+	if err != nil && !IsResourceDoesNotExist(err) {
+		return "", err
+	}
+	if permission != nil {
+		explicit = permission.Permission
+	}
+
+	return EffectivePermission(user, explicit, defaultPermission), nil
+}
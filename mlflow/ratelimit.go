@@ -0,0 +1,110 @@
+package mlflow
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// WithArtifactRateLimit limits the combined throughput of the artifact uploads and downloads
+// of the client to bytesPerSecond.
+func WithArtifactRateLimit(bytesPerSecond int64) ClientOption {
+	return func(c *Client) {
+		c.artifactLimiter = newRateLimiter(bytesPerSecond)
+	}
+}
+
+// rateLimiter limits the throughput of the readers and writers sharing it.
+type rateLimiter struct {
+	rate float64 // bytes per second
+
+	mu sync.Mutex
+	// next is the time at which the transfer of the next bytes may start.
+	next time.Time
+}
+
+func newRateLimiter(bytesPerSecond int64) *rateLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+
+	return &rateLimiter{rate: float64(bytesPerSecond)}
+}
+
+// wait accounts for the transfer of n bytes, sleeping as long as needed to keep the
+// throughput under the limit.
+func (l *rateLimiter) wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(time.Duration(float64(n) / l.rate * float64(time.Second)))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reader returns r, throttled by the limiter, which may be nil.
+func (l *rateLimiter) reader(ctx context.Context, r io.Reader) io.Reader {
+	if l == nil {
+		return r
+	}
+
+	return &limitedReader{ctx: ctx, r: r, l: l}
+}
+
+// writer returns w, throttled by the limiter, which may be nil.
+func (l *rateLimiter) writer(ctx context.Context, w io.Writer) io.Writer {
+	if l == nil {
+		return w
+	}
+
+	return &limitedWriter{ctx: ctx, w: w, l: l}
+}
+
+type limitedReader struct {
+	ctx context.Context
+	r   io.Reader
+	l   *rateLimiter
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if werr := r.l.wait(r.ctx, n); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return n, err
+}
+
+type limitedWriter struct {
+	ctx context.Context
+	w   io.Writer
+	l   *rateLimiter
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	if n > 0 {
+		if werr := w.l.wait(w.ctx, n); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return n, err
+}
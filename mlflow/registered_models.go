@@ -0,0 +1,267 @@
+package mlflow
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+type RegisteredModel struct {
+	Name                 string                  `json:"name,omitempty"`
+	CreationTimestamp    int64                   `json:"creation_timestamp,omitempty"`
+	LastUpdatedTimestamp int64                   `json:"last_updated_timestamp,omitempty"`
+	UserID               string                  `json:"user_id,omitempty"`
+	Description          string                  `json:"description,omitempty"`
+	LatestVersions       []*ModelVersion         `json:"latest_versions,omitempty"`
+	Tags                 []*RegisteredModelTag   `json:"tags,omitempty"`
+	Aliases              []*RegisteredModelAlias `json:"aliases,omitempty"`
+}
+
+type RegisteredModelTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type RegisteredModelAlias struct {
+	Alias   string `json:"alias"`
+	Version string `json:"version"`
+}
+
+type RegisteredModelCreateOptions struct {
+	Name        string                `json:"name,omitempty"`
+	Tags        []*RegisteredModelTag `json:"tags,omitempty"`
+	Description string                `json:"description,omitempty"`
+}
+
+type RegisteredModelsSearchOptions struct {
+	Filter     string
+	MaxResults int64
+	OrderBy    []string
+	PageToken  string
+}
+
+type RegisteredModelsSearchResults struct {
+	RegisteredModels []*RegisteredModel `json:"registered_models,omitempty"`
+	NextPageToken    string             `json:"next_page_token,omitempty"`
+}
+
+func (s *RegisteredModelService) Create(ctx context.Context, opts *RegisteredModelCreateOptions) (*RegisteredModel, error) {
+	var res struct {
+		RegisteredModel *RegisteredModel `json:"registered_model,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "POST", "registered-models/create", nil, opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.RegisteredModel, nil
+}
+
+func (s *RegisteredModelService) Get(ctx context.Context, name string) (*RegisteredModel, error) {
+	var res struct {
+		RegisteredModel *RegisteredModel `json:"registered_model,omitempty"`
+	}
+
+	params := url.Values{}
+	params.Set("name", name)
+
+	_, err := s.client.Do(ctx, "GET", "registered-models/get", params, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.RegisteredModel, nil
+}
+
+func (s *RegisteredModelService) Update(ctx context.Context, name, description string) (*RegisteredModel, error) {
+	opts := struct {
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description"`
+	}{
+		Name:        name,
+		Description: description,
+	}
+
+	var res struct {
+		RegisteredModel *RegisteredModel `json:"registered_model,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "PATCH", "registered-models/update", nil, &opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.RegisteredModel, nil
+}
+
+func (s *RegisteredModelService) Rename(ctx context.Context, name, newName string) (*RegisteredModel, error) {
+	opts := struct {
+		Name    string `json:"name,omitempty"`
+		NewName string `json:"new_name,omitempty"`
+	}{
+		Name:    name,
+		NewName: newName,
+	}
+
+	var res struct {
+		RegisteredModel *RegisteredModel `json:"registered_model,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "POST", "registered-models/rename", nil, &opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.RegisteredModel, nil
+}
+
+func (s *RegisteredModelService) Delete(ctx context.Context, name string) error {
+	opts := struct {
+		Name string `json:"name,omitempty"`
+	}{
+		Name: name,
+	}
+
+	_, err := s.client.Do(ctx, "DELETE", "registered-models/delete", nil, &opts, nil)
+	return err
+}
+
+func (s *RegisteredModelService) SetTag(ctx context.Context, name, key, value string) error {
+	opts := struct {
+		Name  string `json:"name,omitempty"`
+		Key   string `json:"key,omitempty"`
+		Value string `json:"value,omitempty"`
+	}{
+		Name:  name,
+		Key:   key,
+		Value: value,
+	}
+
+	_, err := s.client.Do(ctx, "POST", "registered-models/set-tag", nil, &opts, nil)
+	return err
+}
+
+// SetTags sets multiple tags on a registered model, issuing the requests concurrently.
+func (s *RegisteredModelService) SetTags(ctx context.Context, name string, tags map[string]string) error {
+	return setTags(ctx, tags, func(ctx context.Context, key, value string) error {
+		return s.SetTag(ctx, name, key, value)
+	})
+}
+
+func (s *RegisteredModelService) DeleteTag(ctx context.Context, name, key string) error {
+	opts := struct {
+		Name string `json:"name,omitempty"`
+		Key  string `json:"key,omitempty"`
+	}{
+		Name: name,
+		Key:  key,
+	}
+
+	_, err := s.client.Do(ctx, "DELETE", "registered-models/delete-tag", nil, &opts, nil)
+	return err
+}
+
+// Alias returns the version the alias points to, empty if the model has no such alias.
+func (m *RegisteredModel) Alias(alias string) string {
+	for _, a := range m.Aliases {
+		if a.Alias == alias {
+			return a.Version
+		}
+	}
+	return ""
+}
+
+func (s *RegisteredModelService) Search(ctx context.Context, opts *RegisteredModelsSearchOptions) (*RegisteredModelsSearchResults, error) {
+	var res RegisteredModelsSearchResults
+
+	params := url.Values{}
+	if opts != nil {
+		if opts.Filter != "" {
+			params.Set("filter", opts.Filter)
+		}
+		if opts.MaxResults > 0 {
+			params.Set("max_results", strconv.FormatInt(opts.MaxResults, 10))
+		}
+		for _, orderBy := range opts.OrderBy {
+			params.Add("order_by", orderBy)
+		}
+		if opts.PageToken != "" {
+			params.Set("page_token", opts.PageToken)
+		}
+	}
+
+	_, err := s.client.Do(ctx, "GET", "registered-models/search", params, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *RegisteredModelService) Iterate(ctx context.Context, opts *RegisteredModelsSearchOptions) *Iterator[*RegisteredModel] {
+	if opts == nil {
+		opts = &RegisteredModelsSearchOptions{}
+	}
+	o := *opts
+
+	return newIterator(ctx, o.PageToken, func(ctx context.Context, pageToken string) ([]*RegisteredModel, string, error) {
+		o.PageToken = pageToken
+
+		res, err := s.Search(ctx, &o)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return res.RegisteredModels, res.NextPageToken, nil
+	})
+}
+
+func (s *RegisteredModelService) SearchAll(ctx context.Context, opts *RegisteredModelsSearchOptions) ([]*RegisteredModel, error) {
+	return s.Iterate(ctx, opts).All()
+}
+
+func (s *RegisteredModelService) SetAlias(ctx context.Context, name, alias, version string) error {
+	opts := struct {
+		Name    string `json:"name,omitempty"`
+		Alias   string `json:"alias,omitempty"`
+		Version string `json:"version,omitempty"`
+	}{
+		Name:    name,
+		Alias:   alias,
+		Version: version,
+	}
+
+	_, err := s.client.Do(ctx, "POST", "registered-models/alias", nil, &opts, nil)
+	return err
+}
+
+func (s *RegisteredModelService) DeleteAlias(ctx context.Context, name, alias string) error {
+	opts := struct {
+		Name  string `json:"name,omitempty"`
+		Alias string `json:"alias,omitempty"`
+	}{
+		Name:  name,
+		Alias: alias,
+	}
+
+	_, err := s.client.Do(ctx, "DELETE", "registered-models/alias", nil, &opts, nil)
+	return err
+}
+
+func (s *RegisteredModelService) GetModelVersionByAlias(ctx context.Context, name, alias string) (*ModelVersion, error) {
+	var res struct {
+		ModelVersion *ModelVersion `json:"model_version,omitempty"`
+	}
+
+	params := url.Values{}
+	params.Set("name", name)
+	params.Set("alias", alias)
+
+	_, err := s.client.Do(ctx, "GET", "registered-models/alias", params, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.ModelVersion, nil
+}
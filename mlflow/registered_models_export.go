@@ -0,0 +1,280 @@
+package mlflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/codeocean/go-mlflow/internal/modelversion"
+)
+
+// exportModelFile is the file of a registered model export.
+const exportModelFile = "model.json"
+
+type exportedModelFile struct {
+	System exportSystem `json:"system"`
+	Info   struct {
+		NumSrcVersions int      `json:"num_src_versions"`
+		NumDstVersions int      `json:"num_dst_versions"`
+		FailedVersions []string `json:"failed_versions"`
+	} `json:"info"`
+	MLflow struct {
+		RegisteredModel *exportedModel `json:"registered_model"`
+	} `json:"mlflow"`
+}
+
+type exportedModel struct {
+	Name                 string                  `json:"name"`
+	CreationTimestamp    int64                   `json:"creation_timestamp,omitempty"`
+	LastUpdatedTimestamp int64                   `json:"last_updated_timestamp,omitempty"`
+	Description          string                  `json:"description,omitempty"`
+	Tags                 map[string]string       `json:"tags"`
+	Aliases              exportedAliases         `json:"aliases"`
+	Versions             []*exportedModelVersion `json:"versions"`
+}
+
+// exportedAliases maps the aliases of a registered model to versions, decoded from an object
+// or from a list of aliases.
+type exportedAliases map[string]string
+
+func (a *exportedAliases) UnmarshalJSON(b []byte) error {
+	var m map[string]string
+	if json.Unmarshal(b, &m) == nil {
+		*a = m
+		return nil
+	}
+
+	var list []*RegisteredModelAlias
+	err := json.Unmarshal(b, &list)
+	if err != nil {
+		return err
+	}
+	*a = exportedAliases{}
+	for _, alias := range list {
+		(*a)[alias.Alias] = alias.Version
+	}
+	return nil
+}
+
+type exportedModelVersion struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	CreationTimestamp    int64             `json:"creation_timestamp,omitempty"`
+	LastUpdatedTimestamp int64             `json:"last_updated_timestamp,omitempty"`
+	CurrentStage         ModelVersionStage `json:"current_stage,omitempty"`
+	Description          string            `json:"description,omitempty"`
+	Source               string            `json:"source,omitempty"`
+	RunID                string            `json:"run_id,omitempty"`
+	Status               string            `json:"status,omitempty"`
+	Tags                 map[string]string `json:"tags"`
+	Aliases              []string          `json:"aliases"`
+}
+
+type ModelImportResult struct {
+	Name string
+	// RunIDs maps the IDs of the exported runs of the versions to the IDs of the imported runs.
+	RunIDs map[string]string
+	// Versions maps the exported versions to the imported versions.
+	Versions map[string]string
+}
+
+// Export writes a registered model, its versions and their runs to dir, using the layout of
+// mlflow-export-import:
+//
+//	dir/
+//	  model.json             registered model metadata, aliases and versions
+//	  <run_id>/              the run of a version, see RunService.Export
+//
+// The versions whose run was deleted are exported without it.
+func (s *RegisteredModelService) Export(ctx context.Context, name, dir string) error {
+	model, err := s.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	versions, err := s.client.ModelVersions.SearchAll(ctx, &ModelVersionsSearchOptions{
+		Filter: "name = " + quoteFilterString(name),
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i].Version, versions[j].Version) < 0
+	})
+
+	system := s.client.exportSystem()
+
+	var out exportedModelFile
+	out.System = system
+	out.Info.FailedVersions = []string{}
+	out.MLflow.RegisteredModel = &exportedModel{
+		Name:                 model.Name,
+		CreationTimestamp:    model.CreationTimestamp,
+		LastUpdatedTimestamp: model.LastUpdatedTimestamp,
+		Description:          model.Description,
+		Tags:                 map[string]string{},
+		Aliases:              exportedAliases{},
+		Versions:             []*exportedModelVersion{},
+	}
+	for _, tag := range model.Tags {
+		out.MLflow.RegisteredModel.Tags[tag.Key] = tag.Value
+	}
+	for _, alias := range model.Aliases {
+		out.MLflow.RegisteredModel.Aliases[alias.Alias] = alias.Version
+	}
+
+	exported := map[string]bool{}
+	for _, v := range versions {
+		ev := &exportedModelVersion{
+			Name:                 v.Name,
+			Version:              v.Version,
+			CreationTimestamp:    v.CreationTimestamp,
+			LastUpdatedTimestamp: v.LastUpdatedTimestamp,
+			CurrentStage:         ModelVersionStage(v.CurrentStage),
+			Description:          v.Description,
+			Source:               v.Source,
+			RunID:                v.RunID,
+			Status:               string(v.Status),
+			Tags:                 map[string]string{},
+			Aliases:              v.Aliases,
+		}
+		for _, tag := range v.Tags {
+			ev.Tags[tag.Key] = tag.Value
+		}
+		if ev.Aliases == nil {
+			ev.Aliases = []string{}
+		}
+		out.MLflow.RegisteredModel.Versions = append(out.MLflow.RegisteredModel.Versions, ev)
+
+		if v.RunID == "" || exported[v.RunID] {
+			continue
+		}
+		run, err := s.client.Runs.Get(ctx, v.RunID)
+		if IsResourceDoesNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = s.client.Runs.export(ctx, run, filepath.Join(dir, v.RunID), system)
+		if err != nil {
+			return err
+		}
+		exported[v.RunID] = true
+	}
+	out.Info.NumSrcVersions = len(versions)
+	out.Info.NumDstVersions = len(versions)
+
+	return writeJSONFile(filepath.Join(dir, exportModelFile), &out)
+}
+
+// Import recreates a registered model exported by Export, or by mlflow-export-import, from
+// dir. The runs of the versions are imported in an experiment, and the sources of the
+// versions referring to them are rewritten to the imported runs. The versions are created
+// in order, waiting for each to be ready, before they are moved to their stage and the
+// aliases are set.
+func (s *RegisteredModelService) Import(ctx context.Context, dir, experimentID string) (*ModelImportResult, error) {
+	var in exportedModelFile
+	err := readJSONFile(filepath.Join(dir, exportModelFile), &in)
+	if err != nil {
+		return nil, err
+	}
+	m := in.MLflow.RegisteredModel
+	if m == nil {
+		return nil, fmt.Errorf("mlflow: %s does not describe a registered model", exportModelFile)
+	}
+
+	opts := &RegisteredModelCreateOptions{Name: m.Name, Description: m.Description}
+	for key, value := range m.Tags {
+		opts.Tags = append(opts.Tags, &RegisteredModelTag{Key: key, Value: value})
+	}
+	_, err = s.Create(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := append([]*exportedModelVersion(nil), m.Versions...)
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i].Version, versions[j].Version) < 0
+	})
+
+	var dirs []string
+	seen := map[string]bool{}
+	for _, v := range versions {
+		runDir := filepath.Join(dir, v.RunID)
+		if v.RunID == "" || seen[runDir] {
+			continue
+		}
+		seen[runDir] = true
+		if _, err := os.Stat(filepath.Join(runDir, exportRunFile)); err == nil {
+			dirs = append(dirs, runDir)
+		}
+	}
+
+	res := &ModelImportResult{Name: m.Name, Versions: map[string]string{}}
+	runIDs, rewrite, err := s.client.Runs.importRuns(ctx, experimentID, dirs)
+	res.RunIDs = runIDs
+	if err != nil {
+		return res, err
+	}
+
+	for _, v := range versions {
+		opts := &ModelVersionCreateOptions{
+			Name:        m.Name,
+			Source:      rewrite.Replace(v.Source),
+			RunID:       runIDs[v.RunID],
+			Description: v.Description,
+		}
+		for key, value := range v.Tags {
+			opts.Tags = append(opts.Tags, &ModelVersionTag{Key: key, Value: value})
+		}
+
+		created, err := s.client.ModelVersions.Create(ctx, opts)
+		if err != nil {
+			return res, err
+		}
+		res.Versions[v.Version] = created.Version
+
+		_, err = s.client.ModelVersions.WaitUntilReady(ctx, m.Name, created.Version, time.Second)
+		if err != nil {
+			return res, err
+		}
+
+		if v.CurrentStage != "" && v.CurrentStage != ModelVersionStageNone {
+			_, err = s.client.ModelVersions.TransitionStage(ctx, m.Name, created.Version, v.CurrentStage, false)
+			if err != nil {
+				return res, err
+			}
+		}
+	}
+
+	aliases := m.Aliases
+	if len(aliases) == 0 {
+		// Older exports only list the aliases of the versions.
+		aliases = exportedAliases{}
+		for _, v := range versions {
+			for _, alias := range v.Aliases {
+				aliases[alias] = v.Version
+			}
+		}
+	}
+	for alias, version := range aliases {
+		if imported, ok := res.Versions[version]; ok {
+			err = s.SetAlias(ctx, m.Name, alias, imported)
+			if err != nil {
+				return res, err
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// compareVersions orders model versions numerically.
+func compareVersions(a, b string) int {
+	return modelversion.Compare(a, b)
+}
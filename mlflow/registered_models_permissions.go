@@ -32,11 +32,19 @@ func (s *RegisteredModelService) CreatePermission(ctx context.Context, name, use
 	if err != nil {
 		return nil, err
 	}
+	s.client.permissionCache.evict(permissionCacheKey{"registered_model", name, username})
 
 	return res.RegisteredModelPermission, nil
 }
 
 func (s *RegisteredModelService) GetPermission(ctx context.Context, name, username string) (*RegisteredModelPermission, error) {
+	key := permissionCacheKey{"registered_model", name, username}
+	return cachedPermission(s.client.permissionCache, key, func() (*RegisteredModelPermission, error) {
+		return s.getPermission(ctx, name, username)
+	})
+}
+
+func (s *RegisteredModelService) getPermission(ctx context.Context, name, username string) (*RegisteredModelPermission, error) {
 	var res struct {
 		RegisteredModelPermission *RegisteredModelPermission `json:"registered_model_permission,omitempty"`
 	}
@@ -65,7 +73,12 @@ func (s *RegisteredModelService) UpdatePermission(ctx context.Context, name, use
 	}
 
 	_, err := s.client.Do(ctx, "PATCH", "registered-models/permissions/update", nil, &opts, nil)
-	return err
+	if err != nil {
+		return err
+	}
+	s.client.permissionCache.evict(permissionCacheKey{"registered_model", name, username})
+
+	return nil
 }
 
 func (s *RegisteredModelService) DeletePermission(ctx context.Context, name, username string) error {
@@ -78,5 +91,20 @@ func (s *RegisteredModelService) DeletePermission(ctx context.Context, name, use
 	}
 
 	_, err := s.client.Do(ctx, "DELETE", "registered-models/permissions/delete", nil, &opts, nil)
+	if err != nil {
+		return err
+	}
+	s.client.permissionCache.evict(permissionCacheKey{"registered_model", name, username})
+
+	return nil
+}
+
+// GrantPermission gives a user a permission on a registered model, creating the permission or
+// updating the existing one.
+func (s *RegisteredModelService) GrantPermission(ctx context.Context, name, username string, permission Permission) error {
+	_, err := s.CreatePermission(ctx, name, username, permission)
+	if IsResourceAlreadyExists(err) {
+		return s.UpdatePermission(ctx, name, username, permission)
+	}
 	return err
 }
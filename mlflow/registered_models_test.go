@@ -0,0 +1,169 @@
+package mlflow_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+	"github.com/codeocean/go-mlflow/mlflowtest"
+)
+
+// newTestClient returns a client of a fake tracking server closed at the end of the test.
+func newTestClient(t *testing.T) *mlflow.Client {
+	t.Helper()
+	srv := mlflowtest.NewServer()
+	t.Cleanup(srv.Close)
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// createVersions creates a registered model with n versions.
+func createVersions(t *testing.T, client *mlflow.Client, name string, n int) {
+	t.Helper()
+	ctx := context.Background()
+	_, err := client.RegisteredModels.Create(ctx, &mlflow.RegisteredModelCreateOptions{Name: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		_, err := client.ModelVersions.Create(ctx, &mlflow.ModelVersionCreateOptions{Name: name, Source: "s3://bucket/model"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRegisteredModelsDeleteEndpoints(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// delete deletes what the setup of the test created.
+		delete func(client *mlflow.Client) error
+		// deleted returns an error if it was not deleted.
+		deleted func(client *mlflow.Client) error
+	}{
+		{
+			name: "alias",
+			delete: func(client *mlflow.Client) error {
+				return client.RegisteredModels.DeleteAlias(ctx, "m", "champion")
+			},
+			deleted: func(client *mlflow.Client) error {
+				_, err := client.RegisteredModels.GetModelVersionByAlias(ctx, "m", "champion")
+				return expectNotFound(err)
+			},
+		},
+		{
+			name: "registered model tag",
+			delete: func(client *mlflow.Client) error {
+				return client.RegisteredModels.DeleteTag(ctx, "m", "team")
+			},
+			deleted: func(client *mlflow.Client) error {
+				m, err := client.RegisteredModels.Get(ctx, "m")
+				if err != nil {
+					return err
+				}
+				if len(m.Tags) > 0 {
+					return errorf("tags %v not deleted", m.Tags)
+				}
+				return nil
+			},
+		},
+		{
+			name: "model version tag",
+			delete: func(client *mlflow.Client) error {
+				return client.ModelVersions.DeleteTag(ctx, "m", "1", "validated")
+			},
+			deleted: func(client *mlflow.Client) error {
+				v, err := client.ModelVersions.Get(ctx, "m", "1")
+				if err != nil {
+					return err
+				}
+				if len(v.Tags) > 0 {
+					return errorf("tags %v not deleted", v.Tags)
+				}
+				return nil
+			},
+		},
+		{
+			name: "model version",
+			delete: func(client *mlflow.Client) error {
+				return client.ModelVersions.Delete(ctx, "m", "1")
+			},
+			deleted: func(client *mlflow.Client) error {
+				_, err := client.ModelVersions.Get(ctx, "m", "1")
+				return expectNotFound(err)
+			},
+		},
+		{
+			name: "registered model",
+			delete: func(client *mlflow.Client) error {
+				return client.RegisteredModels.Delete(ctx, "m")
+			},
+			deleted: func(client *mlflow.Client) error {
+				_, err := client.RegisteredModels.Get(ctx, "m")
+				return expectNotFound(err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t)
+			createVersions(t, client, "m", 1)
+			for _, err := range []error{
+				client.RegisteredModels.SetAlias(ctx, "m", "champion", "1"),
+				client.RegisteredModels.SetTag(ctx, "m", "team", "fraud"),
+				client.ModelVersions.SetTag(ctx, "m", "1", "validated", "true"),
+			} {
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := tt.delete(client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = tt.deleted(client)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestPromoteVersionUnaliasesPreviousVersions(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	createVersions(t, client, "m", 2)
+	for _, alias := range []string{"champion", "legacy"} {
+		err := client.RegisteredModels.SetAlias(ctx, "m", alias, "1")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := client.ModelVersions.PromoteVersion(ctx, "m", "2", &mlflow.PromotionPolicy{
+		Alias:    "champion",
+		Previous: mlflow.PreviousVersionsUnalias,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := client.RegisteredModels.GetModelVersionByAlias(ctx, "m", "champion")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Version != "2" {
+		t.Errorf("champion points to version %s, want 2", v.Version)
+	}
+	_, err = client.RegisteredModels.GetModelVersionByAlias(ctx, "m", "legacy")
+	if err := expectNotFound(err); err != nil {
+		t.Error(err)
+	}
+}
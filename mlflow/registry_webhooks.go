@@ -0,0 +1,200 @@
+package mlflow
+
+import (
+	"context"
+	"net/url"
+)
+
+// RegistryWebhooksService manages the model registry webhooks of Databricks workspaces; the
+// open source tracking server does not implement these endpoints.
+type RegistryWebhooksService service
+
+type RegistryWebhookEvent string
+
+const (
+	RegistryWebhookEventModelVersionCreated                  RegistryWebhookEvent = "MODEL_VERSION_CREATED"
+	RegistryWebhookEventModelVersionTransitionedStage        RegistryWebhookEvent = "MODEL_VERSION_TRANSITIONED_STAGE"
+	RegistryWebhookEventTransitionRequestCreated             RegistryWebhookEvent = "TRANSITION_REQUEST_CREATED"
+	RegistryWebhookEventCommentCreated                       RegistryWebhookEvent = "COMMENT_CREATED"
+	RegistryWebhookEventRegisteredModelCreated               RegistryWebhookEvent = "REGISTERED_MODEL_CREATED"
+	RegistryWebhookEventModelVersionTagSet                   RegistryWebhookEvent = "MODEL_VERSION_TAG_SET"
+	RegistryWebhookEventModelVersionTransitionedToStaging    RegistryWebhookEvent = "MODEL_VERSION_TRANSITIONED_TO_STAGING"
+	RegistryWebhookEventModelVersionTransitionedToProduction RegistryWebhookEvent = "MODEL_VERSION_TRANSITIONED_TO_PRODUCTION"
+	RegistryWebhookEventModelVersionTransitionedToArchived   RegistryWebhookEvent = "MODEL_VERSION_TRANSITIONED_TO_ARCHIVED"
+	RegistryWebhookEventTransitionRequestToStagingCreated    RegistryWebhookEvent = "TRANSITION_REQUEST_TO_STAGING_CREATED"
+	RegistryWebhookEventTransitionRequestToProductionCreated RegistryWebhookEvent = "TRANSITION_REQUEST_TO_PRODUCTION_CREATED"
+	RegistryWebhookEventTransitionRequestToArchivedCreated   RegistryWebhookEvent = "TRANSITION_REQUEST_TO_ARCHIVED_CREATED"
+)
+
+type RegistryWebhookStatus string
+
+const (
+	RegistryWebhookStatusActive   RegistryWebhookStatus = "ACTIVE"
+	RegistryWebhookStatusTestMode RegistryWebhookStatus = "TEST_MODE"
+	RegistryWebhookStatusDisabled RegistryWebhookStatus = "DISABLED"
+)
+
+type RegistryWebhook struct {
+	ID                   string                 `json:"id,omitempty"`
+	CreationTimestamp    int64                  `json:"creation_timestamp,omitempty"`
+	LastUpdatedTimestamp int64                  `json:"last_updated_timestamp,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Events               []RegistryWebhookEvent `json:"events,omitempty"`
+	HttpUrlSpec          *HttpUrlSpec           `json:"http_url_spec,omitempty"`
+	JobSpec              *JobSpec               `json:"job_spec,omitempty"`
+	ModelName            string                 `json:"model_name,omitempty"`
+	Status               RegistryWebhookStatus  `json:"status,omitempty"`
+}
+
+// HttpUrlSpec configures a webhook calling a URL.
+type HttpUrlSpec struct {
+	URL                   string `json:"url,omitempty"`
+	EnableSslVerification *bool  `json:"enable_ssl_verification,omitempty"`
+	// Secret is used to sign the payloads, it is never returned.
+	Secret string `json:"secret,omitempty"`
+	// Authorization is sent as the Authorization header, it is never returned.
+	Authorization string `json:"authorization,omitempty"`
+}
+
+// JobSpec configures a webhook triggering a Databricks job.
+type JobSpec struct {
+	JobID        string `json:"job_id,omitempty"`
+	WorkspaceURL string `json:"workspace_url,omitempty"`
+	// AccessToken is never returned.
+	AccessToken string `json:"access_token,omitempty"`
+}
+
+type RegistryWebhookCreateOptions struct {
+	// ModelName restricts the webhook to the events of a registered model, empty for the
+	// events of all the models of the registry.
+	ModelName   string                 `json:"model_name,omitempty"`
+	Events      []RegistryWebhookEvent `json:"events,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Status      RegistryWebhookStatus  `json:"status,omitempty"`
+	HttpUrlSpec *HttpUrlSpec           `json:"http_url_spec,omitempty"`
+	JobSpec     *JobSpec               `json:"job_spec,omitempty"`
+}
+
+type RegistryWebhookUpdateOptions struct {
+	ID          string                 `json:"id,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Events      []RegistryWebhookEvent `json:"events,omitempty"`
+	Status      RegistryWebhookStatus  `json:"status,omitempty"`
+	HttpUrlSpec *HttpUrlSpec           `json:"http_url_spec,omitempty"`
+	JobSpec     *JobSpec               `json:"job_spec,omitempty"`
+}
+
+type RegistryWebhooksListOptions struct {
+	ModelName string
+	Events    []RegistryWebhookEvent
+	PageToken string
+}
+
+type RegistryWebhooksListResults struct {
+	Webhooks      []*RegistryWebhook `json:"webhooks,omitempty"`
+	NextPageToken string             `json:"next_page_token,omitempty"`
+}
+
+// RegistryWebhookTestResult is the response of the webhook endpoint to a test event.
+type RegistryWebhookTestResult struct {
+	StatusCode int    `json:"status_code,omitempty"`
+	Body       string `json:"body,omitempty"`
+}
+
+func (s *RegistryWebhooksService) Create(ctx context.Context, opts *RegistryWebhookCreateOptions) (*RegistryWebhook, error) {
+	var res struct {
+		Webhook *RegistryWebhook `json:"webhook,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "POST", "registry-webhooks/create", nil, opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Webhook, nil
+}
+
+func (s *RegistryWebhooksService) List(ctx context.Context, opts *RegistryWebhooksListOptions) (*RegistryWebhooksListResults, error) {
+	var res RegistryWebhooksListResults
+
+	params := url.Values{}
+	if opts != nil {
+		if opts.ModelName != "" {
+			params.Set("model_name", opts.ModelName)
+		}
+		for _, event := range opts.Events {
+			params.Add("events", string(event))
+		}
+		if opts.PageToken != "" {
+			params.Set("page_token", opts.PageToken)
+		}
+	}
+
+	_, err := s.client.Do(ctx, "GET", "registry-webhooks/list", params, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *RegistryWebhooksService) Iterate(ctx context.Context, opts *RegistryWebhooksListOptions) *Iterator[*RegistryWebhook] {
+	if opts == nil {
+		opts = &RegistryWebhooksListOptions{}
+	}
+	o := *opts
+
+	return newIterator(ctx, o.PageToken, func(ctx context.Context, pageToken string) ([]*RegistryWebhook, string, error) {
+		o.PageToken = pageToken
+
+		res, err := s.List(ctx, &o)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return res.Webhooks, res.NextPageToken, nil
+	})
+}
+
+func (s *RegistryWebhooksService) Update(ctx context.Context, opts *RegistryWebhookUpdateOptions) (*RegistryWebhook, error) {
+	var res struct {
+		Webhook *RegistryWebhook `json:"webhook,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "PATCH", "registry-webhooks/update", nil, opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Webhook, nil
+}
+
+func (s *RegistryWebhooksService) Delete(ctx context.Context, id string) error {
+	params := url.Values{}
+	params.Set("id", id)
+
+	_, err := s.client.Do(ctx, "DELETE", "registry-webhooks/delete", params, nil, nil)
+	return err
+}
+
+// Test sends a test event to a webhook, event defaults to the first event of the webhook.
+func (s *RegistryWebhooksService) Test(ctx context.Context, id string, event RegistryWebhookEvent) (*RegistryWebhookTestResult, error) {
+	opts := struct {
+		ID    string               `json:"id,omitempty"`
+		Event RegistryWebhookEvent `json:"event,omitempty"`
+	}{
+		ID:    id,
+		Event: event,
+	}
+
+	var res struct {
+		Webhook *RegistryWebhookTestResult `json:"webhook,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "POST", "registry-webhooks/test", nil, &opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Webhook, nil
+}
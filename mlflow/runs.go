@@ -1,8 +1,15 @@
 package mlflow
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -18,6 +25,9 @@ const (
 	RunStatusKilled    RunStatus = "KILLED"
 )
 
+// TagLoggedArtifacts is the run tag listing the tables and images logged to a run.
+const TagLoggedArtifacts = "mlflow.loggedArtifacts"
+
 type ViewType string
 
 const (
@@ -26,6 +36,21 @@ const (
 	ViewTypeAll         ViewType = "ALL"
 )
 
+type LifecycleStage string
+
+const (
+	LifecycleStageActive  LifecycleStage = "active"
+	LifecycleStageDeleted LifecycleStage = "deleted"
+)
+
+// ViewType returns the view type selecting only the entities in the lifecycle stage.
+func (s LifecycleStage) ViewType() ViewType {
+	if s == LifecycleStageDeleted {
+		return ViewTypeDeletedOnly
+	}
+	return ViewTypeActiveOnly
+}
+
 type Run struct {
 	Info   *RunInfo   `json:"info,omitempty"`
 	Data   *RunData   `json:"data,omitempty"`
@@ -33,14 +58,18 @@ type Run struct {
 }
 
 type RunInfo struct {
-	RunID          string    `json:"run_id,omitempty"`
-	RunName        string    `json:"run_name,omitempty"`
-	ExperimentID   string    `json:"experiment_id,omitempty"`
-	Status         RunStatus `json:"status,omitempty"`
-	StartTime      int64     `json:"start_time,omitempty"`
-	EndTime        int64     `json:"end_time,omitempty"`
-	ArtifactUri    string    `json:"artifact_uri,omitempty"`
-	LifecycleStage string    `json:"lifecycle_stage,omitempty"`
+	RunID          string         `json:"run_id,omitempty"`
+	RunName        string         `json:"run_name,omitempty"`
+	ExperimentID   string         `json:"experiment_id,omitempty"`
+	Status         RunStatus      `json:"status,omitempty"`
+	StartTime      int64          `json:"start_time,omitempty"`
+	EndTime        int64          `json:"end_time,omitempty"`
+	ArtifactUri    string         `json:"artifact_uri,omitempty"`
+	LifecycleStage LifecycleStage `json:"lifecycle_stage,omitempty"`
+}
+
+func (i *RunInfo) IsDeleted() bool {
+	return i.LifecycleStage == LifecycleStageDeleted
 }
 
 type RunData struct {
@@ -49,11 +78,92 @@ type RunData struct {
 	Tags    []*RunTag `json:"tags,omitempty"`
 }
 
+// LatestMetrics returns the most recently logged value of each metric, keyed by metric key.
+// Ties on timestamp are broken by the higher step.
+func (d *RunData) LatestMetrics() map[string]float64 {
+	if d == nil {
+		return map[string]float64{}
+	}
+
+	latest := map[string]*Metric{}
+	for _, m := range d.Metrics {
+		l, ok := latest[m.Key]
+		if !ok || m.Timestamp > l.Timestamp || (m.Timestamp == l.Timestamp && m.Step >= l.Step) {
+			latest[m.Key] = m
+		}
+	}
+
+	res := make(map[string]float64, len(latest))
+	for key, m := range latest {
+		res[key] = m.Value
+	}
+	return res
+}
+
+// MaxMetrics returns the maximum value of each metric, keyed by metric key.
+func (d *RunData) MaxMetrics() map[string]float64 {
+	return d.reduceMetrics(func(acc, v float64) float64 {
+		if v > acc {
+			return v
+		}
+		return acc
+	})
+}
+
+// MinMetrics returns the minimum value of each metric, keyed by metric key.
+func (d *RunData) MinMetrics() map[string]float64 {
+	return d.reduceMetrics(func(acc, v float64) float64 {
+		if v < acc {
+			return v
+		}
+		return acc
+	})
+}
+
+// MeanMetrics returns the arithmetic mean of each metric, keyed by metric key.
+func (d *RunData) MeanMetrics() map[string]float64 {
+	sums := d.reduceMetrics(func(acc, v float64) float64 { return acc + v })
+	if len(sums) == 0 {
+		return sums
+	}
+
+	counts := map[string]int{}
+	for _, m := range d.Metrics {
+		counts[m.Key]++
+	}
+
+	for key, sum := range sums {
+		sums[key] = sum / float64(counts[key])
+	}
+	return sums
+}
+
+func (d *RunData) reduceMetrics(fn func(acc, v float64) float64) map[string]float64 {
+	res := map[string]float64{}
+	if d == nil {
+		return res
+	}
+
+	for _, m := range d.Metrics {
+		acc, ok := res[m.Key]
+		if !ok {
+			res[m.Key] = m.Value
+			continue
+		}
+		res[m.Key] = fn(acc, m.Value)
+	}
+	return res
+}
+
 type Metric struct {
 	Key       string  `json:"key,omitempty"`
 	Value     float64 `json:"value,omitempty"`
 	Timestamp int64   `json:"timestamp,omitempty"`
 	Step      int64   `json:"step,omitempty"`
+	// Model and dataset the metric was computed for, supported by MLflow 3 servers.
+	ModelID       string `json:"model_id,omitempty"`
+	DatasetName   string `json:"dataset_name,omitempty"`
+	DatasetDigest string `json:"dataset_digest,omitempty"`
 }
 
 type Param struct {
@@ -119,6 +229,10 @@ func (s *RunService) Create(ctx context.Context, experimentID, name string, star
 		opts.StartTime = time.Now().UnixMilli()
 	}
 
+	if name == "" && s.client.generateRunNames {
+		opts.RunName = generateRunName()
+	}
+
 	for key, value := range tags {
 		opts.Tags = append(opts.Tags, &RunTag{Key: key, Value: value})
 	}
@@ -155,7 +269,7 @@ func (s *RunService) Update(ctx context.Context, id, name string, status RunStat
 	}
 
 	var res struct {
-		Info *RunInfo `json:"info,omitempty"`
+		Info *RunInfo `json:"run_info,omitempty"`
 	}
 
 	_, err := s.client.Do(ctx, "POST", "runs/update", nil, &opts, &res)
@@ -215,6 +329,28 @@ func (s *RunService) Search(ctx context.Context, opts *RunSearchOptions) (*RunSe
 	return &res, nil
 }
 
+func (s *RunService) Iterate(ctx context.Context, opts *RunSearchOptions) *Iterator[*Run] {
+	if opts == nil {
+		opts = &RunSearchOptions{}
+	}
+	o := *opts
+
+	return newIterator(ctx, o.PageToken, func(ctx context.Context, pageToken string) ([]*Run, string, error) {
+		o.PageToken = pageToken
+
+		res, err := s.Search(ctx, &o)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return res.Runs, res.NextPageToken, nil
+	})
+}
+
+func (s *RunService) SearchAll(ctx context.Context, opts *RunSearchOptions) ([]*Run, error) {
+	return s.Iterate(ctx, opts).All()
+}
+
 func (s *RunService) SetTag(ctx context.Context, id, key, value string) error {
 	opts := struct {
 		RunID string `json:"run_id,omitempty"`
@@ -243,6 +379,30 @@ func (s *RunService) DeleteTag(ctx context.Context, id, key string) error {
 	return err
 }
 
+// SetTags sets multiple tags on a run, in log-batch requests of at most 100 tags.
+func (s *RunService) SetTags(ctx context.Context, id string, tags map[string]string) error {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	batch := make([]*RunTag, len(keys))
+	for i, key := range keys {
+		batch[i] = &RunTag{Key: key, Value: tags[key]}
+	}
+	for len(batch) > 0 {
+		n := chunkSize(len(batch), logBatchMaxTags)
+		err := s.LogBatch(ctx, id, &RunData{Tags: batch[:n]})
+		if err != nil {
+			return err
+		}
+		batch = batch[n:]
+	}
+
+	return nil
+}
+
 func (s *RunService) LogMetric(ctx context.Context, id, key string, value float64, timestamp int64, step int64) error {
 	opts := struct {
 		RunID     string  `json:"run_id,omitempty"`
@@ -315,3 +475,95 @@ func (s *RunService) LogModel(ctx context.Context, id, model string) error {
 	_, err := s.client.Do(ctx, "POST", "runs/log-model", nil, &opts, nil)
 	return err
 }
+
+// LogTable logs a table as a JSON artifact of the run, in the format the MLflow UI
+// displays in its artifact and evaluation views. Every row must have one value per column.
+func (s *RunService) LogTable(ctx context.Context, id, name string, columns []string, rows [][]any) error {
+	if !strings.HasSuffix(name, ".json") {
+		return fmt.Errorf("mlflow: table artifact %q must have a .json extension", name)
+	}
+
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return fmt.Errorf("mlflow: table row %d has %d values, expected %d", i, len(row), len(columns))
+		}
+	}
+
+	if rows == nil {
+		rows = [][]any{}
+	}
+
+	table, err := json.Marshal(struct {
+		Columns []string `json:"columns"`
+		Data    [][]any  `json:"data"`
+	}{
+		Columns: columns,
+		Data:    rows,
+	})
+	if err != nil {
+		return err
+	}
+
+	run, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.client.Artifacts.upload(ctx, run.Info.ArtifactUri, name, bytes.NewReader(table))
+	if err != nil {
+		return err
+	}
+
+	return s.addLoggedArtifact(ctx, run, name, "table")
+}
+
+// addLoggedArtifact records an artifact in the mlflow.loggedArtifacts tag of the run,
+// which the MLflow UI uses to find tables and images.
+func (s *RunService) addLoggedArtifact(ctx context.Context, run *Run, path, artifactType string) error {
+	type loggedArtifact struct {
+		Path string `json:"path"`
+		Type string `json:"type"`
+	}
+
+	var logged []loggedArtifact
+	if run.Data != nil {
+		for _, tag := range run.Data.Tags {
+			if tag.Key == TagLoggedArtifacts {
+				_ = json.Unmarshal([]byte(tag.Value), &logged)
+			}
+		}
+	}
+
+	for _, a := range logged {
+		if a.Path == path && a.Type == artifactType {
+			return nil
+		}
+	}
+	logged = append(logged, loggedArtifact{Path: path, Type: artifactType})
+
+	value, err := json.Marshal(logged)
+	if err != nil {
+		return err
+	}
+
+	return s.SetTag(ctx, run.Info.RunID, TagLoggedArtifacts, string(value))
+}
+
+// LogArtifact uploads a local file to the artifacts of the run, under artifactPath, like
+// mlflow.log_artifact: the file keeps its name, and is uploaded to the artifact root when
+// artifactPath is empty.
+func (s *RunService) LogArtifact(ctx context.Context, id, localPath, artifactPath string) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return s.client.Artifacts.Upload(ctx, id, joinArtifactPath(artifactPath, filepath.Base(localPath)), f)
+}
+
+// LogArtifacts uploads the content of a local directory to the artifacts of the run, under
+// artifactPath, like mlflow.log_artifacts.
+func (s *RunService) LogArtifacts(ctx context.Context, id, localDir, artifactPath string) error {
+	return s.client.Artifacts.UploadTree(ctx, id, localDir, artifactPath, nil)
+}
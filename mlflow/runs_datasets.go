@@ -0,0 +1,70 @@
+package mlflow
+
+import "context"
+
+// inputTagDatasetContext is the tag of dataset inputs holding the context the dataset was used
+// in, such as "training".
+const inputTagDatasetContext = "mlflow.data.context"
+
+// DatasetRun is a run which consumed a dataset.
+type DatasetRun struct {
+	Run     *Run
+	Dataset *Dataset
+	// Context is the context the dataset was used in, such as "training" or "eval", empty if
+	// the input has none.
+	Context string
+}
+
+// SearchByDataset returns the runs of the experiments which consumed the versions of the
+// dataset name with the given digest, any version if digest is empty, such as the runs
+// affected by an incident on the dataset. It searches all the experiments, deleted runs
+// included, if experimentIDs is empty. A run consuming several matching versions is returned
+// once per version.
+func (s *RunService) SearchByDataset(ctx context.Context, experimentIDs []string, name, digest string) ([]*DatasetRun, error) {
+	if len(experimentIDs) == 0 {
+		experiments, err := s.client.Experiments.SearchAll(ctx, &ExperimentsSearchOptions{ViewType: ViewTypeAll})
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range experiments {
+			experimentIDs = append(experimentIDs, e.ExperimentID)
+		}
+		if len(experimentIDs) == 0 {
+			return nil, nil
+		}
+	}
+
+	filter := "datasets.name = " + quoteFilterString(name)
+	if digest != "" {
+		filter += " AND datasets.digest = " + quoteFilterString(digest)
+	}
+
+	var res []*DatasetRun
+	it := s.Iterate(ctx, &RunSearchOptions{ExperimentIDs: experimentIDs, Filter: filter, RunViewType: ViewTypeAll})
+	for it.Next() {
+		run := it.Value()
+		if run.Inputs == nil {
+			continue
+		}
+
+		for _, input := range run.Inputs.DatasetInputs {
+			d := input.Dataset
+			if d == nil || d.Name != name || (digest != "" && d.Digest != digest) {
+				continue
+			}
+
+			r := &DatasetRun{Run: run, Dataset: d}
+			for _, t := range input.Tags {
+				if t.Key == inputTagDatasetContext {
+					r.Context = t.Value
+				}
+			}
+			res = append(res, r)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,32 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+)
+
+// Export writes a run, its full metric histories and its artifacts to dir, using the layout
+// of the runs of mlflow-export-import, see ExperimentService.Export.
+func (s *RunService) Export(ctx context.Context, id, dir string) error {
+	run, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.export(ctx, run, dir, s.client.exportSystem())
+}
+
+// Import recreates a run exported by Export, or by mlflow-export-import, from dir in an
+// experiment and returns the ID of the imported run, also when the import fails after its
+// creation. Tag values referring to the exported run ID or artifact URI are rewritten to the
+// new ones.
+func (s *RunService) Import(ctx context.Context, experimentID, dir string) (string, error) {
+	runIDs, _, err := s.importRuns(ctx, experimentID, []string{dir})
+	for _, id := range runIDs {
+		return id, err
+	}
+	if err == nil {
+		err = fmt.Errorf("mlflow: no run imported from %s", dir)
+	}
+	return "", err
+}
@@ -0,0 +1,37 @@
+package mlflow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/png"
+	"strings"
+)
+
+// LogDict serializes v as JSON and logs it as an artifact of the run, like mlflow.log_dict.
+// JSON being valid YAML, artifactFile may also have a .yaml or .yml extension.
+func (s *RunService) LogDict(ctx context.Context, id string, v any, artifactFile string) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return s.client.Artifacts.Upload(ctx, id, artifactFile, bytes.NewReader(b))
+}
+
+// LogText logs text as an artifact of the run, like mlflow.log_text.
+func (s *RunService) LogText(ctx context.Context, id, text, artifactFile string) error {
+	return s.client.Artifacts.Upload(ctx, id, artifactFile, strings.NewReader(text))
+}
+
+// LogImage encodes img as PNG and logs it as an artifact of the run, like mlflow.log_image.
+func (s *RunService) LogImage(ctx context.Context, id string, img image.Image, artifactFile string) error {
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Artifacts.Upload(ctx, id, artifactFile, &buf)
+}
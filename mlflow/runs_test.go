@@ -0,0 +1,48 @@
+package mlflow_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+func TestRunsSetTagsBatches(t *testing.T) {
+	var batches []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/2.0/mlflow/runs/log-batch" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code": "ENDPOINT_NOT_FOUND"}`))
+			return
+		}
+		var req struct {
+			RunID string           `json:"run_id"`
+			Tags  []*mlflow.RunTag `json:"tags"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		batches = append(batches, len(req.Tags))
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+	client, err := mlflow.NewClient(srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags := map[string]string{}
+	for i := 0; i < 150; i++ {
+		tags[fmt.Sprintf("tag%d", i)] = "value"
+	}
+	err = client.Runs.SetTags(context.Background(), "r1", tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(batches) != "[100 50]" {
+		t.Errorf("got batches of %v tags, want [100 50]", batches)
+	}
+}
@@ -0,0 +1,426 @@
+package mlflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DataType is the type of a column of a schema.
+type DataType string
+
+const (
+	DataTypeBoolean  DataType = "boolean"
+	DataTypeInteger  DataType = "integer"
+	DataTypeLong     DataType = "long"
+	DataTypeFloat    DataType = "float"
+	DataTypeDouble   DataType = "double"
+	DataTypeString   DataType = "string"
+	DataTypeBinary   DataType = "binary"
+	DataTypeDatetime DataType = "datetime"
+	DataTypeArray    DataType = "array"
+)
+
+// ColSpec describes a column of a column-based schema.
+type ColSpec struct {
+	Type DataType
+	// Name is empty for unnamed columns.
+	Name     string
+	Required bool
+	// Items is the type of the elements of array columns.
+	Items *ColSpec
+}
+
+// TensorSpec describes a tensor of a tensor-based schema.
+type TensorSpec struct {
+	Name string `json:"-"`
+	// DType is the numpy data type of the elements, such as "float32" or "int64".
+	DType string `json:"dtype"`
+	// Shape is the shape of the tensor, -1 for variable dimensions.
+	Shape []int64 `json:"shape"`
+}
+
+// Schema is a column-based or tensor-based schema of the inputs or outputs of a model, only
+// one of Columns and Tensors is set.
+type Schema struct {
+	Columns []*ColSpec
+	Tensors []*TensorSpec
+}
+
+// ParamSpec describes an inference parameter of a model.
+type ParamSpec struct {
+	Name    string   `json:"name"`
+	Type    DataType `json:"type"`
+	Default any      `json:"default"`
+	// Shape is nil for scalar parameters and [-1] for lists.
+	Shape []int64 `json:"shape"`
+}
+
+// ModelSignature describes the inputs, outputs and parameters of a model.
+type ModelSignature struct {
+	Inputs  *Schema
+	Outputs *Schema
+	Params  []*ParamSpec
+}
+
+type colSpecJSON struct {
+	Type     DataType     `json:"type"`
+	Items    *colSpecJSON `json:"items,omitempty"`
+	Name     string       `json:"name,omitempty"`
+	Required *bool        `json:"required,omitempty"`
+}
+
+func (c *ColSpec) toJSON(top bool) *colSpecJSON {
+	res := &colSpecJSON{Type: c.Type, Name: c.Name}
+	if c.Items != nil {
+		res.Items = c.Items.toJSON(false)
+	}
+	if top {
+		required := c.Required
+		res.Required = &required
+	}
+	return res
+}
+
+func (c *colSpecJSON) colSpec() *ColSpec {
+	res := &ColSpec{Type: c.Type, Name: c.Name, Required: c.Required == nil || *c.Required}
+	if c.Items != nil {
+		res.Items = c.Items.colSpec()
+	}
+	return res
+}
+
+func (c *ColSpec) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.toJSON(true))
+}
+
+// UnmarshalJSON decodes a column, which is required unless stated otherwise, like in the
+// schemas written by MLflow < 2.10.
+func (c *ColSpec) UnmarshalJSON(b []byte) error {
+	var v colSpecJSON
+	err := json.Unmarshal(b, &v)
+	if err != nil {
+		return err
+	}
+
+	*c = *v.colSpec()
+	return nil
+}
+
+type tensorSpecJSON struct {
+	Type       string      `json:"type"`
+	TensorSpec *TensorSpec `json:"tensor-spec"`
+	Name       string      `json:"name,omitempty"`
+}
+
+// MarshalJSON encodes the schema as MLflow does, as a list of column or tensor specs.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if len(s.Tensors) > 0 {
+		specs := make([]*tensorSpecJSON, len(s.Tensors))
+		for i, t := range s.Tensors {
+			specs[i] = &tensorSpecJSON{Type: "tensor", TensorSpec: t, Name: t.Name}
+		}
+		return json.Marshal(specs)
+	}
+
+	if s.Columns == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s.Columns)
+}
+
+func (s *Schema) UnmarshalJSON(b []byte) error {
+	var specs []json.RawMessage
+	err := json.Unmarshal(b, &specs)
+	if err != nil {
+		return err
+	}
+
+	*s = Schema{}
+	for _, spec := range specs {
+		var t tensorSpecJSON
+		err = json.Unmarshal(spec, &t)
+		if err != nil {
+			return err
+		}
+
+		if t.Type == "tensor" {
+			if t.TensorSpec == nil {
+				return fmt.Errorf("mlflow: tensor spec without tensor-spec")
+			}
+			t.TensorSpec.Name = t.Name
+			s.Tensors = append(s.Tensors, t.TensorSpec)
+			continue
+		}
+
+		var c ColSpec
+		err = json.Unmarshal(spec, &c)
+		if err != nil {
+			return err
+		}
+		s.Columns = append(s.Columns, &c)
+	}
+
+	if len(s.Columns) > 0 && len(s.Tensors) > 0 {
+		return fmt.Errorf("mlflow: schema mixes columns and tensors")
+	}
+
+	return nil
+}
+
+// MLmodelSignature is the signature of an MLmodel file, the schemas are JSON documents.
+type MLmodelSignature struct {
+	Inputs  string `yaml:"inputs,omitempty"`
+	Outputs string `yaml:"outputs,omitempty"`
+	Params  string `yaml:"params,omitempty"`
+}
+
+// MLmodelSignature returns the signature in the format of MLmodel files.
+func (s *ModelSignature) MLmodelSignature() (*MLmodelSignature, error) {
+	var res MLmodelSignature
+
+	for _, v := range []struct {
+		dst *string
+		src any
+		set bool
+	}{
+		{&res.Inputs, s.Inputs, s.Inputs != nil},
+		{&res.Outputs, s.Outputs, s.Outputs != nil},
+		{&res.Params, s.Params, s.Params != nil},
+	} {
+		if !v.set {
+			continue
+		}
+		b, err := json.Marshal(v.src)
+		if err != nil {
+			return nil, err
+		}
+		*v.dst = string(b)
+	}
+
+	return &res, nil
+}
+
+// ModelSignature parses the schemas of the signature.
+func (s *MLmodelSignature) ModelSignature() (*ModelSignature, error) {
+	var res ModelSignature
+
+	if s.Inputs != "" {
+		res.Inputs = &Schema{}
+		err := json.Unmarshal([]byte(s.Inputs), res.Inputs)
+		if err != nil {
+			return nil, fmt.Errorf("mlflow: invalid input schema: %w", err)
+		}
+	}
+	if s.Outputs != "" {
+		res.Outputs = &Schema{}
+		err := json.Unmarshal([]byte(s.Outputs), res.Outputs)
+		if err != nil {
+			return nil, fmt.Errorf("mlflow: invalid output schema: %w", err)
+		}
+	}
+	if s.Params != "" {
+		err := json.Unmarshal([]byte(s.Params), &res.Params)
+		if err != nil {
+			return nil, fmt.Errorf("mlflow: invalid params schema: %w", err)
+		}
+	}
+
+	return &res, nil
+}
+
+// InferSignature infers a signature from example inputs and outputs of a model, see
+// InferSchema. output may be nil for models without output schema.
+func InferSignature(input, output any) (*ModelSignature, error) {
+	var (
+		res ModelSignature
+		err error
+	)
+
+	res.Inputs, err = InferSchema(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if output != nil {
+		res.Outputs, err = InferSchema(output)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &res, nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// InferSchema infers a schema from an example value:
+//   - a struct, or a slice of structs, gives a column per exported field, named after its
+//     json tag or its name; pointer fields are optional,
+//   - a slice, possibly nested, or an array of numbers or booleans gives a tensor whose
+//     first dimension is variable,
+//   - a slice of other scalars, such as strings, gives a single unnamed column.
+func InferSchema(v any) (*Schema, error) {
+	val := reflect.ValueOf(v)
+	if !val.IsValid() {
+		return nil, fmt.Errorf("mlflow: cannot infer a schema from nil")
+	}
+	for val.Kind() == reflect.Pointer && !val.IsNil() {
+		val = val.Elem()
+	}
+	t := val.Type()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if val.Kind() == reflect.Pointer {
+		val = reflect.Zero(t)
+	}
+
+	if t.Kind() == reflect.Struct && t != timeType {
+		return structSchema(t)
+	}
+
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		elem := t.Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		if elem.Kind() == reflect.Struct && elem != timeType {
+			return structSchema(elem)
+		}
+
+		if tensor, ok := inferTensor(val); ok {
+			return &Schema{Tensors: []*TensorSpec{tensor}}, nil
+		}
+
+		col, err := inferColSpec(elem)
+		if err != nil {
+			return nil, err
+		}
+		col.Required = true
+		return &Schema{Columns: []*ColSpec{col}}, nil
+	}
+
+	return nil, fmt.Errorf("mlflow: cannot infer a schema from %s", t)
+}
+
+func structSchema(t reflect.Type) (*Schema, error) {
+	res := &Schema{}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := f.Name
+		if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+
+		ft := f.Type
+		required := true
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+			required = false
+		}
+
+		col, err := inferColSpec(ft)
+		if err != nil {
+			return nil, fmt.Errorf("mlflow: field %s: %w", f.Name, err)
+		}
+		col.Name = name
+		col.Required = required
+		res.Columns = append(res.Columns, col)
+	}
+
+	return res, nil
+}
+
+func inferColSpec(t reflect.Type) (*ColSpec, error) {
+	if t == timeType {
+		return &ColSpec{Type: DataTypeDatetime}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &ColSpec{Type: DataTypeBoolean}, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &ColSpec{Type: DataTypeInteger}, nil
+	case reflect.Int, reflect.Int64, reflect.Uint32:
+		return &ColSpec{Type: DataTypeLong}, nil
+	case reflect.Float32:
+		return &ColSpec{Type: DataTypeFloat}, nil
+	case reflect.Float64:
+		return &ColSpec{Type: DataTypeDouble}, nil
+	case reflect.String:
+		return &ColSpec{Type: DataTypeString}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &ColSpec{Type: DataTypeBinary}, nil
+		}
+		items, err := inferColSpec(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &ColSpec{Type: DataTypeArray, Items: items}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// tensorDTypes maps Go kinds to numpy data types.
+var tensorDTypes = map[reflect.Kind]string{
+	reflect.Bool:    "bool",
+	reflect.Int8:    "int8",
+	reflect.Int16:   "int16",
+	reflect.Int32:   "int32",
+	reflect.Int64:   "int64",
+	reflect.Int:     "int64",
+	reflect.Uint8:   "uint8",
+	reflect.Uint16:  "uint16",
+	reflect.Uint32:  "uint32",
+	reflect.Uint64:  "uint64",
+	reflect.Float32: "float32",
+	reflect.Float64: "float64",
+}
+
+// inferTensor returns the spec of a nested slice or array of numbers or booleans with at least
+// two dimensions, or one of numbers.
+func inferTensor(v reflect.Value) (*TensorSpec, bool) {
+	shape := []int64{-1}
+
+	t := v.Type().Elem()
+	var elem reflect.Value
+	if v.Len() > 0 {
+		elem = v.Index(0)
+	}
+	for t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		switch {
+		case t.Kind() == reflect.Array:
+			shape = append(shape, int64(t.Len()))
+		case elem.IsValid():
+			shape = append(shape, int64(elem.Len()))
+		default:
+			shape = append(shape, -1)
+		}
+
+		if elem.IsValid() && elem.Len() > 0 {
+			elem = elem.Index(0)
+		} else {
+			elem = reflect.Value{}
+		}
+		t = t.Elem()
+	}
+
+	dtype, ok := tensorDTypes[t.Kind()]
+	if !ok || (len(shape) == 1 && t.Kind() == reflect.Bool) {
+		return nil, false
+	}
+
+	return &TensorSpec{DType: dtype, Shape: shape}, true
+}
@@ -0,0 +1,318 @@
+package mlflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldError is an error about a field of a scoring payload.
+type FieldError struct {
+	// Path locates the field, such as "[3].age" for the age column of the fourth record or
+	// "params.temperature".
+	Path    string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// PayloadError lists the fields of a scoring payload which do not match the signature of a
+// model.
+type PayloadError struct {
+	Errors []*FieldError
+}
+
+func (e *PayloadError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return "mlflow: invalid payload: " + strings.Join(msgs, "; ")
+}
+
+// InvocationsPayload validates input and params against the signature and returns the body of
+// a request to the /invocations endpoint of the model server. input is a record, such as a
+// struct or a map, or a slice of records for column-based schemas, and a tensor, or a map
+// of tensors by name, for tensor-based schemas. Values are coerced to the types of the
+// schema when that is lossless, such as the string "42" to an integer column; the fields
+// which cannot be are reported by a *PayloadError.
+func (s *ModelSignature) InvocationsPayload(input any, params map[string]any) (map[string]any, error) {
+	v, err := toJSONValue(input)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &payloadChecker{}
+	body := map[string]any{}
+
+	switch {
+	case s.Inputs == nil:
+		body["inputs"] = v
+	case len(s.Inputs.Tensors) > 0:
+		body["inputs"] = c.tensors(s.Inputs.Tensors, v)
+	default:
+		key, records := c.columns(s.Inputs.Columns, v)
+		body[key] = records
+	}
+
+	if params != nil {
+		p, err := toJSONValue(params)
+		if err != nil {
+			return nil, err
+		}
+		body["params"] = c.params(s.Params, p.(map[string]any))
+	}
+
+	if len(c.errs) > 0 {
+		return nil, &PayloadError{Errors: c.errs}
+	}
+
+	return body, nil
+}
+
+// toJSONValue converts v to the values encoding/json decodes, with numbers as json.Number.
+func toJSONValue(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var res any
+	err = dec.Decode(&res)
+	return res, err
+}
+
+type payloadChecker struct {
+	errs []*FieldError
+}
+
+func (c *payloadChecker) errorf(path, format string, args ...any) {
+	c.errs = append(c.errs, &FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// columns checks records against a column-based schema, returning the key of the payload
+// format and the coerced records.
+func (c *payloadChecker) columns(cols []*ColSpec, v any) (string, any) {
+	records, ok := v.([]any)
+	if !ok {
+		records = []any{v}
+	}
+
+	// A single unnamed column takes a list of values.
+	if len(cols) == 1 && cols[0].Name == "" {
+		for i, r := range records {
+			records[i] = c.value(cols[0], fmt.Sprintf("[%d]", i), r)
+		}
+		return "inputs", records
+	}
+
+	for i, r := range records {
+		path := fmt.Sprintf("[%d]", i)
+		record, ok := r.(map[string]any)
+		if !ok {
+			c.errorf(path, "expected a record, got %s", jsonKind(r))
+			continue
+		}
+
+		for _, col := range cols {
+			value, ok := record[col.Name]
+			if !ok || value == nil {
+				if col.Required {
+					c.errorf(path+"."+col.Name, "missing required column")
+				}
+				continue
+			}
+			record[col.Name] = c.value(col, path+"."+col.Name, value)
+		}
+	}
+
+	return "dataframe_records", records
+}
+
+// value checks and coerces a value of a column.
+func (c *payloadChecker) value(col *ColSpec, path string, v any) any {
+	switch col.Type {
+	case DataTypeInteger, DataTypeLong:
+		n, ok := v.(json.Number)
+		if s, isString := v.(string); isString {
+			n, ok = json.Number(s), true
+		}
+		if ok {
+			i, err := n.Int64()
+			if err != nil {
+				// Floats with an integral value, such as 3.0, are integers.
+				if f, ferr := n.Float64(); ferr == nil && f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+					i, err = int64(f), nil
+				}
+			}
+			if err == nil && (col.Type == DataTypeLong || (i >= math.MinInt32 && i <= math.MaxInt32)) {
+				return i
+			}
+		}
+	case DataTypeFloat, DataTypeDouble:
+		n, ok := v.(json.Number)
+		if s, isString := v.(string); isString {
+			n, ok = json.Number(s), true
+		}
+		if ok {
+			if f, err := n.Float64(); err == nil {
+				return f
+			}
+		}
+	case DataTypeBoolean:
+		switch v := v.(type) {
+		case bool:
+			return v
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b
+			}
+		}
+	case DataTypeString, DataTypeBinary:
+		// Binary values are base64 strings, as []byte values are encoded in JSON.
+		if s, ok := v.(string); ok {
+			return s
+		}
+	case DataTypeDatetime:
+		if s, ok := v.(string); ok {
+			for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+				if _, err := time.Parse(layout, s); err == nil {
+					return s
+				}
+			}
+		}
+	case DataTypeArray:
+		if items, ok := v.([]any); ok {
+			if col.Items == nil {
+				return items
+			}
+			for i, item := range items {
+				items[i] = c.value(col.Items, fmt.Sprintf("%s[%d]", path, i), item)
+			}
+			return items
+		}
+	default:
+		return v
+	}
+
+	c.errorf(path, "expected %s, got %s", col.Type, jsonKind(v))
+	return v
+}
+
+// tensors checks the shapes and data types of tensors.
+func (c *payloadChecker) tensors(specs []*TensorSpec, v any) any {
+	if len(specs) == 1 && specs[0].Name == "" {
+		c.tensor(specs[0], "inputs", v)
+		return v
+	}
+
+	m, ok := v.(map[string]any)
+	if !ok {
+		c.errorf("inputs", "expected tensors by name, got %s", jsonKind(v))
+		return v
+	}
+	for _, spec := range specs {
+		t, ok := m[spec.Name]
+		if !ok {
+			c.errorf("inputs."+spec.Name, "missing tensor")
+			continue
+		}
+		c.tensor(spec, "inputs."+spec.Name, t)
+	}
+
+	return v
+}
+
+func (c *payloadChecker) tensor(spec *TensorSpec, path string, v any) {
+	var check func(path string, dim int, v any)
+	check = func(path string, dim int, v any) {
+		if dim == len(spec.Shape) {
+			switch v.(type) {
+			case json.Number:
+				if strings.HasPrefix(spec.DType, "bool") || strings.HasPrefix(spec.DType, "str") {
+					c.errorf(path, "expected %s, got number", spec.DType)
+				}
+			case bool:
+				if spec.DType != "bool" {
+					c.errorf(path, "expected %s, got boolean", spec.DType)
+				}
+			case string:
+				if !strings.HasPrefix(spec.DType, "str") && spec.DType != "object" {
+					c.errorf(path, "expected %s, got string", spec.DType)
+				}
+			default:
+				c.errorf(path, "expected %s, got %s", spec.DType, jsonKind(v))
+			}
+			return
+		}
+
+		items, ok := v.([]any)
+		if !ok {
+			c.errorf(path, "expected a dimension of %d elements, got %s", spec.Shape[dim], jsonKind(v))
+			return
+		}
+		if spec.Shape[dim] >= 0 && int64(len(items)) != spec.Shape[dim] {
+			c.errorf(path, "expected %d elements, got %d", spec.Shape[dim], len(items))
+			return
+		}
+		for i, item := range items {
+			check(fmt.Sprintf("%s[%d]", path, i), dim+1, item)
+		}
+	}
+
+	check(path, 0, v)
+}
+
+// params checks and coerces inference parameters, unknown parameters are reported as MLflow
+// ignores them.
+func (c *payloadChecker) params(specs []*ParamSpec, params map[string]any) map[string]any {
+	known := map[string]*ParamSpec{}
+	for _, spec := range specs {
+		known[spec.Name] = spec
+	}
+
+	for name, v := range params {
+		path := "params." + name
+		spec, ok := known[name]
+		if !ok {
+			c.errorf(path, "unknown parameter")
+			continue
+		}
+
+		col := &ColSpec{Type: spec.Type}
+		if len(spec.Shape) > 0 {
+			col = &ColSpec{Type: DataTypeArray, Items: col}
+		}
+		params[name] = c.value(col, path, v)
+	}
+
+	return params
+}
+
+// jsonKind describes the kind of a decoded JSON value.
+func jsonKind(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case json.Number:
+		return "number " + v.String()
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return "boolean"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
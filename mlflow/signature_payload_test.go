@@ -0,0 +1,87 @@
+package mlflow_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+func TestInvocationsPayloadIntegers(t *testing.T) {
+	tests := []struct {
+		name  string
+		typ   mlflow.DataType
+		value any
+		want  int64
+		err   bool
+	}{
+		{name: "integer", typ: mlflow.DataTypeInteger, value: 42, want: 42},
+		{name: "integer max", typ: mlflow.DataTypeInteger, value: 2147483647, want: 2147483647},
+		{name: "integer min", typ: mlflow.DataTypeInteger, value: -2147483648, want: -2147483648},
+		{name: "integer above max", typ: mlflow.DataTypeInteger, value: 2147483648, err: true},
+		{name: "integer below min", typ: mlflow.DataTypeInteger, value: -2147483649, err: true},
+		{name: "integer out of range", typ: mlflow.DataTypeInteger, value: 3000000000, err: true},
+		{name: "integer string", typ: mlflow.DataTypeInteger, value: "42", want: 42},
+		{name: "integer integral float", typ: mlflow.DataTypeInteger, value: 3.0, want: 3},
+		{name: "integer integral float out of range", typ: mlflow.DataTypeInteger, value: 3e9, err: true},
+		{name: "integer fractional float", typ: mlflow.DataTypeInteger, value: 3.5, err: true},
+		{name: "long", typ: mlflow.DataTypeLong, value: 3000000000, want: 3000000000},
+		{name: "long max", typ: mlflow.DataTypeLong, value: int64(9223372036854775807), want: 9223372036854775807},
+		{name: "long integral float", typ: mlflow.DataTypeLong, value: 3e9, want: 3000000000},
+		{name: "long float beyond float precision", typ: mlflow.DataTypeLong, value: 1e20, err: true},
+		{name: "long string", typ: mlflow.DataTypeLong, value: "abc", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mlflow.ModelSignature{Inputs: &mlflow.Schema{Columns: []*mlflow.ColSpec{{Type: tt.typ, Name: "x"}}}}
+
+			body, err := s.InvocationsPayload(map[string]any{"x": tt.value}, nil)
+			if tt.err {
+				var perr *mlflow.PayloadError
+				if !errors.As(err, &perr) {
+					t.Fatalf("got error %v, want a *PayloadError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			records := body["dataframe_records"].([]any)
+			got := records[0].(map[string]any)["x"]
+			if got != tt.want {
+				t.Errorf("got %v (%T), want %d", got, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvocationsPayloadErrors(t *testing.T) {
+	s := &mlflow.ModelSignature{
+		Inputs: &mlflow.Schema{Columns: []*mlflow.ColSpec{
+			{Type: mlflow.DataTypeInteger, Name: "age", Required: true},
+			{Type: mlflow.DataTypeString, Name: "name"},
+		}},
+		Params: []*mlflow.ParamSpec{{Name: "temperature", Type: mlflow.DataTypeDouble}},
+	}
+
+	input := []map[string]any{{"age": 3000000000, "name": "a"}, {"name": 1}}
+	_, err := s.InvocationsPayload(input, map[string]any{"temperature": "hot", "top_k": 3})
+
+	var perr *mlflow.PayloadError
+	if !errors.As(err, &perr) {
+		t.Fatalf("got error %v, want a *PayloadError", err)
+	}
+	got := map[string]bool{}
+	for _, e := range perr.Errors {
+		got[e.Path] = true
+	}
+	for _, path := range []string{"[0].age", "[1].age", "[1].name", "params.temperature", "params.top_k"} {
+		if !got[path] {
+			b, _ := json.Marshal(perr.Errors)
+			t.Errorf("no error for %s in %s", path, b)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package mlflow
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+type TracesService service
+
+type TraceStatus string
+
+const (
+	TraceStatusUnspecified TraceStatus = "TRACE_STATUS_UNSPECIFIED"
+	TraceStatusOK          TraceStatus = "OK"
+	TraceStatusError       TraceStatus = "ERROR"
+	TraceStatusInProgress  TraceStatus = "IN_PROGRESS"
+)
+
+// Trace tags and request metadata set by MLflow.
+const (
+	// TraceTagArtifactLocation is the location of the artifacts of a trace, set by the server.
+	TraceTagArtifactLocation = "mlflow.artifactLocation"
+	TraceTagName             = "mlflow.traceName"
+	TraceMetadataInputs      = "mlflow.traceInputs"
+	TraceMetadataOutputs     = "mlflow.traceOutputs"
+	TraceMetadataSourceRun   = "mlflow.sourceRun"
+)
+
+type TraceInfo struct {
+	RequestID       string                  `json:"request_id,omitempty"`
+	ExperimentID    string                  `json:"experiment_id,omitempty"`
+	TimestampMs     int64                   `json:"timestamp_ms,omitempty"`
+	ExecutionTimeMs int64                   `json:"execution_time_ms,omitempty"`
+	Status          TraceStatus             `json:"status,omitempty"`
+	RequestMetadata []*TraceRequestMetadata `json:"request_metadata,omitempty"`
+	Tags            []*TraceTag             `json:"tags,omitempty"`
+}
+
+type TraceRequestMetadata struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type TraceTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Tag returns the value of a tag of the trace, empty if it has no such tag.
+func (i *TraceInfo) Tag(key string) string {
+	for _, t := range i.Tags {
+		if t.Key == key {
+			return t.Value
+		}
+	}
+	return ""
+}
+
+// Metadata returns the value of a request metadata of the trace, empty if it has no such
+// metadata.
+func (i *TraceInfo) Metadata(key string) string {
+	for _, m := range i.RequestMetadata {
+		if m.Key == key {
+			return m.Value
+		}
+	}
+	return ""
+}
+
+// TracesSearchOptions filters and orders the traces of experiments. Filter is a search
+// expression over the attributes, tags and request metadata of traces, such as
+// "attributes.status = 'ERROR' AND attributes.execution_time_ms > 1000" or
+// "tags.environment = 'production'"; OrderBy lists attributes such as
+// "timestamp_ms DESC".
+type TracesSearchOptions struct {
+	ExperimentIDs []string
+	Filter        string
+	MaxResults    int64
+	OrderBy       []string
+	PageToken     string
+}
+
+type TracesSearchResults struct {
+	Traces        []*TraceInfo `json:"traces,omitempty"`
+	NextPageToken string       `json:"next_page_token,omitempty"`
+}
+
+// Start starts a trace in an experiment at timestampMs, in milliseconds since the epoch. The
+// returned trace info has the request ID of the trace, to end it with End, and the location
+// of its artifacts, see UploadData.
+func (s *TracesService) Start(ctx context.Context, experimentID string, timestampMs int64, requestMetadata, tags map[string]string) (*TraceInfo, error) {
+	opts := struct {
+		ExperimentID    string                  `json:"experiment_id,omitempty"`
+		TimestampMs     int64                   `json:"timestamp_ms,omitempty"`
+		RequestMetadata []*TraceRequestMetadata `json:"request_metadata,omitempty"`
+		Tags            []*TraceTag             `json:"tags,omitempty"`
+	}{
+		ExperimentID: experimentID,
+		TimestampMs:  timestampMs,
+	}
+	for key, value := range requestMetadata {
+		opts.RequestMetadata = append(opts.RequestMetadata, &TraceRequestMetadata{Key: key, Value: value})
+	}
+	for key, value := range tags {
+		opts.Tags = append(opts.Tags, &TraceTag{Key: key, Value: value})
+	}
+
+	var res struct {
+		TraceInfo *TraceInfo `json:"trace_info,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "POST", "traces", nil, &opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.TraceInfo, nil
+}
+
+// End ends a trace at timestampMs, in milliseconds since the epoch, with the given status,
+// adding the request metadata and tags.
+func (s *TracesService) End(ctx context.Context, requestID string, timestampMs int64, status TraceStatus, requestMetadata, tags map[string]string) (*TraceInfo, error) {
+	opts := struct {
+		RequestID       string                  `json:"request_id,omitempty"`
+		TimestampMs     int64                   `json:"timestamp_ms,omitempty"`
+		Status          TraceStatus             `json:"status,omitempty"`
+		RequestMetadata []*TraceRequestMetadata `json:"request_metadata,omitempty"`
+		Tags            []*TraceTag             `json:"tags,omitempty"`
+	}{
+		RequestID:   requestID,
+		TimestampMs: timestampMs,
+		Status:      status,
+	}
+	for key, value := range requestMetadata {
+		opts.RequestMetadata = append(opts.RequestMetadata, &TraceRequestMetadata{Key: key, Value: value})
+	}
+	for key, value := range tags {
+		opts.Tags = append(opts.Tags, &TraceTag{Key: key, Value: value})
+	}
+
+	var res struct {
+		TraceInfo *TraceInfo `json:"trace_info,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "PATCH", "traces/"+url.PathEscape(requestID), nil, &opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.TraceInfo, nil
+}
+
+func (s *TracesService) GetInfo(ctx context.Context, requestID string) (*TraceInfo, error) {
+	var res struct {
+		TraceInfo *TraceInfo `json:"trace_info,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "GET", "traces/"+url.PathEscape(requestID)+"/info", nil, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.TraceInfo, nil
+}
+
+func (s *TracesService) SetTag(ctx context.Context, requestID, key, value string) error {
+	opts := struct {
+		Key   string `json:"key,omitempty"`
+		Value string `json:"value,omitempty"`
+	}{
+		Key:   key,
+		Value: value,
+	}
+
+	_, err := s.client.Do(ctx, "PATCH", "traces/"+url.PathEscape(requestID)+"/tags", nil, &opts, nil)
+	return err
+}
+
+func (s *TracesService) DeleteTag(ctx context.Context, requestID, key string) error {
+	opts := struct {
+		Key string `json:"key,omitempty"`
+	}{
+		Key: key,
+	}
+
+	_, err := s.client.Do(ctx, "DELETE", "traces/"+url.PathEscape(requestID)+"/tags", nil, &opts, nil)
+	return err
+}
+
+func (s *TracesService) Search(ctx context.Context, opts *TracesSearchOptions) (*TracesSearchResults, error) {
+	var res TracesSearchResults
+
+	params := url.Values{}
+	if opts != nil {
+		for _, id := range opts.ExperimentIDs {
+			params.Add("experiment_ids", id)
+		}
+		if opts.Filter != "" {
+			params.Set("filter", opts.Filter)
+		}
+		if opts.MaxResults > 0 {
+			params.Set("max_results", strconv.FormatInt(opts.MaxResults, 10))
+		}
+		for _, orderBy := range opts.OrderBy {
+			params.Add("order_by", orderBy)
+		}
+		if opts.PageToken != "" {
+			params.Set("page_token", opts.PageToken)
+		}
+	}
+
+	_, err := s.client.Do(ctx, "GET", "traces", params, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *TracesService) Iterate(ctx context.Context, opts *TracesSearchOptions) *Iterator[*TraceInfo] {
+	if opts == nil {
+		opts = &TracesSearchOptions{}
+	}
+	o := *opts
+
+	return newIterator(ctx, o.PageToken, func(ctx context.Context, pageToken string) ([]*TraceInfo, string, error) {
+		o.PageToken = pageToken
+
+		res, err := s.Search(ctx, &o)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return res.Traces, res.NextPageToken, nil
+	})
+}
+
+func (s *TracesService) SearchAll(ctx context.Context, opts *TracesSearchOptions) ([]*TraceInfo, error) {
+	return s.Iterate(ctx, opts).All()
+}
+
+// Delete deletes the traces of an experiment with the given request IDs.
+func (s *TracesService) Delete(ctx context.Context, experimentID string, requestIDs []string) (int, error) {
+	opts := struct {
+		ExperimentID string   `json:"experiment_id,omitempty"`
+		RequestIDs   []string `json:"request_ids,omitempty"`
+	}{
+		ExperimentID: experimentID,
+		RequestIDs:   requestIDs,
+	}
+
+	var res struct {
+		TracesDeleted int `json:"traces_deleted,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "POST", "traces/delete-traces", nil, &opts, &res)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.TracesDeleted, nil
+}
@@ -0,0 +1,154 @@
+package mlflow
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Assessments are a feature of MLflow 3 servers, under the 3.0 API.
+
+type AssessmentSourceType string
+
+const (
+	AssessmentSourceHuman    AssessmentSourceType = "HUMAN"
+	AssessmentSourceLLMJudge AssessmentSourceType = "LLM_JUDGE"
+	AssessmentSourceCode     AssessmentSourceType = "CODE"
+)
+
+// AssessmentSource is the producer of an assessment, such as a user or an LLM judge.
+type AssessmentSource struct {
+	SourceType AssessmentSourceType `json:"source_type,omitempty"`
+	// SourceID identifies the source, such as a username or the name of a judge.
+	SourceID string `json:"source_id,omitempty"`
+}
+
+// Assessment is a feedback on a trace or one of its spans, such as a human rating or the
+// verdict of an LLM judge, or an expectation, such as the expected output. Only one of
+// Feedback and Expectation is set.
+type Assessment struct {
+	AssessmentID   string            `json:"assessment_id,omitempty"`
+	AssessmentName string            `json:"assessment_name,omitempty"`
+	TraceID        string            `json:"trace_id,omitempty"`
+	SpanID         string            `json:"span_id,omitempty"`
+	Source         *AssessmentSource `json:"source,omitempty"`
+	CreateTime     *time.Time        `json:"create_time,omitempty"`
+	LastUpdateTime *time.Time        `json:"last_update_time,omitempty"`
+	Feedback       *Feedback         `json:"feedback,omitempty"`
+	Expectation    *Expectation      `json:"expectation,omitempty"`
+	Rationale      string            `json:"rationale,omitempty"`
+	Metadata       map[string]string `json:"metadata,omitempty"`
+	// Overrides is the ID of the assessment this one replaces, which is then invalid.
+	Overrides string `json:"overrides,omitempty"`
+	Valid     *bool  `json:"valid,omitempty"`
+}
+
+type Feedback struct {
+	// Value is a JSON value, such as a score, a boolean or a label.
+	Value any `json:"value"`
+	// Error is set for feedbacks which could not be computed, such as a failed LLM judge.
+	Error *AssessmentError `json:"error,omitempty"`
+}
+
+type AssessmentError struct {
+	ErrorCode    string `json:"error_code,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+type Expectation struct {
+	// Value is a JSON value, such as the expected output of the trace.
+	Value any `json:"value"`
+}
+
+// assessmentsPath returns the path of the assessments of a trace, or of one of them, on the
+// 3.0 API.
+func assessmentsPath(traceID, assessmentID string) string {
+	p := "../../3.0/mlflow/traces/" + url.PathEscape(traceID) + "/assessments"
+	if assessmentID != "" {
+		p += "/" + url.PathEscape(assessmentID)
+	}
+	return p
+}
+
+// CreateAssessment logs an assessment on the trace of a.TraceID.
+func (s *TracesService) CreateAssessment(ctx context.Context, a *Assessment) (*Assessment, error) {
+	opts := struct {
+		Assessment *Assessment `json:"assessment,omitempty"`
+	}{
+		Assessment: a,
+	}
+
+	var res struct {
+		Assessment *Assessment `json:"assessment,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "POST", assessmentsPath(a.TraceID, ""), nil, &opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Assessment, nil
+}
+
+// LogFeedback logs a feedback on a trace, see CreateAssessment.
+func (s *TracesService) LogFeedback(ctx context.Context, traceID, name string, value any, source *AssessmentSource, rationale string) (*Assessment, error) {
+	return s.CreateAssessment(ctx, &Assessment{
+		AssessmentName: name,
+		TraceID:        traceID,
+		Source:         source,
+		Feedback:       &Feedback{Value: value},
+		Rationale:      rationale,
+	})
+}
+
+// LogExpectation logs an expectation on a trace, see CreateAssessment.
+func (s *TracesService) LogExpectation(ctx context.Context, traceID, name string, value any, source *AssessmentSource) (*Assessment, error) {
+	return s.CreateAssessment(ctx, &Assessment{
+		AssessmentName: name,
+		TraceID:        traceID,
+		Source:         source,
+		Expectation:    &Expectation{Value: value},
+	})
+}
+
+func (s *TracesService) GetAssessment(ctx context.Context, traceID, assessmentID string) (*Assessment, error) {
+	var res struct {
+		Assessment *Assessment `json:"assessment,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "GET", assessmentsPath(traceID, assessmentID), nil, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Assessment, nil
+}
+
+// UpdateAssessment updates the fields of the assessment a.AssessmentID listed in fields, the
+// JSON names of the fields of Assessment, such as "feedback" or "rationale".
+func (s *TracesService) UpdateAssessment(ctx context.Context, a *Assessment, fields []string) (*Assessment, error) {
+	opts := struct {
+		Assessment *Assessment `json:"assessment,omitempty"`
+		UpdateMask string      `json:"update_mask,omitempty"`
+	}{
+		Assessment: a,
+		UpdateMask: strings.Join(fields, ","),
+	}
+
+	var res struct {
+		Assessment *Assessment `json:"assessment,omitempty"`
+	}
+
+	_, err := s.client.Do(ctx, "PATCH", assessmentsPath(a.TraceID, a.AssessmentID), nil, &opts, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Assessment, nil
+}
+
+func (s *TracesService) DeleteAssessment(ctx context.Context, traceID, assessmentID string) error {
+	_, err := s.client.Do(ctx, "DELETE", assessmentsPath(traceID, assessmentID), nil, nil, nil)
+	return err
+}
@@ -0,0 +1,117 @@
+package mlflow
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Span attributes and trace metadata of chat models, which the MLflow UI renders as
+// conversations and token counts.
+const (
+	SpanAttributeChatMessages   = "mlflow.chat.messages"
+	SpanAttributeChatTokenUsage = "mlflow.chat.tokenUsage"
+	TraceMetadataTokenUsage     = "mlflow.trace.tokenUsage"
+)
+
+// ChatMessage is a message of a conversation with a chat model, in the OpenAI format.
+type ChatMessage struct {
+	// Role is the author of the message, such as "system", "user" or "assistant".
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type TokenUsage struct {
+	InputTokens  int64 `json:"input_tokens"`
+	OutputTokens int64 `json:"output_tokens"`
+	TotalTokens  int64 `json:"total_tokens"`
+}
+
+// ChatCompletion is a request to a chat model and its response.
+type ChatCompletion struct {
+	Model string
+	// Messages are the messages of the prompt.
+	Messages []*ChatMessage
+	Response *ChatMessage
+	// Usage is the token usage of the request, its total is computed if zero.
+	Usage *TokenUsage
+	// StartTime is the start of the request, Latency its duration. If StartTime is zero,
+	// the request is taken to end when it is logged.
+	StartTime time.Time
+	Latency   time.Duration
+	// Err is the error of the request, if it failed.
+	Err error
+	// Attributes are additional attributes of the span, such as the parameters of the
+	// request.
+	Attributes map[string]any
+}
+
+// LogChat records a chat completion as a trace of an experiment, with a chat model span the
+// MLflow UI displays as a conversation, see Log.
+func (s *TracesService) LogChat(ctx context.Context, experimentID string, c *ChatCompletion) (*TraceInfo, error) {
+	name := c.Model
+	if name == "" {
+		name = "chat"
+	}
+
+	messages := c.Messages
+	inputs := map[string]any{"messages": messages}
+	if c.Model != "" {
+		inputs["model"] = c.Model
+	}
+
+	start := c.StartTime
+	if start.IsZero() {
+		start = time.Now().Add(-c.Latency)
+	}
+
+	span := &Span{
+		Name:       name,
+		Context:    NewSpanContext(""),
+		StartTime:  start.UnixNano(),
+		EndTime:    start.Add(c.Latency).UnixNano(),
+		StatusCode: SpanStatusOK,
+		Attributes: map[string]any{
+			SpanAttributeType:   SpanTypeChatModel,
+			SpanAttributeInputs: inputs,
+		},
+	}
+	for key, value := range c.Attributes {
+		span.Attributes[key] = value
+	}
+
+	if c.Response != nil {
+		messages = append(messages[:len(messages):len(messages)], c.Response)
+		span.Attributes[SpanAttributeOutputs] = map[string]any{
+			"choices": []any{map[string]any{"index": 0, "message": c.Response}},
+		}
+	}
+	span.Attributes[SpanAttributeChatMessages] = messages
+
+	if c.Err != nil {
+		span.StatusCode = SpanStatusError
+		span.StatusMessage = c.Err.Error()
+		span.Events = []*SpanEvent{{
+			Name:       "exception",
+			Timestamp:  span.EndTime,
+			Attributes: map[string]any{"exception.message": c.Err.Error()},
+		}}
+	}
+
+	var metadata map[string]string
+	if c.Usage != nil {
+		usage := *c.Usage
+		if usage.TotalTokens == 0 {
+			usage.TotalTokens = usage.InputTokens + usage.OutputTokens
+		}
+		span.Attributes[SpanAttributeChatTokenUsage] = &usage
+
+		b, err := json.Marshal(&usage)
+		if err != nil {
+			return nil, err
+		}
+		metadata = map[string]string{TraceMetadataTokenUsage: string(b)}
+	}
+
+	return s.log(ctx, experimentID, &TraceData{Spans: []*Span{span}}, metadata)
+}
@@ -0,0 +1,299 @@
+package mlflow
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// TraceDataFile is the artifact holding the spans of a trace.
+const TraceDataFile = "traces.json"
+
+type SpanType string
+
+const (
+	SpanTypeLLM       SpanType = "LLM"
+	SpanTypeChain     SpanType = "CHAIN"
+	SpanTypeAgent     SpanType = "AGENT"
+	SpanTypeTool      SpanType = "TOOL"
+	SpanTypeChatModel SpanType = "CHAT_MODEL"
+	SpanTypeRetriever SpanType = "RETRIEVER"
+	SpanTypeParser    SpanType = "PARSER"
+	SpanTypeEmbedding SpanType = "EMBEDDING"
+	SpanTypeReranker  SpanType = "RERANKER"
+	SpanTypeUnknown   SpanType = "UNKNOWN"
+)
+
+type SpanStatusCode string
+
+const (
+	SpanStatusUnset SpanStatusCode = "UNSET"
+	SpanStatusOK    SpanStatusCode = "OK"
+	SpanStatusError SpanStatusCode = "ERROR"
+)
+
+// Span attributes used by MLflow.
+const (
+	SpanAttributeRequestID = "mlflow.traceRequestId"
+	SpanAttributeType      = "mlflow.spanType"
+	SpanAttributeInputs    = "mlflow.spanInputs"
+	SpanAttributeOutputs   = "mlflow.spanOutputs"
+)
+
+// TraceData is the content of the TraceDataFile artifact of a trace.
+type TraceData struct {
+	Spans []*Span `json:"spans"`
+}
+
+// Span is an operation of a trace, such as a call to an LLM.
+type Span struct {
+	Name    string      `json:"name"`
+	Context SpanContext `json:"context"`
+	// ParentID is the span ID of the parent of the span, empty for the root span.
+	ParentID string `json:"parent_id"`
+	// StartTime and EndTime are in nanoseconds since the epoch.
+	StartTime     int64          `json:"start_time"`
+	EndTime       int64          `json:"end_time"`
+	StatusCode    SpanStatusCode `json:"status_code"`
+	StatusMessage string         `json:"status_message"`
+	// Attributes are encoded in JSON, see SpanAttributeType, SpanAttributeInputs and
+	// SpanAttributeOutputs.
+	Attributes map[string]any `json:"attributes"`
+	Events     []*SpanEvent   `json:"events"`
+}
+
+// SpanContext identifies a span, the IDs are hexadecimal with a 0x prefix, see NewSpanContext.
+type SpanContext struct {
+	SpanID  string `json:"span_id"`
+	TraceID string `json:"trace_id"`
+}
+
+// SpanEvent is an event of a span, such as an exception.
+type SpanEvent struct {
+	Name string `json:"name"`
+	// Timestamp is in nanoseconds since the epoch.
+	Timestamp  int64          `json:"timestamp"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+// NewSpanContext returns the context of a new span of the trace with the given ID, a new
+// trace if traceID is empty.
+func NewSpanContext(traceID string) SpanContext {
+	if traceID == "" {
+		traceID = randomHexID(16)
+	}
+	return SpanContext{SpanID: randomHexID(8), TraceID: traceID}
+}
+
+func randomHexID(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return "0x" + hex.EncodeToString(b)
+}
+
+type spanJSON struct {
+	Name          string            `json:"name"`
+	Context       SpanContext       `json:"context"`
+	ParentID      *string           `json:"parent_id"`
+	StartTime     int64             `json:"start_time"`
+	EndTime       int64             `json:"end_time"`
+	StatusCode    SpanStatusCode    `json:"status_code"`
+	StatusMessage string            `json:"status_message"`
+	Attributes    map[string]string `json:"attributes"`
+	Events        []*SpanEvent      `json:"events"`
+}
+
+// MarshalJSON encodes the span as MLflow does, with JSON encoded attribute values.
+func (s *Span) MarshalJSON() ([]byte, error) {
+	v := spanJSON{
+		Name:          s.Name,
+		Context:       s.Context,
+		StartTime:     s.StartTime,
+		EndTime:       s.EndTime,
+		StatusCode:    s.StatusCode,
+		StatusMessage: s.StatusMessage,
+		Attributes:    map[string]string{},
+		Events:        s.Events,
+	}
+	if s.ParentID != "" {
+		v.ParentID = &s.ParentID
+	}
+	if v.StatusCode == "" {
+		v.StatusCode = SpanStatusUnset
+	}
+	if v.Events == nil {
+		v.Events = []*SpanEvent{}
+	}
+
+	for key, value := range s.Attributes {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("mlflow: span attribute %s: %w", key, err)
+		}
+		v.Attributes[key] = string(b)
+	}
+
+	return json.Marshal(&v)
+}
+
+func (s *Span) UnmarshalJSON(b []byte) error {
+	var v spanJSON
+	err := json.Unmarshal(b, &v)
+	if err != nil {
+		return err
+	}
+
+	*s = Span{
+		Name:          v.Name,
+		Context:       v.Context,
+		StartTime:     v.StartTime,
+		EndTime:       v.EndTime,
+		StatusCode:    v.StatusCode,
+		StatusMessage: v.StatusMessage,
+		Attributes:    map[string]any{},
+		Events:        v.Events,
+	}
+	if v.ParentID != nil {
+		s.ParentID = *v.ParentID
+	}
+
+	for key, value := range v.Attributes {
+		var a any
+		if json.Unmarshal([]byte(value), &a) != nil {
+			// Not encoded by MLflow, keep the raw value.
+			a = value
+		}
+		s.Attributes[key] = a
+	}
+
+	return nil
+}
+
+// Root returns the root span of the trace, nil if it has none.
+func (d *TraceData) Root() *Span {
+	for _, s := range d.Spans {
+		if s.ParentID == "" {
+			return s
+		}
+	}
+	return nil
+}
+
+// UploadData uploads the spans of a trace to the location of its artifacts.
+func (s *TracesService) UploadData(ctx context.Context, info *TraceInfo, data *TraceData) error {
+	location := info.Tag(TraceTagArtifactLocation)
+	if location == "" {
+		return fmt.Errorf("mlflow: trace %s has no artifact location", info.RequestID)
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Artifacts.upload(ctx, location, TraceDataFile, bytes.NewReader(b))
+}
+
+// GetData downloads the spans of a trace.
+func (s *TracesService) GetData(ctx context.Context, info *TraceInfo) (*TraceData, error) {
+	location := info.Tag(TraceTagArtifactLocation)
+	if location == "" {
+		return nil, fmt.Errorf("mlflow: trace %s has no artifact location", info.RequestID)
+	}
+
+	var buf bytes.Buffer
+	err := s.client.Artifacts.download(ctx, location, TraceDataFile, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	var data TraceData
+	err = json.Unmarshal(buf.Bytes(), &data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// traceMetadataMaxLength is the length MLflow truncates the inputs and outputs previews of
+// the request metadata of traces to.
+const traceMetadataMaxLength = 250
+
+// Log records a completed trace in an experiment: it starts the trace at the start of the
+// root span of data, uploads the spans, tagged with the request ID of the trace, and ends the
+// trace at the end of the root span, with its status and the previews of its inputs and
+// outputs the UI displays.
+func (s *TracesService) Log(ctx context.Context, experimentID string, data *TraceData) (*TraceInfo, error) {
+	return s.log(ctx, experimentID, data, nil)
+}
+
+// LogForRun records a completed trace in the experiment of a run, linked to the run, see Log.
+func (s *TracesService) LogForRun(ctx context.Context, run *RunInfo, data *TraceData) (*TraceInfo, error) {
+	return s.log(ctx, run.ExperimentID, data, map[string]string{TraceMetadataSourceRun: run.RunID})
+}
+
+func (s *TracesService) log(ctx context.Context, experimentID string, data *TraceData, requestMetadata map[string]string) (*TraceInfo, error) {
+	root := data.Root()
+	if root == nil {
+		return nil, errors.New("mlflow: trace has no root span")
+	}
+
+	info, err := s.Start(ctx, experimentID, root.StartTime/1e6, requestMetadata, map[string]string{TraceTagName: root.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	// The spans are tagged with the request ID on copies, leaving those of data unchanged.
+	uploaded := &TraceData{Spans: make([]*Span, len(data.Spans))}
+	for i, span := range data.Spans {
+		c := *span
+		c.Attributes = make(map[string]any, len(span.Attributes)+1)
+		for key, value := range span.Attributes {
+			c.Attributes[key] = value
+		}
+		c.Attributes[SpanAttributeRequestID] = info.RequestID
+		uploaded.Spans[i] = &c
+	}
+
+	err = s.UploadData(ctx, info, uploaded)
+	if err != nil {
+		// End the trace, which would otherwise stay in progress.
+		_, endErr := s.End(ctx, info.RequestID, root.EndTime/1e6, TraceStatusError, nil, nil)
+		if endErr != nil {
+			return nil, fmt.Errorf("%w (ending the trace: %v)", err, endErr)
+		}
+		return nil, err
+	}
+
+	metadata := map[string]string{}
+	for key, attribute := range map[string]string{TraceMetadataInputs: SpanAttributeInputs, TraceMetadataOutputs: SpanAttributeOutputs} {
+		if v, ok := root.Attributes[attribute]; ok {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			metadata[key] = truncate(string(b), traceMetadataMaxLength)
+		}
+	}
+
+	status := TraceStatusOK
+	if root.StatusCode == SpanStatusError {
+		status = TraceStatusError
+	}
+
+	return s.End(ctx, info.RequestID, root.EndTime/1e6, status, metadata, nil)
+}
+
+// truncate returns the first n runes of s.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
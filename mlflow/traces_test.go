@@ -0,0 +1,181 @@
+package mlflow_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// traceServer is a tracking server recording the trace requests.
+type traceServer struct {
+	failUpload bool
+
+	mu       sync.Mutex
+	uploaded *mlflow.TraceData
+	status   mlflow.TraceStatus
+	bodies   map[string]string
+}
+
+func (s *traceServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, _ := io.ReadAll(r.Body)
+	request := r.Method + " " + r.URL.Path
+	if s.bodies == nil {
+		s.bodies = map[string]string{}
+	}
+	s.bodies[request] = string(b)
+	if r.URL.RawQuery != "" {
+		s.bodies[request] += "?" + r.URL.RawQuery
+	}
+
+	info := &mlflow.TraceInfo{
+		RequestID:    "tr-1",
+		ExperimentID: "0",
+		Status:       mlflow.TraceStatusInProgress,
+		Tags:         []*mlflow.TraceTag{{Key: mlflow.TraceTagArtifactLocation, Value: "mlflow-artifacts:/0/traces/tr-1/artifacts"}},
+	}
+	switch request {
+	case "POST /api/2.0/mlflow/traces":
+	case "PUT /api/2.0/mlflow-artifacts/artifacts/0/traces/tr-1/artifacts/traces.json":
+		if s.failUpload {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error_code": "INTERNAL_ERROR", "message": "disk full"}`))
+			return
+		}
+		s.uploaded = &mlflow.TraceData{}
+		_ = json.Unmarshal(b, s.uploaded)
+	case "PATCH /api/2.0/mlflow/traces/tr-1":
+		var req struct {
+			Status mlflow.TraceStatus `json:"status"`
+		}
+		_ = json.Unmarshal(b, &req)
+		s.status = req.Status
+		info.Status = req.Status
+	case "DELETE /api/2.0/mlflow/traces/tr-1/tags":
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code": "ENDPOINT_NOT_FOUND"}`))
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"trace_info": info})
+}
+
+func newTraceClient(t *testing.T, s *traceServer) *mlflow.Client {
+	t.Helper()
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	client, err := mlflow.NewClient(srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func testTraceData() *mlflow.TraceData {
+	root := mlflow.NewSpanContext("")
+	return &mlflow.TraceData{Spans: []*mlflow.Span{
+		{
+			Name:       "predict",
+			Context:    root,
+			StartTime:  1e15,
+			EndTime:    2e15,
+			StatusCode: mlflow.SpanStatusOK,
+			Attributes: map[string]any{mlflow.SpanAttributeInputs: "question"},
+		},
+		{
+			Name:      "retrieve",
+			Context:   mlflow.NewSpanContext(root.TraceID),
+			ParentID:  root.SpanID,
+			StartTime: 1e15,
+			EndTime:   2e15,
+		},
+	}}
+}
+
+func TestTracesDeleteTagSendsBody(t *testing.T) {
+	s := &traceServer{}
+	client := newTraceClient(t, s)
+
+	err := client.Traces.DeleteTag(context.Background(), "tr-1", "team")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := s.bodies["DELETE /api/2.0/mlflow/traces/tr-1/tags"]
+	if got != `{"key":"team"}` {
+		t.Errorf("got request %q, want the key in the JSON body", got)
+	}
+}
+
+func TestTracesLogDoesNotModifySpans(t *testing.T) {
+	s := &traceServer{}
+	client := newTraceClient(t, s)
+	data := testTraceData()
+
+	_, err := client.Traces.Log(context.Background(), "0", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := data.Spans[0].Attributes[mlflow.SpanAttributeRequestID]; ok {
+		t.Error("the attributes of the root span were modified")
+	}
+	if data.Spans[1].Attributes != nil {
+		t.Error("the attributes of the child span were set")
+	}
+	for _, span := range s.uploaded.Spans {
+		if span.Attributes[mlflow.SpanAttributeRequestID] != "tr-1" {
+			t.Errorf("span %s uploaded without request ID", span.Name)
+		}
+	}
+	if s.status != mlflow.TraceStatusOK {
+		t.Errorf("trace ended with status %s, want OK", s.status)
+	}
+}
+
+func TestTracesLogEndsTraceOnUploadError(t *testing.T) {
+	s := &traceServer{failUpload: true}
+	client := newTraceClient(t, s)
+
+	_, err := client.Traces.Log(context.Background(), "0", testTraceData())
+	if err == nil {
+		t.Fatal("no error")
+	}
+	if s.status != mlflow.TraceStatusError {
+		t.Errorf("trace ended with status %q, want ERROR", s.status)
+	}
+}
+
+func TestTracesLogChatDefaultsStartTime(t *testing.T) {
+	s := &traceServer{}
+	client := newTraceClient(t, s)
+
+	before := time.Now()
+	_, err := client.Traces.LogChat(context.Background(), "0", &mlflow.ChatCompletion{
+		Model:    "gpt",
+		Messages: []*mlflow.ChatMessage{{Role: "user", Content: "hello"}},
+		Latency:  2 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now()
+
+	span := s.uploaded.Spans[0]
+	start := time.Unix(0, span.StartTime)
+	if start.Before(before.Add(-2*time.Second)) || start.After(after.Add(-2*time.Second)) {
+		t.Errorf("got start time %v, want the latency before the call, between %v and %v", start, before, after)
+	}
+	if d := time.Duration(span.EndTime - span.StartTime); d != 2*time.Second {
+		t.Errorf("got span duration %v, want 2s", d)
+	}
+}
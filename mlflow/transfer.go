@@ -0,0 +1,351 @@
+package mlflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codeocean/go-mlflow/internal/localpath"
+)
+
+// TransferOptions configures the transfer of artifact trees.
+type TransferOptions struct {
+	// Workers is the number of files transferred concurrently. Defaults to 8.
+	Workers int
+	// Retries is the number of times the transfer of a file is retried after a
+	// transient failure.
+	Retries int
+	// Progress, if set, is called as data is transferred, at most every 100ms, and each
+	// time the transfer of a file completes. Calls are serialized.
+	Progress func(TransferProgress)
+}
+
+// TransferProgress describes the progress of an artifact tree transfer.
+type TransferProgress struct {
+	// Path is the artifact path of the file whose transfer completed, empty when the
+	// progress is reported while files are being transferred.
+	Path       string
+	FilesDone  int
+	FilesTotal int
+	BytesDone  int64
+	// BytesTotal is the total size of the files, -1 if the size of some files is unknown.
+	BytesTotal int64
+	// Rate is the average transfer rate since the start of the transfer, in bytes per second.
+	Rate float64
+}
+
+// progressInterval is the minimum interval between progress reports of a transfer.
+const progressInterval = 100 * time.Millisecond
+
+// DownloadTree downloads the artifacts of the run below path into localDir, transferring
+// several files concurrently.
+func (s *ArtifactsService) DownloadTree(ctx context.Context, runID, path, localDir string, opts *TransferOptions) error {
+	run, err := s.client.Runs.Get(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	files, err := s.listFiles(ctx, runID, path)
+	if err != nil {
+		return err
+	}
+
+	return s.downloadFiles(ctx, run.Info.ArtifactUri, path, files, localDir, opts)
+}
+
+// UploadTree uploads the files below localDir to path, relative to the artifact root of the
+// run, transferring several files concurrently.
+func (s *ArtifactsService) UploadTree(ctx context.Context, runID, localDir, path string, opts *TransferOptions) error {
+	run, err := s.client.Runs.Get(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	files, err := listLocalFiles(localDir)
+	if err != nil {
+		return err
+	}
+
+	return s.uploadFiles(ctx, run.Info.ArtifactUri, localDir, files, path, opts)
+}
+
+// downloadFiles downloads artifacts below the directory path into localDir.
+func (s *ArtifactsService) downloadFiles(ctx context.Context, artifactURI, path string, files []*FileInfo, localDir string, opts *TransferOptions) error {
+	sizes := make([]int64, len(files))
+	for i, f := range files {
+		sizes[i] = expectedSize(f)
+	}
+
+	t := newTransfer(opts, sizes)
+	return t.run(ctx, func(ctx context.Context, i int, progress func(int64)) (string, error) {
+		f := files[i]
+		localPath, err := localArtifactPath(localDir, relativeArtifactPath(path, f.Path))
+		if err != nil {
+			return f.Path, err
+		}
+		return f.Path, s.downloadCachedFile(ctx, artifactURI, f.Path, localPath, sizes[i], f.ContentHash, progress)
+	})
+}
+
+// localArtifactPath returns the path in localDir of the artifact at the slash-separated path
+// p, failing if p, as listed by the server or bucket, is not below localDir.
+func localArtifactPath(localDir, p string) (string, error) {
+	if !localpath.IsLocal(filepath.FromSlash(p)) {
+		return "", fmt.Errorf("mlflow: invalid artifact path %q", p)
+	}
+	return filepath.Join(localDir, filepath.FromSlash(p)), nil
+}
+
+// uploadFiles uploads files below localDir to the directory path.
+func (s *ArtifactsService) uploadFiles(ctx context.Context, artifactURI, localDir string, files []*localFile, path string, opts *TransferOptions) error {
+	sizes := make([]int64, len(files))
+	for i, f := range files {
+		sizes[i] = f.size
+	}
+
+	t := newTransfer(opts, sizes)
+	return t.run(ctx, func(ctx context.Context, i int, progress func(int64)) (string, error) {
+		artifactPath := joinArtifactPath(path, files[i].path)
+
+		f, err := os.Open(filepath.Join(localDir, filepath.FromSlash(files[i].path)))
+		if err != nil {
+			return artifactPath, err
+		}
+		defer f.Close()
+
+		return artifactPath, s.upload(ctx, artifactURI, artifactPath, &progressReader{r: f, fn: progress})
+	})
+}
+
+// localFile is a file below a local directory.
+type localFile struct {
+	// path is the slash-separated path of the file, relative to the directory.
+	path string
+	size int64
+}
+
+// listLocalFiles lists the files below dir recursively.
+func listLocalFiles(dir string) ([]*localFile, error) {
+	var files []*localFile
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		files = append(files, &localFile{path: filepath.ToSlash(rel), size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// transfer runs file transfers on a pool of workers, retrying failed transfers and
+// reporting progress.
+type transfer struct {
+	opts  TransferOptions
+	start time.Time
+	n     int
+
+	mu           sync.Mutex
+	done         int
+	bytesDone    int64
+	bytesTotal   int64
+	fileBytes    []int64
+	lastProgress time.Time
+}
+
+// newTransfer returns a transfer of files of the given sizes, -1 for unknown sizes.
+func newTransfer(opts *TransferOptions, sizes []int64) *transfer {
+	t := &transfer{
+		start:     time.Now(),
+		n:         len(sizes),
+		fileBytes: make([]int64, len(sizes)),
+	}
+	if opts != nil {
+		t.opts = *opts
+	}
+	if t.opts.Workers <= 0 {
+		t.opts.Workers = defaultConcurrency
+	}
+
+	for _, size := range sizes {
+		if size < 0 {
+			t.bytesTotal = -1
+			break
+		}
+		t.bytesTotal += size
+	}
+
+	return t
+}
+
+// run transfers the files by calling fn for each of them, which must report the number of
+// bytes it transfers to progress.
+func (t *transfer) run(ctx context.Context, fn func(ctx context.Context, i int, progress func(int64)) (string, error)) error {
+	return forEach(ctx, t.n, t.opts.Workers, func(ctx context.Context, i int) error {
+		progress := func(n int64) { t.transferred(i, n) }
+
+		var (
+			path string
+			err  error
+		)
+		for attempt := 0; ; attempt++ {
+			t.resetFile(i)
+			path, err = fn(ctx, i, progress)
+			if err == nil || attempt >= t.opts.Retries || !isRetryable(err) {
+				break
+			}
+
+			select {
+			case <-time.After(retryBackoff(attempt)):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		t.fileDone(path)
+		return nil
+	})
+}
+
+// transferred records that n more bytes of the file i were transferred.
+func (t *transfer) transferred(i int, n int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.fileBytes[i] += n
+	t.bytesDone += n
+
+	if time.Since(t.lastProgress) >= progressInterval {
+		t.report("")
+	}
+}
+
+// resetFile discards the bytes transferred by a failed attempt to transfer the file i.
+func (t *transfer) resetFile(i int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.bytesDone -= t.fileBytes[i]
+	t.fileBytes[i] = 0
+}
+
+func (t *transfer) fileDone(path string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.done++
+	t.report(path)
+}
+
+// report calls the progress callback, t.mu must be held.
+func (t *transfer) report(path string) {
+	if t.opts.Progress == nil {
+		return
+	}
+
+	now := time.Now()
+	t.lastProgress = now
+
+	var rate float64
+	if elapsed := now.Sub(t.start).Seconds(); elapsed > 0 {
+		rate = float64(t.bytesDone) / elapsed
+	}
+
+	t.opts.Progress(TransferProgress{
+		Path:       path,
+		FilesDone:  t.done,
+		FilesTotal: t.n,
+		BytesDone:  t.bytesDone,
+		BytesTotal: t.bytesTotal,
+		Rate:       rate,
+	})
+}
+
+// progressReader reports the number of bytes read through it.
+type progressReader struct {
+	r  io.Reader
+	fn func(int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 && r.fn != nil {
+		r.fn(int64(n))
+	}
+	return n, err
+}
+
+// progressWriter reports the number of bytes written through it.
+type progressWriter struct {
+	w  io.Writer
+	fn func(int64)
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	if n > 0 && w.fn != nil {
+		w.fn(int64(n))
+	}
+	return n, err
+}
+
+// isRetryable reports whether a failed request may succeed when retried.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
+	}
+
+	var pathErr *fs.PathError
+	return !errors.As(err, &pathErr)
+}
+
+// retryBackoff returns the delay before retrying after the given failed attempt.
+func retryBackoff(attempt int) time.Duration {
+	d := 500 * time.Millisecond << attempt
+	if d > 30*time.Second || d <= 0 {
+		d = 30 * time.Second
+	}
+
+	return d
+}
+
+// joinArtifactPath joins artifact path elements with slashes.
+func joinArtifactPath(dir, path string) string {
+	dir = strings.Trim(dir, "/")
+	path = strings.TrimPrefix(path, "/")
+	if dir == "" || path == "" {
+		return dir + path
+	}
+
+	return dir + "/" + path
+}
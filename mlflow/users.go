@@ -2,6 +2,7 @@ package mlflow
 
 import (
 	"context"
+	"errors"
 	"net/url"
 )
 
@@ -50,6 +51,21 @@ func (s *UserService) Get(ctx context.Context, username string) (*User, error) {
 	return res.User, nil
 }
 
+// Me returns the user the client is authenticated as. The MLflow API has no endpoint to
+// identify the caller, so the client must be created with basic auth credentials, in its URL,
+// with WithBasicAuth or with a CredentialsProvider returning a username.
+func (s *UserService) Me(ctx context.Context) (*User, error) {
+	username, err := s.client.callerUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if username == "" {
+		return nil, errors.New("mlflow: unknown caller, the client has no basic auth credentials")
+	}
+
+	return s.Get(ctx, username)
+}
+
 func (s *UserService) UpdatePassword(ctx context.Context, username, password string) error {
 	opts := struct {
 		Username string `json:"username,omitempty"`
@@ -66,7 +82,7 @@ func (s *UserService) UpdatePassword(ctx context.Context, username, password str
 func (s *UserService) UpdateAdmin(ctx context.Context, username string, isAdmin bool) error {
 	opts := struct {
 		Username string `json:"username,omitempty"`
-		IsAdmin  bool   `json:"is_admin,omitempty"`
+		IsAdmin  bool   `json:"is_admin"`
 	}{
 		Username: username,
 		IsAdmin:  isAdmin,
@@ -0,0 +1,117 @@
+package mlflow
+
+import "context"
+
+// AccessSource is where the permission of a user on a resource comes from.
+type AccessSource string
+
+const (
+	// AccessExplicit is a permission granted to the user on the resource.
+	AccessExplicit AccessSource = "explicit"
+	// AccessDefault is the default permission of the server, which the API does not expose.
+	AccessDefault AccessSource = "default"
+	// AccessAdmin is the MANAGE permission admins have on all resources.
+	AccessAdmin AccessSource = "admin"
+)
+
+// ResourceAccess is the permission of a user on an experiment or a registered model.
+type ResourceAccess struct {
+	// ID is the ID of experiments, empty for registered models.
+	ID   string
+	Name string
+	// Permission is empty for AccessDefault.
+	Permission Permission
+	Source     AccessSource
+}
+
+// AccessReport lists the permissions of a user on the experiments and registered models.
+type AccessReport struct {
+	User             *User
+	Experiments      []*ResourceAccess
+	RegisteredModels []*ResourceAccess
+}
+
+// AccessReport returns the permissions of a user on all the active experiments and the
+// registered models. The permissions are looked up concurrently.
+func (s *UserService) AccessReport(ctx context.Context, username string) (*AccessReport, error) {
+	user, err := s.Get(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	experiments, err := s.client.Experiments.SearchAll(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	models, err := s.client.RegisteredModels.SearchAll(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &AccessReport{
+		User:             user,
+		Experiments:      make([]*ResourceAccess, len(experiments)),
+		RegisteredModels: make([]*ResourceAccess, len(models)),
+	}
+
+	err = forEach(ctx, len(experiments), defaultConcurrency, func(ctx context.Context, i int) error {
+		e := experiments[i]
+		res.Experiments[i] = &ResourceAccess{ID: e.ExperimentID, Name: e.Name}
+		if user.IsAdmin {
+			res.Experiments[i].setAdmin()
+			return nil
+		}
+
+		permission, err := s.client.Experiments.GetPermission(ctx, e.ExperimentID, username)
+		if err != nil {
+			return res.Experiments[i].setDefault(err)
+		}
+		res.Experiments[i].setExplicit(permission.Permission)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = forEach(ctx, len(models), defaultConcurrency, func(ctx context.Context, i int) error {
+		m := models[i]
+		res.RegisteredModels[i] = &ResourceAccess{Name: m.Name}
+		if user.IsAdmin {
+			res.RegisteredModels[i].setAdmin()
+			return nil
+		}
+
+		permission, err := s.client.RegisteredModels.GetPermission(ctx, m.Name, username)
+		if err != nil {
+			return res.RegisteredModels[i].setDefault(err)
+		}
+		res.RegisteredModels[i].setExplicit(permission.Permission)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (a *ResourceAccess) setAdmin() {
+	a.Permission = PermissionManage
+	a.Source = AccessAdmin
+}
+
+func (a *ResourceAccess) setExplicit(permission Permission) {
+	a.Permission = permission
+	a.Source = AccessExplicit
+}
+
+// setDefault records the default permission if err reports that the user has no explicit
+// permission, and returns err otherwise.
+func (a *ResourceAccess) setDefault(err error) error {
+	if !IsResourceDoesNotExist(err) {
+		return err
+	}
+	a.Source = AccessDefault
+	return nil
+}
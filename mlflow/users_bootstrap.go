@@ -0,0 +1,57 @@
+package mlflow
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// DefaultAdminUsername is the admin user MLflow servers with the basic-auth app create on
+// first start.
+const DefaultAdminUsername = "admin"
+
+// Bootstrap secures a new MLflow server with the basic-auth app: logged in as the default
+// admin user with defaultAdminPassword, it creates the admin newAdmin, or makes the existing
+// user an admin, verifies that newAdmin can log in as an admin and then sets the password of
+// the default admin to a random one, which is discarded; newAdmin can reset it if needed.
+// The credentials of the client are not used.
+func (s *UserService) Bootstrap(ctx context.Context, defaultAdminPassword, newAdmin, newPassword string) (*User, error) {
+	if newAdmin == DefaultAdminUsername {
+		return nil, fmt.Errorf("mlflow: the new admin must not be the default %q admin", DefaultAdminUsername)
+	}
+
+	admin := s.client.withBasicAuth(DefaultAdminUsername, defaultAdminPassword)
+
+	_, err := admin.Users.Create(ctx, newAdmin, newPassword)
+	if err != nil && !IsResourceAlreadyExists(err) {
+		return nil, err
+	}
+
+	err = admin.Users.UpdateAdmin(ctx, newAdmin, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the new admin before locking the default one out.
+	client := s.client.withBasicAuth(newAdmin, newPassword)
+	user, err := client.Users.Get(ctx, newAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("mlflow: logging in as %s: %w", newAdmin, err)
+	}
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("mlflow: %s is not an admin", newAdmin)
+	}
+
+	b := make([]byte, 32)
+	_, err = rand.Read(b)
+	if err != nil {
+		return nil, err
+	}
+	err = client.Users.UpdatePassword(ctx, DefaultAdminUsername, hex.EncodeToString(b))
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
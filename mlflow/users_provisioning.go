@@ -0,0 +1,164 @@
+package mlflow
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// ProvisioningConfig maps the claims of OIDC ID tokens or JWTs to the state of MLflow users,
+// see Users.Provision.
+type ProvisioningConfig struct {
+	// UsernameClaim is the claim holding the username, "preferred_username" by default.
+	UsernameClaim string
+	// GroupsClaim is the claim listing the groups of the user, "groups" by default.
+	GroupsClaim string
+	// AdminGroups are the groups whose members are admins, the other users not being
+	// admins. The admin status of users is left as it is if empty.
+	AdminGroups []string
+	// ExperimentPermissions maps groups to the permissions of their members on experiments,
+	// keyed by experiment ID.
+	ExperimentPermissions map[string]map[string]Permission
+	// RegisteredModelPermissions maps groups to the permissions of their members on
+	// registered models, keyed by name.
+	RegisteredModelPermissions map[string]map[string]Permission
+}
+
+// Provision ensures that the MLflow user of the claims of an authenticated user, such as the
+// claims of an OIDC ID token, exists and has the admin status and permissions cfg maps its
+// groups to, the admin status only if cfg has admin groups. Users are created with a random password, they are expected to log in through
+// the SSO proxy. Members of several groups get the highest of their permissions; the
+// permissions on the resources of cfg none of the groups of the user grants are deleted.
+// Permissions on other resources are left as they are.
+func (s *UserService) Provision(ctx context.Context, claims map[string]any, cfg *ProvisioningConfig) (*User, error) {
+	usernameClaim := cfg.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = "preferred_username"
+	}
+	groupsClaim := cfg.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = "groups"
+	}
+
+	username, _ := claims[usernameClaim].(string)
+	if username == "" {
+		return nil, fmt.Errorf("mlflow: claims have no %s claim", usernameClaim)
+	}
+	groups := claimStrings(claims[groupsClaim])
+
+	user, err := s.Get(ctx, username)
+	if IsResourceDoesNotExist(err) {
+		b := make([]byte, 32)
+		_, err = rand.Read(b)
+		if err != nil {
+			return nil, err
+		}
+		user, err = s.Create(ctx, username, hex.EncodeToString(b))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	isAdmin := false
+	for _, g := range groups {
+		for _, admin := range cfg.AdminGroups {
+			if g == admin {
+				isAdmin = true
+			}
+		}
+	}
+	if len(cfg.AdminGroups) > 0 && user.IsAdmin != isAdmin {
+		err = s.UpdateAdmin(ctx, username, isAdmin)
+		if err != nil {
+			return nil, err
+		}
+		user.IsAdmin = isAdmin
+	}
+
+	experiments := provisionedPermissions(cfg.ExperimentPermissions, groups)
+	for id, permission := range experiments {
+		current, err := s.client.Experiments.GetPermission(ctx, id, username)
+		if err != nil && !IsResourceDoesNotExist(err) {
+			return nil, err
+		}
+
+		switch {
+		case permission == "" && current != nil:
+			err = s.client.Experiments.DeletePermission(ctx, id, username)
+		case permission != "" && (current == nil || current.Permission != permission):
+			err = s.client.Experiments.GrantPermission(ctx, id, username, permission)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	models := provisionedPermissions(cfg.RegisteredModelPermissions, groups)
+	for name, permission := range models {
+		current, err := s.client.RegisteredModels.GetPermission(ctx, name, username)
+		if err != nil && !IsResourceDoesNotExist(err) {
+			return nil, err
+		}
+
+		switch {
+		case permission == "" && current != nil:
+			err = s.client.RegisteredModels.DeletePermission(ctx, name, username)
+		case permission != "" && (current == nil || current.Permission != permission):
+			err = s.client.RegisteredModels.GrantPermission(ctx, name, username, permission)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+// claimStrings returns the strings of a claim, a string or a list of strings.
+func claimStrings(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []any:
+		var res []string
+		for _, s := range v {
+			if s, ok := s.(string); ok {
+				res = append(res, s)
+			}
+		}
+		return res
+	}
+	return nil
+}
+
+// permissionRanks orders the permissions.
+var permissionRanks = map[Permission]int{
+	PermissionNoPermissions: 1,
+	PermissionRead:          2,
+	PermissionEdit:          3,
+	PermissionManage:        4,
+}
+
+// provisionedPermissions returns the highest permission groups grant on each resource of
+// mapping, empty for the resources none of the groups grants a permission on.
+func provisionedPermissions(mapping map[string]map[string]Permission, groups []string) map[string]Permission {
+	res := map[string]Permission{}
+	for _, permissions := range mapping {
+		for resource := range permissions {
+			res[resource] = ""
+		}
+	}
+
+	for _, g := range groups {
+		for resource, permission := range mapping[g] {
+			if permissionRanks[permission] > permissionRanks[res[resource]] {
+				res[resource] = permission
+			}
+		}
+	}
+
+	return res
+}
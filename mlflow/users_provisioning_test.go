@@ -0,0 +1,73 @@
+package mlflow_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// newUsersClient returns a client of a server with an admin user, alice, recording the
+// admin status updates.
+func newUsersClient(t *testing.T, updates *[]string) *mlflow.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/2.0/mlflow/users/get":
+			_ = json.NewEncoder(w).Encode(map[string]any{"user": &mlflow.User{ID: 1, Username: "alice", IsAdmin: true}})
+		case "/api/2.0/mlflow/users/update-admin":
+			b, _ := io.ReadAll(r.Body)
+			*updates = append(*updates, string(b))
+			_, _ = w.Write([]byte("{}"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code": "ENDPOINT_NOT_FOUND"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	client, err := mlflow.NewClient(srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestUsersProvisionAdmin(t *testing.T) {
+	tests := []struct {
+		name        string
+		adminGroups []string
+		want        []string
+	}{
+		{name: "no admin groups"},
+		{name: "member of an admin group", adminGroups: []string{"ml-admins"}},
+		{
+			name:        "not a member of the admin groups",
+			adminGroups: []string{"platform"},
+			want:        []string{`{"username":"alice","is_admin":false}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updates []string
+			client := newUsersClient(t, &updates)
+
+			claims := map[string]any{"preferred_username": "alice", "groups": []any{"ml-admins"}}
+			user, err := client.Users.Provision(context.Background(), claims, &mlflow.ProvisioningConfig{AdminGroups: tt.adminGroups})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(updates) != len(tt.want) || len(updates) > 0 && updates[0] != tt.want[0] {
+				t.Errorf("got admin updates %q, want %q", updates, tt.want)
+			}
+			if user.IsAdmin != (len(tt.want) == 0) {
+				t.Errorf("got admin %v", user.IsAdmin)
+			}
+		})
+	}
+}
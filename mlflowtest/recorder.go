@@ -0,0 +1,419 @@
+// Package mlflowtest provides helpers for testing code using the MLflow client without a live
+// tracking server.
+//
+// A Recorder records the interactions of a client with a tracking server to a fixture file,
+// and replays them in later runs of the test:
+//
+//	func TestTraining(t *testing.T) {
+//		rec := mlflowtest.NewRecorder(t, "testdata/training.json", nil)
+//		client, err := mlflow.NewClient(rec.Client(), os.Getenv("MLFLOW_TRACKING_URI"))
+//		...
+//	}
+//
+// The fixture is recorded when it does not exist or when the MLFLOWTEST_RECORD environment
+// variable is set, and replayed otherwise, such as in CI.
+//
+// A Server is an in-memory fake of a tracking server, for tests exercising the client against
+// the behaviour of the server without fixtures.
+package mlflowtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+)
+
+// RecordEnv is the environment variable forcing recorders in ModeAuto to record their
+// fixtures again.
+const RecordEnv = "MLFLOWTEST_RECORD"
+
+// Redacted replaces the sanitized values of fixtures.
+const Redacted = "REDACTED"
+
+// Mode is the mode of a recorder.
+type Mode int
+
+const (
+	// ModeAuto replays the fixture if it exists and RecordEnv is not set, and records it
+	// otherwise.
+	ModeAuto Mode = iota
+	ModeRecord
+	ModeReplay
+)
+
+// Interaction is a request and its response.
+type Interaction struct {
+	Request  *Request  `json:"request"`
+	Response *Response `json:"response"`
+}
+
+type Request struct {
+	Method string `json:"method"`
+	// URL is the path and query of the request, without the scheme and host of the server.
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is the body of a request or response, written to fixtures as JSON for JSON bodies, as
+// a string for other text and in base64 otherwise.
+type Body []byte
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	switch {
+	case json.Valid(b):
+		return json.Marshal(map[string]json.RawMessage{"json": json.RawMessage(b)})
+	case utf8.Valid(b):
+		return json.Marshal(map[string]string{"text": string(b)})
+	}
+	return json.Marshal(map[string][]byte{"base64": b})
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var v struct {
+		JSON   json.RawMessage `json:"json"`
+		Text   *string         `json:"text"`
+		Base64 []byte          `json:"base64"`
+	}
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case v.JSON != nil:
+		*b = Body(v.JSON)
+	case v.Text != nil:
+		*b = Body(*v.Text)
+	default:
+		*b = v.Base64
+	}
+	return nil
+}
+
+type fixture struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// RecorderOptions configures a recorder.
+type RecorderOptions struct {
+	Mode Mode
+	// Transport sends the requests recorded, http.DefaultTransport if nil.
+	Transport http.RoundTripper
+	// Sanitize is called with the interactions recorded, after Sanitize, to remove other
+	// sensitive or varying values.
+	Sanitize func(i *Interaction)
+	// MatchBody makes replayed requests match recorded ones by body as well as by method and
+	// URL, for tests sending the same request with different bodies.
+	MatchBody bool
+}
+
+// Recorder is a transport recording interactions with a server to a fixture file, or
+// replaying them. Replayed requests get the first recorded response not replayed yet to a
+// request with the same method and URL.
+type Recorder struct {
+	path string
+	opts RecorderOptions
+
+	mu           sync.Mutex
+	recording    bool
+	interactions []*Interaction
+	replayed     []bool
+}
+
+// NewRecorder returns a recorder of the fixture at path. The fixture is written when the
+// test completes successfully if it is recorded, and the test fails if it cannot be read or
+// written.
+func NewRecorder(tb testing.TB, path string, opts *RecorderOptions) *Recorder {
+	tb.Helper()
+
+	r := &Recorder{path: path}
+	if opts != nil {
+		r.opts = *opts
+	}
+	if r.opts.Transport == nil {
+		r.opts.Transport = http.DefaultTransport
+	}
+
+	switch r.opts.Mode {
+	case ModeRecord:
+		r.recording = true
+	case ModeAuto:
+		_, err := os.Stat(path)
+		r.recording = os.Getenv(RecordEnv) != "" || os.IsNotExist(err)
+	}
+
+	if r.recording {
+		tb.Cleanup(func() {
+			if tb.Failed() {
+				return
+			}
+			if err := r.Save(); err != nil {
+				tb.Errorf("mlflowtest: %v", err)
+			}
+		})
+		return r
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		tb.Fatalf("mlflowtest: %v", err)
+	}
+	var f fixture
+	err = json.Unmarshal(b, &f)
+	if err != nil {
+		tb.Fatalf("mlflowtest: %s: %v", path, err)
+	}
+	r.interactions = f.Interactions
+	r.replayed = make([]bool, len(f.Interactions))
+
+	return r
+}
+
+// Recording reports whether the recorder records the fixture rather than replaying it.
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+// Client returns an HTTP client using the recorder, for mlflow.NewClient.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.recording {
+		return r.record(req, body)
+	}
+	return r.replay(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	res, err := r.opts.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	i := &Interaction{
+		Request: &Request{
+			Method: req.Method,
+			URL:    req.URL.RequestURI(),
+			Header: req.Header.Clone(),
+			Body:   append([]byte(nil), body...),
+		},
+		Response: &Response{
+			StatusCode: res.StatusCode,
+			Header:     res.Header.Clone(),
+			Body:       append([]byte(nil), resBody...),
+		},
+	}
+	Sanitize(i)
+	if r.opts.Sanitize != nil {
+		r.opts.Sanitize(i)
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, i)
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	// Sanitize the request as it was when recorded.
+	i := &Interaction{
+		Request:  &Request{Method: req.Method, URL: req.URL.RequestURI(), Body: body},
+		Response: &Response{},
+	}
+	Sanitize(i)
+	if r.opts.Sanitize != nil {
+		r.opts.Sanitize(i)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for n, recorded := range r.interactions {
+		if r.replayed[n] || !r.matches(recorded.Request, i.Request) {
+			continue
+		}
+		r.replayed[n] = true
+
+		res := recorded.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", res.StatusCode, http.StatusText(res.StatusCode)),
+			StatusCode:    res.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        res.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(res.Body)),
+			ContentLength: int64(len(res.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("mlflowtest: no recorded response to %s %s in %s", req.Method, i.Request.URL, r.path)
+}
+
+func (r *Recorder) matches(recorded, req *Request) bool {
+	if recorded.Method != req.Method || !sameURL(recorded.URL, req.URL) {
+		return false
+	}
+	if !r.opts.MatchBody {
+		return true
+	}
+
+	var a, b bytes.Buffer
+	if json.Compact(&a, recorded.Body) == nil && json.Compact(&b, req.Body) == nil {
+		return bytes.Equal(a.Bytes(), b.Bytes())
+	}
+	return bytes.Equal(recorded.Body, req.Body)
+}
+
+// sameURL reports whether two request URIs are equal, ignoring the order of the query
+// parameters.
+func sameURL(a, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return ua.Path == ub.Path && ua.Query().Encode() == ub.Query().Encode()
+}
+
+// Save writes the recorded interactions to the fixture file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	f := fixture{Interactions: r.interactions}
+	if f.Interactions == nil {
+		f.Interactions = []*Interaction{}
+	}
+	b, err := json.MarshalIndent(&f, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(r.path), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(b, '\n'), 0o644)
+}
+
+// keptHeaders are the headers kept in fixtures, the other ones may hold credentials or vary
+// between runs.
+var keptHeaders = []string{"Content-Type", "Content-Encoding", "Content-Range", "Accept-Ranges", "Content-Md5", "Digest"}
+
+// sensitiveKey matches the keys of the JSON objects whose values are credentials.
+var sensitiveKey = regexp.MustCompile(`(?i)(password|secret|token|api_?key|access_?key|credential|authorization)`)
+
+// Sanitize removes the credentials from an interaction: the headers other than the ones
+// describing content, and the values of JSON object keys such as "password" or "token",
+// which are replaced by Redacted.
+func Sanitize(i *Interaction) {
+	for _, h := range []*http.Header{&i.Request.Header, &i.Response.Header} {
+		if *h == nil {
+			continue
+		}
+		kept := http.Header{}
+		for _, key := range keptHeaders {
+			if v := h.Values(key); len(v) > 0 {
+				kept[key] = v
+			}
+		}
+		if len(kept) == 0 {
+			kept = nil
+		}
+		*h = kept
+	}
+
+	i.Request.Body = redactJSON(i.Request.Body)
+	i.Response.Body = redactJSON(i.Response.Body)
+
+	u, err := url.Parse(i.Request.URL)
+	if err == nil && u.RawQuery != "" {
+		q := u.Query()
+		for key := range q {
+			if sensitiveKey.MatchString(key) && !strings.EqualFold(key, "page_token") {
+				q.Set(key, Redacted)
+			}
+		}
+		u.RawQuery = q.Encode()
+		i.Request.URL = u.String()
+	}
+}
+
+// redactJSON replaces the values of the sensitive keys of a JSON body, leaving other bodies
+// as is.
+func redactJSON(body Body) Body {
+	var v any
+	if len(body) == 0 || json.Unmarshal(body, &v) != nil {
+		return body
+	}
+	if !redact(v) {
+		return body
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+// redact replaces the values of the sensitive keys of a decoded JSON value, reporting whether
+// it changed.
+func redact(v any) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if _, ok := value.(string); ok && sensitiveKey.MatchString(key) && !strings.HasSuffix(key, "page_token") {
+				v[key] = Redacted
+				changed = true
+				continue
+			}
+			changed = redact(value) || changed
+		}
+	case []any:
+		for _, value := range v {
+			changed = redact(value) || changed
+		}
+	}
+	return changed
+}
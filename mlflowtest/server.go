@@ -0,0 +1,360 @@
+package mlflowtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codeocean/go-mlflow/internal/search"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+const (
+	apiPrefix       = "/api/2.0/mlflow/"
+	artifactsPrefix = "/api/2.0/mlflow-artifacts/artifacts"
+)
+
+// DefaultExperimentID is the ID of the experiment created with the server, as by MLflow.
+const DefaultExperimentID = "0"
+
+// Limits of the requests, as enforced by MLflow.
+const (
+	maxEntityKeyLength  = 250
+	maxParamValueLength = 6000
+	maxTagValueLength   = 8000
+
+	maxBatchMetrics = 1000
+	maxBatchParams  = 100
+	maxBatchTags    = 100
+	maxBatchEntries = 1000
+
+	defaultMaxExperiments = 1000
+	maxMaxExperiments     = 50000
+	defaultMaxRuns        = 1000
+	maxMaxRuns            = 50000
+	defaultMaxModels      = 100
+	maxMaxModels          = 1000
+	defaultMaxVersions    = 10000
+	maxMaxVersions        = 200000
+)
+
+// Server is an in-memory fake of a tracking server and model registry, implementing the
+// create, get, update, delete, search and log endpoints of experiments, runs, metrics,
+// registered models and model versions, and the artifacts proxy, with the validation and
+// pagination of MLflow:
+//
+//	srv := mlflowtest.NewServer()
+//	defer srv.Close()
+//	client, err := srv.NewClient()
+//
+// The state of the server is lost when it is closed.
+type Server struct {
+	*httptest.Server
+
+	mu               sync.Mutex
+	nextExperimentID int
+	experiments      map[string]*mlflow.Experiment
+	runs             map[string]*run
+	models           map[string]*model
+	artifacts        map[string][]byte
+}
+
+// NewServer starts a server with a Default experiment, to be closed by the caller.
+func NewServer() *Server {
+	s := &Server{
+		experiments: map[string]*mlflow.Experiment{},
+		runs:        map[string]*run{},
+		models:      map[string]*model{},
+		artifacts:   map[string][]byte{},
+	}
+	s.createExperiment("Default", "", nil)
+
+	mux := &router{routes: map[string]http.HandlerFunc{}}
+	s.registerExperiments(mux)
+	s.registerRuns(mux)
+	s.registerModels(mux)
+	s.registerArtifacts(mux)
+
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// router routes the requests by method and path. The paths of the patterns ending with a
+// slash match the paths they prefix, as those of http.ServeMux.
+type router struct {
+	routes   map[string]http.HandlerFunc
+	prefixes []string
+}
+
+// HandleFunc registers the handler of a pattern, a method and a path such as
+// "GET /api/2.0/mlflow/runs/get".
+func (rt *router) HandleFunc(pattern string, h http.HandlerFunc) {
+	rt.routes[pattern] = h
+	method, p, _ := strings.Cut(pattern, " ")
+	if strings.HasSuffix(p, "/") {
+		rt.prefixes = append(rt.prefixes, p)
+	}
+	if method == http.MethodGet {
+		rt.routes[http.MethodHead+" "+p] = h
+	}
+}
+
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h, ok := rt.routes[r.Method+" "+r.URL.Path]
+	for _, prefix := range rt.prefixes {
+		if !ok && strings.HasPrefix(r.URL.Path, prefix) {
+			h, ok = rt.routes[r.Method+" "+prefix]
+		}
+	}
+	if !ok {
+		writeError(w, &mlflow.Error{
+			StatusCode: http.StatusNotFound,
+			ErrorCode:  "ENDPOINT_NOT_FOUND",
+			Message:    "No API endpoint found for " + r.Method + " " + r.URL.Path,
+		})
+		return
+	}
+	h(w, r)
+}
+
+// NewClient returns a client of the server.
+func (s *Server) NewClient(opts ...mlflow.ClientOption) (*mlflow.Client, error) {
+	return mlflow.NewClient(s.Client(), s.URL, opts...)
+}
+
+// handle returns a handler of the requests of an endpoint, called with the state of the
+// server locked.
+func (s *Server) handle(f func(r *http.Request) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		res, err := f(r)
+		var b []byte
+		if err == nil {
+			if res == nil {
+				res = struct{}{}
+			}
+			b, err = json.Marshal(res)
+		}
+		s.mu.Unlock()
+
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*mlflow.Error)
+	if !ok {
+		e = &mlflow.Error{StatusCode: http.StatusInternalServerError, ErrorCode: "INTERNAL_ERROR", Message: err.Error()}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.StatusCode)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error_code": e.ErrorCode, "message": e.Message})
+}
+
+func errInvalid(format string, args ...any) error {
+	return &mlflow.Error{
+		StatusCode: http.StatusBadRequest,
+		ErrorCode:  mlflow.ErrorInvalidParameterValue,
+		Message:    fmt.Sprintf(format, args...),
+	}
+}
+
+func errNotFound(format string, args ...any) error {
+	return &mlflow.Error{
+		StatusCode: http.StatusNotFound,
+		ErrorCode:  mlflow.ErrorResourceDoesNotExist,
+		Message:    fmt.Sprintf(format, args...),
+	}
+}
+
+func errExists(format string, args ...any) error {
+	return &mlflow.Error{
+		StatusCode: http.StatusBadRequest,
+		ErrorCode:  mlflow.ErrorResourceAlreadyExists,
+		Message:    fmt.Sprintf(format, args...),
+	}
+}
+
+func errMissing(name string) error {
+	return errInvalid("Missing value for required parameter '%s'.", name)
+}
+
+// decode decodes the query parameters of GET requests and the JSON body of the other
+// requests, as the MLflow server does, which ignores the query parameters of DELETE requests.
+func decode(r *http.Request, v any) error {
+	if r.Method == http.MethodGet {
+		return decodeQuery(r.URL.Query(), v)
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if len(b) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		return errInvalid("Malformed request body: %v", err)
+	}
+	return nil
+}
+
+// decodeQuery decodes query parameters by converting them to a JSON object, repeated
+// parameters being arrays.
+func decodeQuery(q url.Values, v any) error {
+	obj := map[string]any{}
+	for key, values := range q {
+		if key == "order_by" || key == "experiment_ids" {
+			obj[key] = values
+			continue
+		}
+		if key == "max_results" {
+			n, err := strconv.ParseInt(values[0], 10, 64)
+			if err != nil {
+				return errInvalid("Invalid value '%s' for parameter 'max_results' supplied.", values[0])
+			}
+			obj[key] = n
+			continue
+		}
+		obj[key] = values[0]
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		return errInvalid("Malformed request parameters: %v", err)
+	}
+	return nil
+}
+
+func now() int64 {
+	return time.Now().UnixMilli()
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// validKey matches the valid names of metrics, params and tags.
+var validKey = regexp.MustCompile(`^[/\w.\- :]*$`)
+
+// validateKey validates the key of a metric, param or tag.
+func validateKey(kind, key string) error {
+	if key == "" {
+		return errMissing("key")
+	}
+	if !validKey.MatchString(key) || strings.HasPrefix(key, "/") || strings.Contains(key, "..") {
+		return errInvalid("Invalid %s name: '%s'. Names may only contain alphanumerics, underscores (_), dashes (-), periods (.), spaces ( ), and slashes (/).", kind, key)
+	}
+	if len(key) > maxEntityKeyLength {
+		return errInvalid("%s '%s' had length %d, which exceeded length limit of %d", kind, key, len(key), maxEntityKeyLength)
+	}
+	return nil
+}
+
+// maxResults validates the maximum number of results of a search.
+func maxResults(n int64, defaultMax, limit int) (int, error) {
+	switch {
+	case n < 0 || n > int64(limit):
+		return 0, errInvalid("Invalid value %d for parameter 'max_results' supplied. It must be at most %d.", n, limit)
+	case n == 0:
+		return defaultMax, nil
+	}
+	return int(n), nil
+}
+
+// find filters, sorts and paginates the results of a search.
+func find[T any](items []T, lookup func(T) search.Lookup, filter string, orderBy []string, defaultOrder []string, pageToken string, max int) ([]T, string, error) {
+	f, err := search.ParseFilter(filter)
+	if err != nil {
+		return nil, "", errInvalid("%v", err)
+	}
+	if len(orderBy) == 0 {
+		orderBy = defaultOrder
+	}
+	o, err := search.ParseOrder(orderBy)
+	if err != nil {
+		return nil, "", errInvalid("%v", err)
+	}
+	o = append(o, defaultOrderKeys(defaultOrder)...)
+
+	var matched []T
+	for _, item := range items {
+		if f.Match(lookup(item)) {
+			matched = append(matched, item)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return o.Compare(lookup(matched[i]), lookup(matched[j])) < 0
+	})
+
+	page, next, err := search.Page(matched, pageToken, max)
+	if err != nil {
+		return nil, "", errInvalid("%v", err)
+	}
+	return page, next, nil
+}
+
+// indexFunc returns the index of the first item of s satisfying f, or -1.
+func indexFunc[T any](s []T, f func(T) bool) int {
+	for i, item := range s {
+		if f(item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// deleteFunc removes the items of s satisfying f, in place.
+func deleteFunc[T any](s []T, f func(T) bool) []T {
+	kept := s[:0]
+	for _, item := range s {
+		if !f(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// defaultOrderKeys returns the keys of the default order, which break the ties of the
+// requested order.
+func defaultOrderKeys(defaultOrder []string) search.Order {
+	o, _ := search.ParseOrder(defaultOrder)
+	return o
+}
+
+// matchesViewType reports whether an entity in a lifecycle stage is returned for a view
+// type, ACTIVE_ONLY if empty.
+func matchesViewType(stage mlflow.LifecycleStage, viewType mlflow.ViewType) bool {
+	switch viewType {
+	case mlflow.ViewTypeAll:
+		return true
+	case mlflow.ViewTypeDeletedOnly:
+		return stage == mlflow.LifecycleStageDeleted
+	}
+	return stage != mlflow.LifecycleStageDeleted
+}
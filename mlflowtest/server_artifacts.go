@@ -0,0 +1,161 @@
+package mlflowtest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+func (s *Server) registerArtifacts(mux *router) {
+	mux.HandleFunc("GET "+artifactsPrefix, s.handle(s.handleListArtifacts))
+	mux.HandleFunc("GET "+artifactsPrefix+"/", s.handleDownloadArtifact)
+	mux.HandleFunc("PUT "+artifactsPrefix+"/", s.handleUploadArtifact)
+	mux.HandleFunc("GET "+apiPrefix+"artifacts/list", s.handle(s.handleListRunArtifacts))
+}
+
+// artifactPath returns the key of an artifact in the store, the clean path of the artifact
+// below the root of the artifacts proxy.
+func artifactPath(p string) (string, error) {
+	p = strings.Trim(p, "/")
+	for _, elem := range strings.Split(p, "/") {
+		if elem == ".." {
+			return "", errInvalid("Invalid path: %s", p)
+		}
+	}
+	p = path.Clean(p)
+	if p == "." {
+		return "", nil
+	}
+	return p, nil
+}
+
+// listArtifacts returns the files and directories directly in dir, with paths relative to
+// the root of the artifacts proxy.
+func (s *Server) listArtifacts(dir string) []*mlflow.FileInfo {
+	prefix := ""
+	if dir != "" {
+		prefix = dir + "/"
+	}
+
+	var files []*mlflow.FileInfo
+	dirs := map[string]bool{}
+	for p, b := range s.artifacts {
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		name, _, isDir := strings.Cut(p[len(prefix):], "/")
+		if isDir {
+			if dirs[name] {
+				continue
+			}
+			dirs[name] = true
+			files = append(files, &mlflow.FileInfo{Path: prefix + name, IsDir: true})
+			continue
+		}
+		files = append(files, &mlflow.FileInfo{Path: prefix + name, FileSize: int64(len(b))})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// handleListArtifacts lists the files of a directory of the artifacts proxy, by name as the
+// proxy does.
+func (s *Server) handleListArtifacts(r *http.Request) (any, error) {
+	dir, err := artifactPath(r.URL.Query().Get("path"))
+	if err != nil {
+		return nil, err
+	}
+
+	files := s.listArtifacts(dir)
+	for _, f := range files {
+		f.Path = path.Base(f.Path)
+	}
+	return map[string]any{"files": files}, nil
+}
+
+// pathValue returns the path of the artifact of a request to the artifacts proxy.
+func pathValue(r *http.Request) string {
+	return strings.TrimPrefix(r.URL.Path, artifactsPrefix+"/")
+}
+
+func (s *Server) handleDownloadArtifact(w http.ResponseWriter, r *http.Request) {
+	p, err := artifactPath(pathValue(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	b, ok := s.artifacts[p]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, errNotFound("File not found: %s", p))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, r, path.Base(p), time.Time{}, bytes.NewReader(b))
+}
+
+func (s *Server) handleUploadArtifact(w http.ResponseWriter, r *http.Request) {
+	p, err := artifactPath(pathValue(r))
+	if err == nil && p == "" {
+		err = errInvalid("Invalid path: %s", pathValue(r))
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	s.artifacts[p] = b
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = io.WriteString(w, "{}")
+}
+
+// handleListRunArtifacts lists the artifacts of a run stored on the artifacts proxy, with
+// paths relative to the artifact root of the run.
+func (s *Server) handleListRunArtifacts(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	id := q.Get("run_id")
+	if id == "" {
+		id = q.Get("run_uuid")
+	}
+	rn, err := s.run(id)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := artifactPath(q.Get("path"))
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string]any{"root_uri": rn.Info.ArtifactUri}
+	if !strings.HasPrefix(rn.Info.ArtifactUri, "mlflow-artifacts:") {
+		return res, nil
+	}
+	root, err := artifactPath(strings.TrimPrefix(rn.Info.ArtifactUri, "mlflow-artifacts:"))
+	if err != nil {
+		return nil, err
+	}
+
+	files := s.listArtifacts(path.Join(root, rel))
+	for _, f := range files {
+		f.Path = strings.TrimPrefix(f.Path, root+"/")
+	}
+	res["files"] = files
+	return res, nil
+}
@@ -0,0 +1,263 @@
+package mlflowtest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/codeocean/go-mlflow/internal/search"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+func (s *Server) registerExperiments(mux *router) {
+	mux.HandleFunc("POST "+apiPrefix+"experiments/create", s.handle(s.handleCreateExperiment))
+	mux.HandleFunc("GET "+apiPrefix+"experiments/get", s.handle(s.handleGetExperiment))
+	mux.HandleFunc("GET "+apiPrefix+"experiments/get-by-name", s.handle(s.handleGetExperimentByName))
+	mux.HandleFunc("POST "+apiPrefix+"experiments/search", s.handle(s.handleSearchExperiments))
+	mux.HandleFunc("GET "+apiPrefix+"experiments/search", s.handle(s.handleSearchExperiments))
+	mux.HandleFunc("POST "+apiPrefix+"experiments/update", s.handle(s.handleUpdateExperiment))
+	mux.HandleFunc("POST "+apiPrefix+"experiments/delete", s.handle(s.handleDeleteExperiment))
+	mux.HandleFunc("POST "+apiPrefix+"experiments/restore", s.handle(s.handleRestoreExperiment))
+	mux.HandleFunc("POST "+apiPrefix+"experiments/set-experiment-tag", s.handle(s.handleSetExperimentTag))
+	mux.HandleFunc("POST "+apiPrefix+"experiments/delete-experiment-tag", s.handle(s.handleDeleteExperimentTag))
+}
+
+func (s *Server) createExperiment(name, artifactLocation string, tags []*mlflow.ExperimentTag) *mlflow.Experiment {
+	id := strconv.Itoa(s.nextExperimentID)
+	s.nextExperimentID++
+
+	if artifactLocation == "" {
+		artifactLocation = "mlflow-artifacts:/" + id
+	}
+	t := now()
+	e := &mlflow.Experiment{
+		ExperimentID:     id,
+		Name:             name,
+		ArtifactLocation: artifactLocation,
+		LifecycleStage:   mlflow.LifecycleStageActive,
+		CreationTime:     t,
+		LastUpdateTime:   t,
+		Tags:             tags,
+	}
+	s.experiments[id] = e
+	return e
+}
+
+func (s *Server) experiment(id string) (*mlflow.Experiment, error) {
+	if id == "" {
+		return nil, errMissing("experiment_id")
+	}
+	e, ok := s.experiments[id]
+	if !ok {
+		return nil, errNotFound("No Experiment with id=%s exists", id)
+	}
+	return e, nil
+}
+
+func (s *Server) experimentByName(name string) *mlflow.Experiment {
+	for _, e := range s.experiments {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// activeExperiment returns an experiment which must be active to be modified.
+func (s *Server) activeExperiment(id string) (*mlflow.Experiment, error) {
+	e, err := s.experiment(id)
+	if err != nil {
+		return nil, err
+	}
+	if e.IsDeleted() {
+		return nil, errInvalid("The experiment %s must be in the 'active' state. Current state is deleted.", id)
+	}
+	return e, nil
+}
+
+func (s *Server) handleCreateExperiment(r *http.Request) (any, error) {
+	var req mlflow.ExperimentCreateOptions
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, errMissing("name")
+	}
+	if e := s.experimentByName(req.Name); e != nil {
+		if e.IsDeleted() {
+			return nil, errExists("Experiment '%s' already exists in deleted state. You can restore the experiment, or permanently delete the experiment to create a new one.", req.Name)
+		}
+		return nil, errExists("Experiment '%s' already exists.", req.Name)
+	}
+	for _, t := range req.Tags {
+		err = validateTag(t.Key, t.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	e := s.createExperiment(req.Name, req.ArtifactLocation, req.Tags)
+	return map[string]any{"experiment_id": e.ExperimentID}, nil
+}
+
+func (s *Server) handleGetExperiment(r *http.Request) (any, error) {
+	e, err := s.experiment(r.URL.Query().Get("experiment_id"))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"experiment": e}, nil
+}
+
+func (s *Server) handleGetExperimentByName(r *http.Request) (any, error) {
+	name := r.URL.Query().Get("experiment_name")
+	if name == "" {
+		return nil, errMissing("experiment_name")
+	}
+	e := s.experimentByName(name)
+	if e == nil {
+		return nil, errNotFound("Could not find experiment with name '%s'", name)
+	}
+	return map[string]any{"experiment": e}, nil
+}
+
+func (s *Server) handleSearchExperiments(r *http.Request) (any, error) {
+	var req mlflow.ExperimentsSearchOptions
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	max, err := maxResults(req.MaxResults, defaultMaxExperiments, maxMaxExperiments)
+	if err != nil {
+		return nil, err
+	}
+
+	var experiments []*mlflow.Experiment
+	for _, e := range s.experiments {
+		if matchesViewType(e.LifecycleStage, req.ViewType) {
+			experiments = append(experiments, e)
+		}
+	}
+	page, next, err := find(experiments, search.ExperimentLookup, req.Filter, req.OrderBy,
+		[]string{"creation_time DESC", "experiment_id ASC"}, req.PageToken, max)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"experiments": page, "next_page_token": next}, nil
+}
+
+type experimentRequest struct {
+	ExperimentID string `json:"experiment_id"`
+	NewName      string `json:"new_name"`
+	Key          string `json:"key"`
+	Value        string `json:"value"`
+}
+
+func (s *Server) handleUpdateExperiment(r *http.Request) (any, error) {
+	var req experimentRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.activeExperiment(req.ExperimentID)
+	if err != nil {
+		return nil, err
+	}
+	if req.NewName == "" {
+		return nil, errMissing("new_name")
+	}
+	if other := s.experimentByName(req.NewName); other != nil && other != e {
+		return nil, errExists("Experiment '%s' already exists.", req.NewName)
+	}
+
+	e.Name = req.NewName
+	e.LastUpdateTime = now()
+	return nil, nil
+}
+
+func (s *Server) handleDeleteExperiment(r *http.Request) (any, error) {
+	var req experimentRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.activeExperiment(req.ExperimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	e.LifecycleStage = mlflow.LifecycleStageDeleted
+	e.LastUpdateTime = now()
+	for _, run := range s.runs {
+		if run.Info.ExperimentID == e.ExperimentID {
+			run.Info.LifecycleStage = mlflow.LifecycleStageDeleted
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) handleRestoreExperiment(r *http.Request) (any, error) {
+	var req experimentRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.experiment(req.ExperimentID)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsDeleted() {
+		return nil, errInvalid("Cannot restore an active experiment.")
+	}
+
+	e.LifecycleStage = mlflow.LifecycleStageActive
+	e.LastUpdateTime = now()
+	for _, run := range s.runs {
+		if run.Info.ExperimentID == e.ExperimentID {
+			run.Info.LifecycleStage = mlflow.LifecycleStageActive
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) handleSetExperimentTag(r *http.Request) (any, error) {
+	var req experimentRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.activeExperiment(req.ExperimentID)
+	if err != nil {
+		return nil, err
+	}
+	err = validateTag(req.Key, req.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	i := indexFunc(e.Tags, func(t *mlflow.ExperimentTag) bool { return t.Key == req.Key })
+	if i >= 0 {
+		e.Tags[i].Value = req.Value
+	} else {
+		e.Tags = append(e.Tags, &mlflow.ExperimentTag{Key: req.Key, Value: req.Value})
+	}
+	return nil, nil
+}
+
+func (s *Server) handleDeleteExperimentTag(r *http.Request) (any, error) {
+	var req experimentRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.activeExperiment(req.ExperimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	i := indexFunc(e.Tags, func(t *mlflow.ExperimentTag) bool { return t.Key == req.Key })
+	if i < 0 {
+		return nil, errNotFound("No tag with name: %s in experiment with id %s", req.Key, req.ExperimentID)
+	}
+	e.Tags = append(e.Tags[:i], e.Tags[i+1:]...)
+	return nil, nil
+}
@@ -0,0 +1,624 @@
+package mlflowtest
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/codeocean/go-mlflow/internal/search"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+type model struct {
+	*mlflow.RegisteredModel
+	versions    []*mlflow.ModelVersion
+	nextVersion int
+}
+
+func (s *Server) registerModels(mux *router) {
+	mux.HandleFunc("POST "+apiPrefix+"registered-models/create", s.handle(s.handleCreateModel))
+	mux.HandleFunc("GET "+apiPrefix+"registered-models/get", s.handle(s.handleGetModel))
+	mux.HandleFunc("PATCH "+apiPrefix+"registered-models/update", s.handle(s.handleUpdateModel))
+	mux.HandleFunc("POST "+apiPrefix+"registered-models/rename", s.handle(s.handleRenameModel))
+	mux.HandleFunc("DELETE "+apiPrefix+"registered-models/delete", s.handle(s.handleDeleteModel))
+	mux.HandleFunc("GET "+apiPrefix+"registered-models/search", s.handle(s.handleSearchModels))
+	mux.HandleFunc("POST "+apiPrefix+"registered-models/set-tag", s.handle(s.handleSetModelTag))
+	mux.HandleFunc("DELETE "+apiPrefix+"registered-models/delete-tag", s.handle(s.handleDeleteModelTag))
+	mux.HandleFunc("POST "+apiPrefix+"registered-models/alias", s.handle(s.handleSetAlias))
+	mux.HandleFunc("DELETE "+apiPrefix+"registered-models/alias", s.handle(s.handleDeleteAlias))
+	mux.HandleFunc("GET "+apiPrefix+"registered-models/alias", s.handle(s.handleGetVersionByAlias))
+
+	mux.HandleFunc("POST "+apiPrefix+"model-versions/create", s.handle(s.handleCreateVersion))
+	mux.HandleFunc("GET "+apiPrefix+"model-versions/get", s.handle(s.handleGetVersion))
+	mux.HandleFunc("PATCH "+apiPrefix+"model-versions/update", s.handle(s.handleUpdateVersion))
+	mux.HandleFunc("DELETE "+apiPrefix+"model-versions/delete", s.handle(s.handleDeleteVersion))
+	mux.HandleFunc("POST "+apiPrefix+"model-versions/transition-stage", s.handle(s.handleTransitionStage))
+	mux.HandleFunc("GET "+apiPrefix+"model-versions/search", s.handle(s.handleSearchVersions))
+	mux.HandleFunc("POST "+apiPrefix+"model-versions/set-tag", s.handle(s.handleSetVersionTag))
+	mux.HandleFunc("DELETE "+apiPrefix+"model-versions/delete-tag", s.handle(s.handleDeleteVersionTag))
+	mux.HandleFunc("GET "+apiPrefix+"model-versions/get-download-uri", s.handle(s.handleGetDownloadURI))
+}
+
+// modelRequest is the body of the requests on registered models and model versions.
+type modelRequest struct {
+	Name        string  `json:"name"`
+	NewName     string  `json:"new_name"`
+	Version     string  `json:"version"`
+	Description *string `json:"description"`
+	Key         string  `json:"key"`
+	Value       string  `json:"value"`
+	Alias       string  `json:"alias"`
+
+	Stage                   string `json:"stage"`
+	ArchiveExistingVersions bool   `json:"archive_existing_versions"`
+}
+
+// searchRequest is the query of the searches of registered models and model versions.
+type searchRequest struct {
+	Filter     string   `json:"filter"`
+	MaxResults int64    `json:"max_results"`
+	OrderBy    []string `json:"order_by"`
+	PageToken  string   `json:"page_token"`
+}
+
+func (s *Server) model(name string) (*model, error) {
+	if name == "" {
+		return nil, errMissing("name")
+	}
+	m, ok := s.models[name]
+	if !ok {
+		return nil, errNotFound("Registered Model with name=%s not found", name)
+	}
+	return m, nil
+}
+
+func (s *Server) version(name, version string) (*model, *mlflow.ModelVersion, error) {
+	m, err := s.model(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if version == "" {
+		return nil, nil, errMissing("version")
+	}
+	for _, v := range m.versions {
+		if v.Version == version {
+			return m, v, nil
+		}
+	}
+	return nil, nil, errNotFound("Model Version (name=%s, version=%s) not found", name, version)
+}
+
+// view returns the registered model as returned by the server, with the latest version of
+// each stage.
+func (m *model) view() *mlflow.RegisteredModel {
+	rm := *m.RegisteredModel
+	rm.LatestVersions = nil
+	latest := map[mlflow.ModelVersionStage]*mlflow.ModelVersion{}
+	for _, v := range m.versions {
+		latest[mlflow.ModelVersionStage(v.CurrentStage)] = v
+	}
+	for _, stage := range []mlflow.ModelVersionStage{mlflow.ModelVersionStageNone, mlflow.ModelVersionStageStaging, mlflow.ModelVersionStageProduction, mlflow.ModelVersionStageArchived} {
+		if v, ok := latest[stage]; ok {
+			rm.LatestVersions = append(rm.LatestVersions, m.versionView(v))
+		}
+	}
+	return &rm
+}
+
+// versionView returns a model version as returned by the server, with its aliases.
+func (m *model) versionView(v *mlflow.ModelVersion) *mlflow.ModelVersion {
+	mv := *v
+	mv.Aliases = nil
+	for _, a := range m.Aliases {
+		if a.Version == v.Version {
+			mv.Aliases = append(mv.Aliases, a.Alias)
+		}
+	}
+	return &mv
+}
+
+func (m *model) touch() {
+	m.LastUpdatedTimestamp = now()
+}
+
+func (s *Server) handleCreateModel(r *http.Request) (any, error) {
+	var req mlflow.RegisteredModelCreateOptions
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, errMissing("name")
+	}
+	if _, ok := s.models[req.Name]; ok {
+		return nil, errExists("Registered Model (name=%s) already exists.", req.Name)
+	}
+	for _, t := range req.Tags {
+		err = validateTag(t.Key, t.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	t := now()
+	m := &model{
+		RegisteredModel: &mlflow.RegisteredModel{
+			Name:                 req.Name,
+			CreationTimestamp:    t,
+			LastUpdatedTimestamp: t,
+			Description:          req.Description,
+			Tags:                 req.Tags,
+		},
+		nextVersion: 1,
+	}
+	s.models[req.Name] = m
+	return map[string]any{"registered_model": m.view()}, nil
+}
+
+func (s *Server) handleGetModel(r *http.Request) (any, error) {
+	m, err := s.model(r.URL.Query().Get("name"))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"registered_model": m.view()}, nil
+}
+
+func (s *Server) handleUpdateModel(r *http.Request) (any, error) {
+	var req modelRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.model(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Description != nil {
+		m.Description = *req.Description
+	}
+	m.touch()
+	return map[string]any{"registered_model": m.view()}, nil
+}
+
+func (s *Server) handleRenameModel(r *http.Request) (any, error) {
+	var req modelRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.model(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if req.NewName == "" {
+		return nil, errMissing("new_name")
+	}
+	if _, ok := s.models[req.NewName]; ok && req.NewName != req.Name {
+		return nil, errExists("Registered Model (name=%s) already exists.", req.NewName)
+	}
+
+	delete(s.models, m.Name)
+	m.Name = req.NewName
+	for _, v := range m.versions {
+		v.Name = req.NewName
+	}
+	m.touch()
+	s.models[m.Name] = m
+	return map[string]any{"registered_model": m.view()}, nil
+}
+
+func (s *Server) handleDeleteModel(r *http.Request) (any, error) {
+	var req modelRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.model(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	delete(s.models, m.Name)
+	return nil, nil
+}
+
+func (s *Server) handleSearchModels(r *http.Request) (any, error) {
+	var req searchRequest
+	err := decodeQuery(r.URL.Query(), &req)
+	if err != nil {
+		return nil, err
+	}
+	max, err := maxResults(req.MaxResults, defaultMaxModels, maxMaxModels)
+	if err != nil {
+		return nil, err
+	}
+
+	var models []*model
+	for _, m := range s.models {
+		models = append(models, m)
+	}
+	page, next, err := find(models, modelLookup, req.Filter, req.OrderBy,
+		[]string{"name ASC"}, req.PageToken, max)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]*mlflow.RegisteredModel, len(page))
+	for i, m := range page {
+		views[i] = m.view()
+	}
+	return map[string]any{"registered_models": views, "next_page_token": next}, nil
+}
+
+func modelLookup(m *model) search.Lookup {
+	return func(kind, key string) (any, bool) {
+		switch kind {
+		case search.KindAttribute:
+			switch key {
+			case "name":
+				return m.Name, true
+			case "creation_timestamp":
+				return float64(m.CreationTimestamp), true
+			case "last_updated_timestamp", "timestamp":
+				return float64(m.LastUpdatedTimestamp), true
+			}
+		case search.KindTag:
+			for _, t := range m.Tags {
+				if t.Key == key {
+					return t.Value, true
+				}
+			}
+		}
+		return nil, false
+	}
+}
+
+func (s *Server) handleSetModelTag(r *http.Request) (any, error) {
+	var req modelRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.model(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	err = validateTag(req.Key, req.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	i := indexFunc(m.Tags, func(t *mlflow.RegisteredModelTag) bool { return t.Key == req.Key })
+	if i >= 0 {
+		m.Tags[i].Value = req.Value
+	} else {
+		m.Tags = append(m.Tags, &mlflow.RegisteredModelTag{Key: req.Key, Value: req.Value})
+	}
+	return nil, nil
+}
+
+func (s *Server) handleDeleteModelTag(r *http.Request) (any, error) {
+	var req modelRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.model(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	i := indexFunc(m.Tags, func(t *mlflow.RegisteredModelTag) bool { return t.Key == req.Key })
+	if i < 0 {
+		return nil, errNotFound("Registered model tag with key=%s not found", req.Key)
+	}
+	m.Tags = append(m.Tags[:i], m.Tags[i+1:]...)
+	return nil, nil
+}
+
+// reservedAlias matches the aliases reserved by MLflow for model URIs, "latest" and version
+// numbers such as "v1".
+var reservedAlias = regexp.MustCompile(`(?i)^(latest|v\d+)$`)
+
+func (s *Server) handleSetAlias(r *http.Request) (any, error) {
+	var req modelRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Alias == "" {
+		return nil, errMissing("alias")
+	}
+	if reservedAlias.MatchString(req.Alias) {
+		return nil, errInvalid("'%s' alias name (case insensitive) is reserved.", req.Alias)
+	}
+	m, _, err := s.version(req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	i := indexFunc(m.Aliases, func(a *mlflow.RegisteredModelAlias) bool { return a.Alias == req.Alias })
+	if i >= 0 {
+		m.Aliases[i].Version = req.Version
+	} else {
+		m.Aliases = append(m.Aliases, &mlflow.RegisteredModelAlias{Alias: req.Alias, Version: req.Version})
+	}
+	return nil, nil
+}
+
+func (s *Server) handleDeleteAlias(r *http.Request) (any, error) {
+	var req modelRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.model(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	m.Aliases = deleteFunc(m.Aliases, func(a *mlflow.RegisteredModelAlias) bool { return a.Alias == req.Alias })
+	return nil, nil
+}
+
+func (s *Server) handleGetVersionByAlias(r *http.Request) (any, error) {
+	var req modelRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.model(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	i := indexFunc(m.Aliases, func(a *mlflow.RegisteredModelAlias) bool { return a.Alias == req.Alias })
+	if i < 0 {
+		return nil, errNotFound("Registered model alias %s not found.", req.Alias)
+	}
+	_, v, err := s.version(m.Name, m.Aliases[i].Version)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"model_version": m.versionView(v)}, nil
+}
+
+func (s *Server) handleCreateVersion(r *http.Request) (any, error) {
+	var req mlflow.ModelVersionCreateOptions
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.model(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if req.Source == "" {
+		return nil, errMissing("source")
+	}
+	for _, t := range req.Tags {
+		err = validateTag(t.Key, t.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	t := now()
+	v := &mlflow.ModelVersion{
+		Name:                 m.Name,
+		Version:              strconv.Itoa(m.nextVersion),
+		CreationTimestamp:    t,
+		LastUpdatedTimestamp: t,
+		CurrentStage:         string(mlflow.ModelVersionStageNone),
+		Description:          req.Description,
+		Source:               req.Source,
+		RunID:                req.RunID,
+		RunLink:              req.RunLink,
+		Status:               mlflow.ModelVersionStatusReady,
+		Tags:                 req.Tags,
+	}
+	m.nextVersion++
+	m.versions = append(m.versions, v)
+	m.LastUpdatedTimestamp = t
+	return map[string]any{"model_version": m.versionView(v)}, nil
+}
+
+func (s *Server) handleGetVersion(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	m, v, err := s.version(q.Get("name"), q.Get("version"))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"model_version": m.versionView(v)}, nil
+}
+
+func (s *Server) handleUpdateVersion(r *http.Request) (any, error) {
+	var req modelRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	m, v, err := s.version(req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Description != nil {
+		v.Description = *req.Description
+	}
+	v.LastUpdatedTimestamp = now()
+	return map[string]any{"model_version": m.versionView(v)}, nil
+}
+
+func (s *Server) handleDeleteVersion(r *http.Request) (any, error) {
+	var req modelRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	m, v, err := s.version(req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	m.versions = deleteFunc(m.versions, func(other *mlflow.ModelVersion) bool { return other == v })
+	m.Aliases = deleteFunc(m.Aliases, func(a *mlflow.RegisteredModelAlias) bool { return a.Version == v.Version })
+	m.touch()
+	return nil, nil
+}
+
+// stages maps the lower case stages to the stages, which are case insensitive.
+var stages = map[string]mlflow.ModelVersionStage{
+	"none":       mlflow.ModelVersionStageNone,
+	"staging":    mlflow.ModelVersionStageStaging,
+	"production": mlflow.ModelVersionStageProduction,
+	"archived":   mlflow.ModelVersionStageArchived,
+}
+
+func (s *Server) handleTransitionStage(r *http.Request) (any, error) {
+	var req modelRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	m, v, err := s.version(req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	stage, ok := stages[strings.ToLower(req.Stage)]
+	if !ok {
+		return nil, errInvalid("Invalid Model Version stage: %s. Value must be one of None, Staging, Production, Archived.", req.Stage)
+	}
+
+	t := now()
+	if req.ArchiveExistingVersions && (stage == mlflow.ModelVersionStageStaging || stage == mlflow.ModelVersionStageProduction) {
+		for _, other := range m.versions {
+			if other != v && other.CurrentStage == string(stage) {
+				other.CurrentStage = string(mlflow.ModelVersionStageArchived)
+				other.LastUpdatedTimestamp = t
+			}
+		}
+	}
+	v.CurrentStage = string(stage)
+	v.LastUpdatedTimestamp = t
+	m.LastUpdatedTimestamp = t
+	return map[string]any{"model_version": m.versionView(v)}, nil
+}
+
+func (s *Server) handleSearchVersions(r *http.Request) (any, error) {
+	var req searchRequest
+	err := decodeQuery(r.URL.Query(), &req)
+	if err != nil {
+		return nil, err
+	}
+	max, err := maxResults(req.MaxResults, defaultMaxVersions, maxMaxVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []*mlflow.ModelVersion
+	for _, m := range s.models {
+		for _, v := range m.versions {
+			versions = append(versions, m.versionView(v))
+		}
+	}
+	page, next, err := find(versions, versionLookup, req.Filter, req.OrderBy,
+		[]string{"name ASC", "version_number DESC"}, req.PageToken, max)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"model_versions": page, "next_page_token": next}, nil
+}
+
+func versionLookup(v *mlflow.ModelVersion) search.Lookup {
+	return func(kind, key string) (any, bool) {
+		switch kind {
+		case search.KindAttribute:
+			switch key {
+			case "name":
+				return v.Name, true
+			case "version", "version_number":
+				n, err := strconv.ParseFloat(v.Version, 64)
+				return n, err == nil
+			case "run_id":
+				return v.RunID, true
+			case "source", "source_path":
+				return v.Source, true
+			case "current_stage":
+				return string(v.CurrentStage), true
+			case "creation_timestamp":
+				return float64(v.CreationTimestamp), true
+			case "last_updated_timestamp", "timestamp":
+				return float64(v.LastUpdatedTimestamp), true
+			}
+		case search.KindTag:
+			for _, t := range v.Tags {
+				if t.Key == key {
+					return t.Value, true
+				}
+			}
+		}
+		return nil, false
+	}
+}
+
+func (s *Server) handleSetVersionTag(r *http.Request) (any, error) {
+	var req modelRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	_, v, err := s.version(req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	err = validateTag(req.Key, req.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	i := indexFunc(v.Tags, func(t *mlflow.ModelVersionTag) bool { return t.Key == req.Key })
+	if i >= 0 {
+		v.Tags[i].Value = req.Value
+	} else {
+		v.Tags = append(v.Tags, &mlflow.ModelVersionTag{Key: req.Key, Value: req.Value})
+	}
+	return nil, nil
+}
+
+func (s *Server) handleDeleteVersionTag(r *http.Request) (any, error) {
+	var req modelRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	_, v, err := s.version(req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	i := indexFunc(v.Tags, func(t *mlflow.ModelVersionTag) bool { return t.Key == req.Key })
+	if i < 0 {
+		return nil, errNotFound("Model version tag with key=%s not found", req.Key)
+	}
+	v.Tags = append(v.Tags[:i], v.Tags[i+1:]...)
+	return nil, nil
+}
+
+// handleGetDownloadURI returns the source of a version, with the runs:/ sources resolved to
+// the artifact URIs of the runs.
+func (s *Server) handleGetDownloadURI(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	_, v, err := s.version(q.Get("name"), q.Get("version"))
+	if err != nil {
+		return nil, err
+	}
+
+	uri := v.Source
+	if strings.HasPrefix(uri, "runs:/") {
+		id, artifactPath, _ := strings.Cut(strings.TrimPrefix(uri, "runs:/"), "/")
+		if rn, ok := s.runs[id]; ok {
+			uri = strings.TrimSuffix(rn.Info.ArtifactUri+"/"+artifactPath, "/")
+		}
+	}
+	return map[string]any{"artifact_uri": uri}, nil
+}
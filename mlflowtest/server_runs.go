@@ -0,0 +1,571 @@
+package mlflowtest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/codeocean/go-mlflow/internal/search"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Tags set by the server.
+const (
+	tagRunName         = "mlflow.runName"
+	tagLogModelHistory = "mlflow.log-model.history"
+)
+
+type run struct {
+	*mlflow.Run
+	// history holds the values of the metrics by key, in the order they were logged.
+	history map[string][]*mlflow.Metric
+}
+
+func (s *Server) registerRuns(mux *router) {
+	mux.HandleFunc("POST "+apiPrefix+"runs/create", s.handle(s.handleCreateRun))
+	mux.HandleFunc("GET "+apiPrefix+"runs/get", s.handle(s.handleGetRun))
+	mux.HandleFunc("POST "+apiPrefix+"runs/search", s.handle(s.handleSearchRuns))
+	mux.HandleFunc("POST "+apiPrefix+"runs/update", s.handle(s.handleUpdateRun))
+	mux.HandleFunc("POST "+apiPrefix+"runs/delete", s.handle(s.handleDeleteRun))
+	mux.HandleFunc("POST "+apiPrefix+"runs/restore", s.handle(s.handleRestoreRun))
+	mux.HandleFunc("POST "+apiPrefix+"runs/set-tag", s.handle(s.handleSetRunTag))
+	mux.HandleFunc("POST "+apiPrefix+"runs/delete-tag", s.handle(s.handleDeleteRunTag))
+	mux.HandleFunc("POST "+apiPrefix+"runs/log-metric", s.handle(s.handleLogMetric))
+	mux.HandleFunc("POST "+apiPrefix+"runs/log-parameter", s.handle(s.handleLogParam))
+	mux.HandleFunc("POST "+apiPrefix+"runs/log-batch", s.handle(s.handleLogBatch))
+	mux.HandleFunc("POST "+apiPrefix+"runs/log-inputs", s.handle(s.handleLogInputs))
+	mux.HandleFunc("POST "+apiPrefix+"runs/log-model", s.handle(s.handleLogModel))
+	mux.HandleFunc("GET "+apiPrefix+"metrics/get-history", s.handle(s.handleGetMetricHistory))
+}
+
+func (s *Server) run(id string) (*run, error) {
+	if id == "" {
+		return nil, errMissing("run_id")
+	}
+	r, ok := s.runs[id]
+	if !ok {
+		return nil, errNotFound("Run with id=%s not found", id)
+	}
+	return r, nil
+}
+
+// activeRun returns a run which must be active to be modified.
+func (s *Server) activeRun(id string) (*run, error) {
+	r, err := s.run(id)
+	if err != nil {
+		return nil, err
+	}
+	if r.Info.IsDeleted() {
+		return nil, errInvalid("The run %s must be in the 'active' state. Current state is deleted.", id)
+	}
+	return r, nil
+}
+
+// runRequest is the body of the requests on runs.
+type runRequest struct {
+	RunID string `json:"run_id"`
+	// RunUUID is the deprecated run ID of older clients.
+	RunUUID      string           `json:"run_uuid"`
+	ExperimentID string           `json:"experiment_id"`
+	RunName      string           `json:"run_name"`
+	StartTime    int64            `json:"start_time"`
+	EndTime      int64            `json:"end_time"`
+	Status       mlflow.RunStatus `json:"status"`
+	Key          string           `json:"key"`
+	Value        any              `json:"value"`
+	Timestamp    int64            `json:"timestamp"`
+	Step         int64            `json:"step"`
+	Tags         []*mlflow.RunTag `json:"tags"`
+	Metrics      []*mlflow.Metric `json:"metrics"`
+	Params       []*mlflow.Param  `json:"params"`
+
+	Datasets []*mlflow.DatasetInput `json:"datasets"`
+}
+
+func (req *runRequest) id() string {
+	if req.RunID != "" {
+		return req.RunID
+	}
+	return req.RunUUID
+}
+
+func validateTag(key, value string) error {
+	err := validateKey("tag", key)
+	if err != nil {
+		return err
+	}
+	if len(value) > maxTagValueLength {
+		return errInvalid("Tag value '%.20s...' had length %d, which exceeded length limit of %d", value, len(value), maxTagValueLength)
+	}
+	return nil
+}
+
+func validateParam(key, value string) error {
+	err := validateKey("param", key)
+	if err != nil {
+		return err
+	}
+	if len(value) > maxParamValueLength {
+		return errInvalid("Param value '%.20s...' had length %d, which exceeded length limit of %d", value, len(value), maxParamValueLength)
+	}
+	return nil
+}
+
+func (s *Server) handleCreateRun(r *http.Request) (any, error) {
+	var req runRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.activeExperiment(req.ExperimentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range req.Tags {
+		err = validateTag(t.Key, t.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	id := newID()
+	name := req.RunName
+	for _, t := range req.Tags {
+		if t.Key == tagRunName && name == "" {
+			name = t.Value
+		}
+	}
+	if name == "" {
+		name = "run-" + id[:8]
+	}
+	startTime := req.StartTime
+	if startTime == 0 {
+		startTime = now()
+	}
+
+	rn := &run{
+		Run: &mlflow.Run{
+			Info: &mlflow.RunInfo{
+				RunID:          id,
+				RunName:        name,
+				ExperimentID:   e.ExperimentID,
+				Status:         mlflow.RunStatusRunning,
+				StartTime:      startTime,
+				ArtifactUri:    e.ArtifactLocation + "/" + id + "/artifacts",
+				LifecycleStage: mlflow.LifecycleStageActive,
+			},
+			Data:   &mlflow.RunData{},
+			Inputs: &mlflow.RunInputs{},
+		},
+		history: map[string][]*mlflow.Metric{},
+	}
+	for _, t := range req.Tags {
+		rn.setTag(t.Key, t.Value)
+	}
+	rn.setTag(tagRunName, name)
+	s.runs[id] = rn
+
+	return map[string]any{"run": rn.Run}, nil
+}
+
+func (r *run) setTag(key, value string) {
+	i := indexFunc(r.Data.Tags, func(t *mlflow.RunTag) bool { return t.Key == key })
+	if i >= 0 {
+		r.Data.Tags[i].Value = value
+		return
+	}
+	r.Data.Tags = append(r.Data.Tags, &mlflow.RunTag{Key: key, Value: value})
+}
+
+// checkParam checks that a param is not logged with another value, params cannot be changed
+// once logged.
+func (r *run) checkParam(key, value string) error {
+	for _, p := range r.Data.Params {
+		if p.Key == key && p.Value != value {
+			return errInvalid("Changing param values is not allowed. Param with key='%s' was already logged with value='%s' for run ID='%s'. Attempted logging new value '%s'.", key, p.Value, r.Info.RunID, value)
+		}
+	}
+	return nil
+}
+
+// logParam logs a param, checked by checkParam.
+func (r *run) logParam(key, value string) {
+	if indexFunc(r.Data.Params, func(p *mlflow.Param) bool { return p.Key == key }) >= 0 {
+		return
+	}
+	r.Data.Params = append(r.Data.Params, &mlflow.Param{Key: key, Value: value})
+}
+
+// logMetric logs a metric value and updates the latest value of the metric, the one at the
+// highest step, the most recent for the step.
+func (r *run) logMetric(m *mlflow.Metric) {
+	m = &mlflow.Metric{
+		Key:           m.Key,
+		Value:         m.Value,
+		Timestamp:     m.Timestamp,
+		Step:          m.Step,
+		ModelID:       m.ModelID,
+		DatasetName:   m.DatasetName,
+		DatasetDigest: m.DatasetDigest,
+	}
+	r.history[m.Key] = append(r.history[m.Key], m)
+
+	i := indexFunc(r.Data.Metrics, func(l *mlflow.Metric) bool { return l.Key == m.Key })
+	switch {
+	case i < 0:
+		r.Data.Metrics = append(r.Data.Metrics, m)
+	case m.Step > r.Data.Metrics[i].Step || (m.Step == r.Data.Metrics[i].Step && m.Timestamp >= r.Data.Metrics[i].Timestamp):
+		r.Data.Metrics[i] = m
+	}
+}
+
+func (s *Server) handleGetRun(r *http.Request) (any, error) {
+	var req runRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	rn, err := s.run(req.id())
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"run": rn.Run}, nil
+}
+
+func (s *Server) handleSearchRuns(r *http.Request) (any, error) {
+	var req mlflow.RunSearchOptions
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	max, err := maxResults(int64(req.MaxResults), defaultMaxRuns, maxMaxRuns)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []*mlflow.Run
+	for _, rn := range s.runs {
+		if indexFunc(req.ExperimentIDs, func(id string) bool { return id == rn.Info.ExperimentID }) >= 0 && matchesViewType(rn.Info.LifecycleStage, req.RunViewType) {
+			runs = append(runs, rn.Run)
+		}
+	}
+	page, next, err := find(runs, search.RunLookup, req.Filter, req.OrderBy,
+		[]string{"start_time DESC", "run_id ASC"}, req.PageToken, max)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"runs": page, "next_page_token": next}, nil
+}
+
+func (s *Server) handleUpdateRun(r *http.Request) (any, error) {
+	var req runRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	rn, err := s.activeRun(req.id())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Status != "" {
+		switch req.Status {
+		case mlflow.RunStatusRunning, mlflow.RunStatusScheduled, mlflow.RunStatusFinished, mlflow.RunStatusFailed, mlflow.RunStatusKilled:
+		default:
+			return nil, errInvalid("Invalid value \"%s\" for parameter 'status' supplied.", req.Status)
+		}
+		rn.Info.Status = req.Status
+	}
+	if req.EndTime != 0 {
+		rn.Info.EndTime = req.EndTime
+	}
+	if req.RunName != "" {
+		rn.Info.RunName = req.RunName
+		rn.setTag(tagRunName, req.RunName)
+	}
+	return map[string]any{"run_info": rn.Info}, nil
+}
+
+func (s *Server) handleDeleteRun(r *http.Request) (any, error) {
+	var req runRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	rn, err := s.run(req.id())
+	if err != nil {
+		return nil, err
+	}
+	rn.Info.LifecycleStage = mlflow.LifecycleStageDeleted
+	return nil, nil
+}
+
+func (s *Server) handleRestoreRun(r *http.Request) (any, error) {
+	var req runRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	rn, err := s.run(req.id())
+	if err != nil {
+		return nil, err
+	}
+	rn.Info.LifecycleStage = mlflow.LifecycleStageActive
+	return nil, nil
+}
+
+func (s *Server) handleSetRunTag(r *http.Request) (any, error) {
+	var req runRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	rn, err := s.activeRun(req.id())
+	if err != nil {
+		return nil, err
+	}
+	value, _ := req.Value.(string)
+	err = validateTag(req.Key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	rn.setTag(req.Key, value)
+	if req.Key == tagRunName {
+		rn.Info.RunName = value
+	}
+	return nil, nil
+}
+
+func (s *Server) handleDeleteRunTag(r *http.Request) (any, error) {
+	var req runRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	rn, err := s.activeRun(req.id())
+	if err != nil {
+		return nil, err
+	}
+
+	i := indexFunc(rn.Data.Tags, func(t *mlflow.RunTag) bool { return t.Key == req.Key })
+	if i < 0 {
+		return nil, errNotFound("No tag with name: %s in run with id %s", req.Key, rn.Info.RunID)
+	}
+	rn.Data.Tags = append(rn.Data.Tags[:i], rn.Data.Tags[i+1:]...)
+	return nil, nil
+}
+
+func (s *Server) handleLogMetric(r *http.Request) (any, error) {
+	var req runRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	rn, err := s.activeRun(req.id())
+	if err != nil {
+		return nil, err
+	}
+	err = validateKey("metric", req.Key)
+	if err != nil {
+		return nil, err
+	}
+	value, err := metricValue(req.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	rn.logMetric(&mlflow.Metric{Key: req.Key, Value: value, Timestamp: req.Timestamp, Step: req.Step})
+	return nil, nil
+}
+
+// metricValue returns the value of a metric, 0 when omitted.
+func metricValue(v any) (float64, error) {
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case nil:
+		return 0, nil
+	}
+	return 0, errInvalid("Invalid value %v for parameter 'value' supplied.", v)
+}
+
+func (s *Server) handleLogParam(r *http.Request) (any, error) {
+	var req runRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	rn, err := s.activeRun(req.id())
+	if err != nil {
+		return nil, err
+	}
+	value, _ := req.Value.(string)
+	err = validateParam(req.Key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rn.checkParam(req.Key, value)
+	if err != nil {
+		return nil, err
+	}
+	rn.logParam(req.Key, value)
+	return nil, nil
+}
+
+func (s *Server) handleLogBatch(r *http.Request) (any, error) {
+	var req runRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	rn, err := s.activeRun(req.id())
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(req.Metrics) > maxBatchMetrics:
+		return nil, errInvalid("A batch logging request can contain at most %d metrics. Got %d metrics. Please split up metrics across multiple requests and try again.", maxBatchMetrics, len(req.Metrics))
+	case len(req.Params) > maxBatchParams:
+		return nil, errInvalid("A batch logging request can contain at most %d params. Got %d params. Please split up params across multiple requests and try again.", maxBatchParams, len(req.Params))
+	case len(req.Tags) > maxBatchTags:
+		return nil, errInvalid("A batch logging request can contain at most %d tags. Got %d tags. Please split up tags across multiple requests and try again.", maxBatchTags, len(req.Tags))
+	case len(req.Metrics)+len(req.Params)+len(req.Tags) > maxBatchEntries:
+		return nil, errInvalid("A batch logging request can contain at most %d metrics, params, and tags in total. Got %d. Please split up the data across multiple requests and try again.", maxBatchEntries, len(req.Metrics)+len(req.Params)+len(req.Tags))
+	}
+
+	// Validate the whole batch before logging any of it.
+	for _, m := range req.Metrics {
+		err = validateKey("metric", m.Key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	params := map[string]string{}
+	for _, p := range req.Params {
+		err = validateParam(p.Key, p.Value)
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := params[p.Key]; ok && v != p.Value {
+			return nil, errInvalid("Duplicate parameter keys have been submitted: [%s]. Please ensure the request contains only one param value per param key.", p.Key)
+		}
+		params[p.Key] = p.Value
+		err = rn.checkParam(p.Key, p.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range req.Tags {
+		err = validateTag(t.Key, t.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, p := range req.Params {
+		rn.logParam(p.Key, p.Value)
+	}
+	for _, m := range req.Metrics {
+		rn.logMetric(m)
+	}
+	for _, t := range req.Tags {
+		rn.setTag(t.Key, t.Value)
+		if t.Key == tagRunName {
+			rn.Info.RunName = t.Value
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) handleLogInputs(r *http.Request) (any, error) {
+	var req runRequest
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	rn, err := s.activeRun(req.id())
+	if err != nil {
+		return nil, err
+	}
+	for _, in := range req.Datasets {
+		if in.Dataset == nil || in.Dataset.Name == "" || in.Dataset.Digest == "" {
+			return nil, errInvalid("Dataset name and digest are required.")
+		}
+	}
+
+	for _, in := range req.Datasets {
+		i := indexFunc(rn.Inputs.DatasetInputs, func(d *mlflow.DatasetInput) bool {
+			return d.Dataset.Name == in.Dataset.Name && d.Dataset.Digest == in.Dataset.Digest
+		})
+		if i < 0 {
+			rn.Inputs.DatasetInputs = append(rn.Inputs.DatasetInputs, in)
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) handleLogModel(r *http.Request) (any, error) {
+	var req struct {
+		RunID     string `json:"run_id"`
+		ModelJSON string `json:"model_json"`
+	}
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	rn, err := s.activeRun(req.RunID)
+	if err != nil {
+		return nil, err
+	}
+	var model json.RawMessage
+	err = json.Unmarshal([]byte(req.ModelJSON), &model)
+	if err != nil {
+		return nil, errInvalid("Malformed model info. \n %s \n is not a valid JSON.", req.ModelJSON)
+	}
+
+	// The history is a JSON list of the models logged to the run, as by MLflow.
+	var history []json.RawMessage
+	for _, t := range rn.Data.Tags {
+		if t.Key == tagLogModelHistory {
+			_ = json.Unmarshal([]byte(t.Value), &history)
+		}
+	}
+	b, err := json.Marshal(append(history, model))
+	if err != nil {
+		return nil, err
+	}
+	rn.setTag(tagLogModelHistory, string(b))
+	return nil, nil
+}
+
+func (s *Server) handleGetMetricHistory(r *http.Request) (any, error) {
+	var req struct {
+		RunID      string `json:"run_id"`
+		RunUUID    string `json:"run_uuid"`
+		MetricKey  string `json:"metric_key"`
+		MaxResults int64  `json:"max_results"`
+		PageToken  string `json:"page_token"`
+	}
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	if req.RunID == "" {
+		req.RunID = req.RunUUID
+	}
+	rn, err := s.run(req.RunID)
+	if err != nil {
+		return nil, err
+	}
+	if req.MetricKey == "" {
+		return nil, errMissing("metric_key")
+	}
+
+	page, next, err := search.Page(rn.history[req.MetricKey], req.PageToken, int(req.MaxResults))
+	if err != nil {
+		return nil, errInvalid("%v", err)
+	}
+	return map[string]any{"metrics": page, "next_page_token": next}, nil
+}
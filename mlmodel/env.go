@@ -0,0 +1,272 @@
+package mlmodel
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Files describing the Python environment of a model, at the root of its directory.
+const (
+	RequirementsFile = "requirements.txt"
+	CondaFile        = "conda.yaml"
+	PythonEnvFile    = "python_env.yaml"
+)
+
+// PythonEnv is the content of a python_env.yaml file, the virtualenv of a model.
+type PythonEnv struct {
+	// Python is the Python version, such as "3.10.12".
+	Python            string   `yaml:"python"`
+	BuildDependencies []string `yaml:"build_dependencies,omitempty"`
+	// Dependencies are pip requirements, usually "-r requirements.txt".
+	Dependencies []string `yaml:"dependencies,omitempty"`
+}
+
+// CondaEnv is the content of a conda.yaml file, the conda environment of a model.
+type CondaEnv struct {
+	Name     string
+	Channels []string
+	// Dependencies are conda requirements, such as "python=3.10.12".
+	Dependencies []string
+	// PipDependencies are the pip requirements of the pip entry of the dependencies.
+	PipDependencies []string
+}
+
+type condaEnvYAML struct {
+	Name         string      `yaml:"name,omitempty"`
+	Channels     []string    `yaml:"channels,omitempty"`
+	Dependencies []yaml.Node `yaml:"dependencies,omitempty"`
+}
+
+func (e *CondaEnv) MarshalYAML() (any, error) {
+	v := condaEnvYAML{Name: e.Name, Channels: e.Channels}
+	for _, dep := range e.Dependencies {
+		var n yaml.Node
+		err := n.Encode(dep)
+		if err != nil {
+			return nil, err
+		}
+		v.Dependencies = append(v.Dependencies, n)
+	}
+	if e.PipDependencies != nil {
+		var n yaml.Node
+		err := n.Encode(map[string][]string{"pip": e.PipDependencies})
+		if err != nil {
+			return nil, err
+		}
+		v.Dependencies = append(v.Dependencies, n)
+	}
+
+	return &v, nil
+}
+
+func (e *CondaEnv) UnmarshalYAML(value *yaml.Node) error {
+	var v condaEnvYAML
+	err := value.Decode(&v)
+	if err != nil {
+		return err
+	}
+
+	*e = CondaEnv{Name: v.Name, Channels: v.Channels}
+	for _, n := range v.Dependencies {
+		if n.Kind == yaml.ScalarNode {
+			e.Dependencies = append(e.Dependencies, n.Value)
+			continue
+		}
+
+		var pip struct {
+			Pip []string `yaml:"pip"`
+		}
+		err = n.Decode(&pip)
+		if err != nil {
+			return fmt.Errorf("mlmodel: invalid conda dependency: %w", err)
+		}
+		e.PipDependencies = append(e.PipDependencies, pip.Pip...)
+	}
+
+	return nil
+}
+
+// Environment is the Python environment of a model, the fields are nil for missing
+// files.
+type Environment struct {
+	Requirements []string
+	Conda        *CondaEnv
+	PythonEnv    *PythonEnv
+}
+
+// NewEnvironment returns the environment MLflow generates for a model needing the
+// Python version python and the pip requirements.
+func NewEnvironment(python string, requirements []string) *Environment {
+	return &Environment{
+		Requirements: requirements,
+		Conda: &CondaEnv{
+			Name:            "mlflow-env",
+			Channels:        []string{"conda-forge"},
+			Dependencies:    []string{"python=" + python, "pip"},
+			PipDependencies: requirements,
+		},
+		PythonEnv: &PythonEnv{
+			Python:            python,
+			BuildDependencies: []string{"pip", "setuptools", "wheel"},
+			Dependencies:      []string{"-r " + RequirementsFile},
+		},
+	}
+}
+
+// ParseRequirements parses a requirements.txt file, skipping blank lines and comments.
+func ParseRequirements(r io.Reader) ([]string, error) {
+	var res []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Like pip, only treat # as a comment at the start of a line or after whitespace,
+		// URLs may contain fragments.
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line != "" {
+			res = append(res, line)
+		}
+	}
+
+	return res, scanner.Err()
+}
+
+// ReadEnvironment reads the environment files of the model in dir.
+func ReadEnvironment(dir string) (*Environment, error) {
+	var env Environment
+
+	for _, name := range []string{RequirementsFile, CondaFile, PythonEnvFile} {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = env.parse(name, b)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &env, nil
+}
+
+// GetEnvironment downloads and parses the environment files of the model at uri, see
+// mlflow.ArtifactsService.ResolveURI.
+func GetEnvironment(ctx context.Context, artifacts *mlflow.ArtifactsService, uri string) (*Environment, error) {
+	repo, err := repository(ctx, artifacts, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := repo.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var env Environment
+	for _, f := range files {
+		name := path.Base(f.Path)
+		if f.IsDir || (name != RequirementsFile && name != CondaFile && name != PythonEnvFile) {
+			continue
+		}
+
+		var buf bytes.Buffer
+		err = repo.Get(ctx, name, &buf)
+		if err != nil {
+			return nil, err
+		}
+
+		err = env.parse(name, buf.Bytes())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &env, nil
+}
+
+func (e *Environment) parse(name string, b []byte) error {
+	var err error
+	switch name {
+	case RequirementsFile:
+		e.Requirements, err = ParseRequirements(bytes.NewReader(b))
+	case CondaFile:
+		e.Conda = &CondaEnv{}
+		err = yaml.Unmarshal(b, e.Conda)
+	case PythonEnvFile:
+		e.PythonEnv = &PythonEnv{}
+		err = yaml.Unmarshal(b, e.PythonEnv)
+	}
+	if err != nil {
+		return fmt.Errorf("mlmodel: invalid %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// WriteDir writes the environment files to dir, skipping the missing ones.
+func (e *Environment) WriteDir(dir string) error {
+	if e.Requirements != nil {
+		var buf bytes.Buffer
+		for _, req := range e.Requirements {
+			buf.WriteString(req + "\n")
+		}
+		err := os.WriteFile(filepath.Join(dir, RequirementsFile), buf.Bytes(), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	if e.Conda != nil {
+		err := writeYAMLFile(filepath.Join(dir, CondaFile), e.Conda)
+		if err != nil {
+			return err
+		}
+	}
+
+	if e.PythonEnv != nil {
+		err := writeYAMLFile(filepath.Join(dir, PythonEnvFile), e.PythonEnv)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeYAMLFile(path string, v any) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	err := enc.Encode(v)
+	if err != nil {
+		return err
+	}
+	err = enc.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
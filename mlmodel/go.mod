@@ -0,0 +1,8 @@
+module github.com/codeocean/go-mlflow/mlmodel
+
+go 1.19
+
+require (
+	github.com/codeocean/go-mlflow v0.0.0-20261016211052-97864ca6e3b0
+	gopkg.in/yaml.v3 v3.0.1
+)
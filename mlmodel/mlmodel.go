@@ -0,0 +1,133 @@
+// Package mlmodel reads and writes the files describing an MLflow model at the root of its
+// directory: the MLmodel file and the files of its Python environment.
+//
+//	m, err := mlmodel.Get(ctx, client.Artifacts, "models:/name@champion")
+//	if m.HasFlavor(mlmodel.FlavorPythonFunction) {
+//		...
+//	}
+package mlmodel
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// File is the name of the file describing an MLflow model, at the root of its directory.
+const File = "MLmodel"
+
+// FlavorPythonFunction is the flavor of the models loadable with mlflow.pyfunc.
+const FlavorPythonFunction = "python_function"
+
+// Model is the content of an MLmodel file.
+type Model struct {
+	ArtifactPath   string `yaml:"artifact_path,omitempty"`
+	RunID          string `yaml:"run_id,omitempty"`
+	ModelUUID      string `yaml:"model_uuid,omitempty"`
+	ModelID        string `yaml:"model_id,omitempty"`
+	UTCTimeCreated string `yaml:"utc_time_created,omitempty"`
+	MLflowVersion  string `yaml:"mlflow_version,omitempty"`
+	ModelSizeBytes int64  `yaml:"model_size_bytes,omitempty"`
+	// Flavors maps the names of the flavors of the model, such as "python_function" or
+	// "onnx", to their configuration.
+	Flavors           map[string]map[string]any `yaml:"flavors,omitempty"`
+	Signature         *mlflow.MLmodelSignature  `yaml:"signature,omitempty"`
+	SavedInputExample *SavedInputExampleInfo    `yaml:"saved_input_example_info,omitempty"`
+	Metadata          map[string]any            `yaml:"metadata,omitempty"`
+	DatabricksRuntime string                    `yaml:"databricks_runtime,omitempty"`
+}
+
+// SavedInputExampleInfo describes the input example saved with a model.
+type SavedInputExampleInfo struct {
+	// ArtifactPath is the path of the example, relative to the model directory.
+	ArtifactPath string `yaml:"artifact_path,omitempty"`
+	// Type is the type of the example, such as "dataframe", "ndarray" or "json_object".
+	Type         string `yaml:"type,omitempty"`
+	PandasOrient string `yaml:"pandas_orient,omitempty"`
+	Format       string `yaml:"format,omitempty"`
+}
+
+// Parse parses the content of an MLmodel file.
+func Parse(r io.Reader) (*Model, error) {
+	var m Model
+	err := yaml.NewDecoder(r).Decode(&m)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Read reads the MLmodel file of the model in dir.
+func Read(dir string) (*Model, error) {
+	f, err := os.Open(filepath.Join(dir, File))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Get downloads and parses the MLmodel file of the model at uri, such as
+// models:/name@alias or runs:/run_id/model, see mlflow.ArtifactsService.ResolveURI.
+func Get(ctx context.Context, artifacts *mlflow.ArtifactsService, uri string) (*Model, error) {
+	repo, err := repository(ctx, artifacts, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = repo.Get(ctx, File, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(&buf)
+}
+
+func repository(ctx context.Context, artifacts *mlflow.ArtifactsService, uri string) (mlflow.ArtifactRepository, error) {
+	source, err := artifacts.ResolveURI(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return artifacts.Repository(ctx, source)
+}
+
+// Encode writes the MLmodel file.
+func (m *Model) Encode(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+
+	err := enc.Encode(m)
+	if err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+// FlavorNames returns the sorted names of the flavors of the model.
+func (m *Model) FlavorNames() []string {
+	names := make([]string, 0, len(m.Flavors))
+	for name := range m.Flavors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// HasFlavor reports whether the model has the flavor.
+func (m *Model) HasFlavor(name string) bool {
+	_, ok := m.Flavors[name]
+	return ok
+}
@@ -0,0 +1,238 @@
+// Package oteltraces implements an OpenTelemetry span exporter recording the traces of Go
+// applications instrumented with OpenTelemetry as MLflow traces.
+//
+//	exporter := oteltraces.NewExporter(client, experimentID, nil)
+//	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+//	otel.SetTracerProvider(tp)
+//	defer tp.Shutdown(ctx)
+package oteltraces
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Defaults of ExporterOptions.
+const (
+	DefaultPendingTTL       = 10 * time.Minute
+	DefaultMaxPendingTraces = 1000
+)
+
+// ExporterOptions bound the spans an exporter buffers.
+type ExporterOptions struct {
+	// PendingTTL is how long the spans of a trace are buffered waiting for its root span,
+	// DefaultPendingTTL if zero.
+	PendingTTL time.Duration
+	// MaxPendingTraces is the number of traces whose spans are buffered, the oldest traces
+	// being recorded beyond it, DefaultMaxPendingTraces if zero.
+	MaxPendingTraces int
+}
+
+// Exporter records OpenTelemetry traces as MLflow traces in an experiment. MLflow traces are
+// uploaded at once, so the spans of a trace are buffered until its root span, the span
+// without local parent, is exported. The traces whose root span was not exported within
+// the PendingTTL of the options, or beyond their MaxPendingTraces, are recorded without it,
+// on the next export, as are the traces still buffered on Shutdown; the spans of such
+// traces exported later make another trace.
+type Exporter struct {
+	client       *mlflow.Client
+	experimentID string
+	opts         ExporterOptions
+
+	mu      sync.Mutex
+	pending map[trace.TraceID]*pendingTrace
+}
+
+// pendingTrace is a trace whose root span was not exported yet.
+type pendingTrace struct {
+	spans []*mlflow.Span
+	// start is when the first span was buffered.
+	start time.Time
+}
+
+var _ sdktrace.SpanExporter = (*Exporter)(nil)
+
+// NewExporter returns an exporter recording traces in the experiment experimentID. opts may
+// be nil.
+func NewExporter(client *mlflow.Client, experimentID string, opts *ExporterOptions) *Exporter {
+	e := &Exporter{
+		client:       client,
+		experimentID: experimentID,
+		pending:      map[trace.TraceID]*pendingTrace{},
+	}
+	if opts != nil {
+		e.opts = *opts
+	}
+	if e.opts.PendingTTL <= 0 {
+		e.opts.PendingTTL = DefaultPendingTTL
+	}
+	if e.opts.MaxPendingTraces <= 0 {
+		e.opts.MaxPendingTraces = DefaultMaxPendingTraces
+	}
+	return e
+}
+
+// ExportSpans buffers the spans, and records the traces whose root span is among them, and
+// the buffered traces beyond the limits of the options.
+func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	var complete []trace.TraceID
+	now := time.Now()
+
+	e.mu.Lock()
+	for _, s := range spans {
+		id := s.SpanContext().TraceID()
+		p := e.pending[id]
+		if p == nil {
+			p = &pendingTrace{start: now}
+			e.pending[id] = p
+		}
+		p.spans = append(p.spans, convertSpan(s))
+		if !s.Parent().IsValid() || s.Parent().IsRemote() {
+			complete = append(complete, id)
+		}
+	}
+	traces := make([][]*mlflow.Span, 0, len(complete))
+	for _, id := range complete {
+		if p, ok := e.pending[id]; ok {
+			traces = append(traces, p.spans)
+			delete(e.pending, id)
+		}
+	}
+	expired := e.expire(now)
+	e.mu.Unlock()
+
+	var errs []error
+	for _, spans := range traces {
+		_, err := e.client.Traces.Log(ctx, e.experimentID, &mlflow.TraceData{Spans: spans})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	errs = append(errs, e.logIncomplete(ctx, expired))
+
+	return errors.Join(errs...)
+}
+
+// expire removes the buffered traces older than the PendingTTL of the options, then the
+// oldest traces beyond their MaxPendingTraces, and returns their spans. e.mu must be held.
+func (e *Exporter) expire(now time.Time) [][]*mlflow.Span {
+	var expired [][]*mlflow.Span
+	var ids []trace.TraceID
+	for id, p := range e.pending {
+		if now.Sub(p.start) >= e.opts.PendingTTL {
+			expired = append(expired, p.spans)
+			delete(e.pending, id)
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	if len(ids) > e.opts.MaxPendingTraces {
+		sort.Slice(ids, func(i, j int) bool {
+			return e.pending[ids[i]].start.Before(e.pending[ids[j]].start)
+		})
+		for _, id := range ids[:len(ids)-e.opts.MaxPendingTraces] {
+			expired = append(expired, e.pending[id].spans)
+			delete(e.pending, id)
+		}
+	}
+
+	return expired
+}
+
+// Shutdown records the traces whose root span was not exported, with the spans whose parent
+// is missing as root spans.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	pending := e.pending
+	e.pending = map[trace.TraceID]*pendingTrace{}
+	e.mu.Unlock()
+
+	traces := make([][]*mlflow.Span, 0, len(pending))
+	for _, p := range pending {
+		traces = append(traces, p.spans)
+	}
+	return e.logIncomplete(ctx, traces)
+}
+
+// logIncomplete records traces whose root span was not exported, with the spans whose parent
+// is missing as root spans.
+func (e *Exporter) logIncomplete(ctx context.Context, traces [][]*mlflow.Span) error {
+	var errs []error
+	for _, spans := range traces {
+		ids := map[string]bool{}
+		for _, s := range spans {
+			ids[s.Context.SpanID] = true
+		}
+		for _, s := range spans {
+			if !ids[s.ParentID] {
+				s.ParentID = ""
+			}
+		}
+
+		_, err := e.client.Traces.Log(ctx, e.experimentID, &mlflow.TraceData{Spans: spans})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func convertSpan(s sdktrace.ReadOnlySpan) *mlflow.Span {
+	res := &mlflow.Span{
+		Name: s.Name(),
+		Context: mlflow.SpanContext{
+			SpanID:  "0x" + s.SpanContext().SpanID().String(),
+			TraceID: "0x" + s.SpanContext().TraceID().String(),
+		},
+		StartTime:     s.StartTime().UnixNano(),
+		EndTime:       s.EndTime().UnixNano(),
+		StatusMessage: s.Status().Description,
+		Attributes:    attributes(s.Attributes()),
+	}
+	if s.Parent().IsValid() && !s.Parent().IsRemote() {
+		res.ParentID = "0x" + s.Parent().SpanID().String()
+	}
+
+	switch s.Status().Code {
+	case codes.Ok:
+		res.StatusCode = mlflow.SpanStatusOK
+	case codes.Error:
+		res.StatusCode = mlflow.SpanStatusError
+	default:
+		res.StatusCode = mlflow.SpanStatusUnset
+	}
+
+	if _, ok := res.Attributes[mlflow.SpanAttributeType]; !ok {
+		res.Attributes[mlflow.SpanAttributeType] = mlflow.SpanTypeUnknown
+	}
+
+	for _, event := range s.Events() {
+		res.Events = append(res.Events, &mlflow.SpanEvent{
+			Name:       event.Name,
+			Timestamp:  event.Time.UnixNano(),
+			Attributes: attributes(event.Attributes),
+		})
+	}
+
+	return res
+}
+
+func attributes(kvs []attribute.KeyValue) map[string]any {
+	res := make(map[string]any, len(kvs))
+	for _, kv := range kvs {
+		res[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	return res
+}
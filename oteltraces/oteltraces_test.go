@@ -0,0 +1,95 @@
+package oteltraces_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+	"github.com/codeocean/go-mlflow/oteltraces"
+)
+
+// newClient returns a client of a tracking server counting the traces started.
+func newClient(t *testing.T, started *int) *mlflow.Client {
+	t.Helper()
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPost && r.URL.Path == "/api/2.0/mlflow/traces" {
+			*started++
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"trace_info": &mlflow.TraceInfo{
+			RequestID:    "tr-1",
+			ExperimentID: "0",
+			Tags:         []*mlflow.TraceTag{{Key: mlflow.TraceTagArtifactLocation, Value: "mlflow-artifacts:/0/traces/tr-1/artifacts"}},
+		}})
+	}))
+	t.Cleanup(srv.Close)
+	client, err := mlflow.NewClient(srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// childSpan returns a span of the trace id whose parent, the root span, is never exported.
+func childSpan(id byte) sdktrace.ReadOnlySpan {
+	now := time.Now()
+	return tracetest.SpanStub{
+		Name:        "child",
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{id}, SpanID: trace.SpanID{id, 2}}),
+		Parent:      trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{id}, SpanID: trace.SpanID{id, 1}}),
+		StartTime:   now,
+		EndTime:     now,
+	}.Snapshot()
+}
+
+func TestExporterPendingLimits(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		opts *oteltraces.ExporterOptions
+		// wait is the time waited between the exports.
+		wait        time.Duration
+		wantStarted int
+	}{
+		{name: "within limits", wantStarted: 0},
+		{name: "expired", opts: &oteltraces.ExporterOptions{PendingTTL: 10 * time.Millisecond}, wait: 20 * time.Millisecond, wantStarted: 2},
+		{name: "too many traces", opts: &oteltraces.ExporterOptions{MaxPendingTraces: 1}, wantStarted: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var started int
+			e := oteltraces.NewExporter(newClient(t, &started), "0", tt.opts)
+
+			for i := byte(1); i <= 3; i++ {
+				err := e.ExportSpans(ctx, []sdktrace.ReadOnlySpan{childSpan(i)})
+				if err != nil {
+					t.Fatal(err)
+				}
+				time.Sleep(tt.wait)
+			}
+			if started != tt.wantStarted {
+				t.Errorf("recorded %d traces before shutdown, want %d", started, tt.wantStarted)
+			}
+
+			err := e.Shutdown(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if started != 3 {
+				t.Errorf("recorded %d traces, want 3", started)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Package s3artifacts implements an MLflow artifact repository for artifacts stored in
+// Amazon S3 under s3:// URIs, for deployments where the tracking server does not proxy
+// artifacts.
+//
+//	cfg, err := config.LoadDefaultConfig(ctx)
+//	client, err := mlflow.NewClient(nil, "http://localhost:5000",
+//		mlflow.WithArtifactRepository("s3", s3artifacts.Factory(s3.NewFromConfig(cfg))))
+package s3artifacts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Repository accesses the artifacts below an s3://bucket/prefix URI.
+type Repository struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+// NewRepository returns the repository of the artifacts below rootURI, an s3:// URI.
+func NewRepository(client *s3.Client, rootURI string) (*Repository, error) {
+	u, err := url.Parse(rootURI)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "s3" || u.Host == "" {
+		return nil, fmt.Errorf("s3artifacts: invalid S3 URI %q", rootURI)
+	}
+
+	return &Repository{
+		client: client,
+		bucket: u.Host,
+		prefix: strings.Trim(u.Path, "/"),
+	}, nil
+}
+
+// Factory returns a factory of repositories using client, to be registered with
+// mlflow.WithArtifactRepository for the "s3" scheme.
+func Factory(client *s3.Client) mlflow.ArtifactRepositoryFactory {
+	return func(ctx context.Context, rootURI string) (mlflow.ArtifactRepository, error) {
+		return NewRepository(client, rootURI)
+	}
+}
+
+func (r *Repository) Get(ctx context.Context, path string, w io.Writer) error {
+	out, err := r.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(r.key(path)),
+	})
+	if err != nil {
+		return err
+	}
+	defer out.Body.Close()
+
+	_, err = io.Copy(w, out.Body)
+	return err
+}
+
+func (r *Repository) Put(ctx context.Context, path string, body io.Reader) error {
+	_, err := manager.NewUploader(r.client).Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(r.key(path)),
+		Body:   body,
+	})
+	return err
+}
+
+func (r *Repository) List(ctx context.Context, path string) ([]*mlflow.FileInfo, error) {
+	prefix := r.key(path)
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	var files []*mlflow.FileInfo
+
+	paginator := s3.NewListObjectsV2Paginator(r.client, &s3.ListObjectsV2Input{
+		Bucket:    aws.String(r.bucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range page.CommonPrefixes {
+			files = append(files, &mlflow.FileInfo{
+				Path:  r.relative(strings.TrimSuffix(aws.ToString(p.Prefix), "/")),
+				IsDir: true,
+			})
+		}
+		for _, o := range page.Contents {
+			files = append(files, &mlflow.FileInfo{
+				Path:         r.relative(aws.ToString(o.Key)),
+				FileSize:     aws.ToInt64(o.Size),
+				LastModified: aws.ToTime(o.LastModified),
+				ContentHash:  contentHash(o.ETag),
+			})
+		}
+	}
+
+	return files, nil
+}
+
+func (r *Repository) Stat(ctx context.Context, path string) (*mlflow.FileInfo, error) {
+	out, err := r.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(r.key(path)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mlflow.FileInfo{
+		Path:         strings.Trim(path, "/"),
+		FileSize:     aws.ToInt64(out.ContentLength),
+		LastModified: aws.ToTime(out.LastModified),
+		ContentHash:  contentHash(out.ETag),
+	}, nil
+}
+
+// contentHash returns the mlflow.FileInfo.ContentHash of an object given its ETag, which is
+// the MD5 of the content for objects not uploaded in parts, empty if unknown.
+func contentHash(etag *string) string {
+	sum := strings.Trim(aws.ToString(etag), `"`)
+	if len(sum) != 32 || strings.Contains(sum, "-") {
+		return ""
+	}
+
+	return "md5:" + strings.ToLower(sum)
+}
+
+// key returns the object key of the artifact at path.
+func (r *Repository) key(path string) string {
+	path = strings.Trim(path, "/")
+	if r.prefix == "" {
+		return path
+	}
+	if path == "" {
+		return r.prefix
+	}
+
+	return r.prefix + "/" + path
+}
+
+// relative returns the artifact path of an object key.
+func (r *Repository) relative(key string) string {
+	if r.prefix == "" {
+		return key
+	}
+
+	return strings.TrimPrefix(strings.TrimPrefix(key, r.prefix), "/")
+}
@@ -0,0 +1,148 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// MetricsOptions selects the metric values read by Metrics, all of them if empty.
+type MetricsOptions struct {
+	ExperimentIDs []string
+	RunIDs        []string
+	// Keys are the keys of the metrics, all of them if empty.
+	Keys []string
+}
+
+// RunMetric is a metric value of a run.
+type RunMetric struct {
+	RunID string
+	mlflow.Metric
+}
+
+// MetricRows streams the result of Metrics.
+type MetricRows struct {
+	rows *sql.Rows
+	cur  *RunMetric
+	err  error
+}
+
+// Next advances to the next metric value, returning false when there are no more values or
+// an error occurred.
+func (r *MetricRows) Next() bool {
+	if r.err != nil || !r.rows.Next() {
+		return false
+	}
+
+	var isNaN bool
+	m := &RunMetric{}
+	r.err = r.rows.Scan(&m.RunID, &m.Key, &m.Value, &m.Timestamp, &m.Step, &isNaN)
+	if r.err != nil {
+		r.err = fmt.Errorf("sqlstore: %w", r.err)
+		return false
+	}
+	m.Value = metricValue(m.Value, isNaN)
+	r.cur = m
+	return true
+}
+
+// Value returns the current metric value.
+func (r *MetricRows) Value() *RunMetric {
+	return r.cur
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (r *MetricRows) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	if err := r.rows.Err(); err != nil {
+		return fmt.Errorf("sqlstore: %w", err)
+	}
+	return nil
+}
+
+// Close releases the connection of the query, it must be called when the iteration stops
+// early.
+func (r *MetricRows) Close() error {
+	return r.rows.Close()
+}
+
+// Metrics streams the full histories of metrics, ordered by run, key, step and timestamp,
+// from a single query rather than one paginated request per metric and run.
+func (s *Store) Metrics(ctx context.Context, opts *MetricsOptions) (*MetricRows, error) {
+	if opts == nil {
+		opts = &MetricsOptions{}
+	}
+
+	q := &query{}
+	q.WriteString(`SELECT m.run_uuid, m."key", m."value", m."timestamp", m.step, m.is_nan FROM metrics m`)
+	where := " WHERE "
+	if len(opts.ExperimentIDs) > 0 {
+		ids, err := experimentIDs(opts.ExperimentIDs)
+		if err != nil {
+			return nil, err
+		}
+		q.WriteString(" JOIN runs r ON r.run_uuid = m.run_uuid WHERE ")
+		q.in("r.experiment_id", ids)
+		where = " AND "
+	}
+	if len(opts.RunIDs) > 0 {
+		q.WriteString(where)
+		q.in("m.run_uuid", strings2any(opts.RunIDs))
+		where = " AND "
+	}
+	if len(opts.Keys) > 0 {
+		q.WriteString(where)
+		q.in(`m."key"`, strings2any(opts.Keys))
+	}
+	q.WriteString(` ORDER BY m.run_uuid, m."key", m.step, m."timestamp"`)
+
+	rows, err := s.query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricRows{rows: rows}, nil
+}
+
+// WriteMetricsCSV writes the metric values selected by opts as long-format CSV, with run_id,
+// key, step, timestamp and value columns.
+func (s *Store) WriteMetricsCSV(ctx context.Context, w io.Writer, opts *MetricsOptions) error {
+	rows, err := s.Metrics(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	cw := csv.NewWriter(w)
+
+	err = cw.Write([]string{"run_id", "key", "step", "timestamp", "value"})
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		m := rows.Value()
+		err = cw.Write([]string{
+			m.RunID,
+			m.Key,
+			strconv.FormatInt(m.Step, 10),
+			strconv.FormatInt(m.Timestamp, 10),
+			strconv.FormatFloat(m.Value, 'g', -1, 64),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
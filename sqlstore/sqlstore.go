@@ -0,0 +1,355 @@
+// Package sqlstore reads the experiments, runs and metrics of the database of an MLflow
+// tracking server directly, for bulk exports which would take too many requests to the REST
+// API, such as the full histories of the metrics of thousands of runs.
+//
+// The store only runs SELECT queries against the schema of MLflow's SQLAlchemy store, and
+// should be given a read-only database user. The database driver is registered by the
+// caller:
+//
+//	db, err := sql.Open("pgx", "postgres://reader@db/mlflow")
+//	store := sqlstore.New(db, sqlstore.Postgres)
+//	rows, err := store.Metrics(ctx, &sqlstore.MetricsOptions{ExperimentIDs: []string{"3"}})
+//	defer rows.Close()
+//	for rows.Next() {
+//		m := rows.Value()
+//	}
+//	err = rows.Err()
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Dialect is the SQL dialect of a database.
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	MySQL    Dialect = "mysql"
+	SQLite   Dialect = "sqlite"
+)
+
+// runBatchSize is the number of runs whose params, tags and metrics are read at once.
+const runBatchSize = 500
+
+// Store reads a tracking database.
+type Store struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+func New(db *sql.DB, dialect Dialect) *Store {
+	return &Store{db: db, dialect: dialect}
+}
+
+// query is a query being built, written with double-quoted identifiers and ? placeholders,
+// which are rewritten for the dialect of the store.
+type query struct {
+	strings.Builder
+	args []any
+}
+
+// in writes a "column IN (...)" condition.
+func (q *query) in(column string, values []any) {
+	q.WriteString(column + " IN (")
+	for i, v := range values {
+		if i > 0 {
+			q.WriteString(", ")
+		}
+		q.WriteString("?")
+		q.args = append(q.args, v)
+	}
+	q.WriteString(")")
+}
+
+// sql returns the query in the dialect of the store.
+func (s *Store) sql(q string) string {
+	switch s.dialect {
+	case MySQL:
+		return strings.ReplaceAll(q, `"`, "`")
+	case Postgres:
+		var b strings.Builder
+		n := 0
+		for _, r := range q {
+			if r == '?' {
+				n++
+				b.WriteString("$" + strconv.Itoa(n))
+				continue
+			}
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+	return q
+}
+
+func (s *Store) query(ctx context.Context, q *query) (*sql.Rows, error) {
+	rows, err := s.db.QueryContext(ctx, s.sql(q.String()), q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlstore: %w", err)
+	}
+	return rows, nil
+}
+
+// lifecycleStages returns the lifecycle stages of a view type, nil for all of them.
+func lifecycleStages(viewType mlflow.ViewType) []any {
+	switch viewType {
+	case mlflow.ViewTypeAll:
+		return nil
+	case mlflow.ViewTypeDeletedOnly:
+		return []any{string(mlflow.LifecycleStageDeleted)}
+	}
+	return []any{string(mlflow.LifecycleStageActive)}
+}
+
+// experimentIDs converts experiment IDs to the integers of the experiment_id columns.
+func experimentIDs(ids []string) ([]any, error) {
+	res := make([]any, len(ids))
+	for i, id := range ids {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("sqlstore: invalid experiment ID %q", id)
+		}
+		res[i] = n
+	}
+	return res, nil
+}
+
+func strings2any(values []string) []any {
+	res := make([]any, len(values))
+	for i, v := range values {
+		res[i] = v
+	}
+	return res
+}
+
+// metricValue returns the value of a metric row, NaN values being stored as 0 with is_nan.
+func metricValue(value float64, isNaN bool) float64 {
+	if isNaN {
+		return math.NaN()
+	}
+	return value
+}
+
+// Experiments returns the experiments of a view type, ACTIVE_ONLY if empty, ordered by ID.
+func (s *Store) Experiments(ctx context.Context, viewType mlflow.ViewType) ([]*mlflow.Experiment, error) {
+	q := &query{}
+	q.WriteString(`SELECT experiment_id, name, artifact_location, lifecycle_stage, creation_time, last_update_time FROM experiments`)
+	if stages := lifecycleStages(viewType); stages != nil {
+		q.WriteString(" WHERE ")
+		q.in("lifecycle_stage", stages)
+	}
+	q.WriteString(" ORDER BY experiment_id")
+
+	rows, err := s.query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var experiments []*mlflow.Experiment
+	byID := map[string]*mlflow.Experiment{}
+	for rows.Next() {
+		var (
+			id                           int64
+			location                     sql.NullString
+			creationTime, lastUpdateTime sql.NullInt64
+		)
+		e := &mlflow.Experiment{}
+		err = rows.Scan(&id, &e.Name, &location, &e.LifecycleStage, &creationTime, &lastUpdateTime)
+		if err != nil {
+			return nil, fmt.Errorf("sqlstore: %w", err)
+		}
+		e.ExperimentID = strconv.FormatInt(id, 10)
+		e.ArtifactLocation = location.String
+		e.CreationTime = creationTime.Int64
+		e.LastUpdateTime = lastUpdateTime.Int64
+
+		experiments = append(experiments, e)
+		byID[e.ExperimentID] = e
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlstore: %w", err)
+	}
+
+	q = &query{}
+	q.WriteString(`SELECT experiment_id, "key", "value" FROM experiment_tags ORDER BY experiment_id, "key"`)
+	tags, err := s.query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer tags.Close()
+
+	for tags.Next() {
+		var id int64
+		tag := &mlflow.ExperimentTag{}
+		err = tags.Scan(&id, &tag.Key, &tag.Value)
+		if err != nil {
+			return nil, fmt.Errorf("sqlstore: %w", err)
+		}
+		if e, ok := byID[strconv.FormatInt(id, 10)]; ok {
+			e.Tags = append(e.Tags, tag)
+		}
+	}
+	if err := tags.Err(); err != nil {
+		return nil, fmt.Errorf("sqlstore: %w", err)
+	}
+
+	return experiments, nil
+}
+
+type RunsOptions struct {
+	ExperimentIDs []string
+	// ViewType selects the runs by lifecycle stage, ACTIVE_ONLY if empty.
+	ViewType mlflow.ViewType
+}
+
+// Runs returns the runs of experiments, with their params, tags and latest metrics, ordered
+// by start time, most recent first.
+func (s *Store) Runs(ctx context.Context, opts *RunsOptions) ([]*mlflow.Run, error) {
+	ids, err := experimentIDs(opts.ExperimentIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	q := &query{}
+	q.WriteString(`SELECT run_uuid, name, experiment_id, status, start_time, end_time, artifact_uri, lifecycle_stage FROM runs WHERE `)
+	q.in("experiment_id", ids)
+	if stages := lifecycleStages(opts.ViewType); stages != nil {
+		q.WriteString(" AND ")
+		q.in("lifecycle_stage", stages)
+	}
+	q.WriteString(" ORDER BY start_time DESC, run_uuid")
+
+	rows, err := s.query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []*mlflow.Run
+	for rows.Next() {
+		var (
+			name, status, artifactURI sql.NullString
+			experimentID              int64
+			startTime, endTime        sql.NullInt64
+		)
+		info := &mlflow.RunInfo{}
+		err = rows.Scan(&info.RunID, &name, &experimentID, &status, &startTime, &endTime, &artifactURI, &info.LifecycleStage)
+		if err != nil {
+			return nil, fmt.Errorf("sqlstore: %w", err)
+		}
+		info.RunName = name.String
+		info.ExperimentID = strconv.FormatInt(experimentID, 10)
+		info.Status = mlflow.RunStatus(status.String)
+		info.StartTime = startTime.Int64
+		info.EndTime = endTime.Int64
+		info.ArtifactUri = artifactURI.String
+
+		runs = append(runs, &mlflow.Run{Info: info, Data: &mlflow.RunData{}})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlstore: %w", err)
+	}
+	rows.Close()
+
+	for start := 0; start < len(runs); start += runBatchSize {
+		end := start + runBatchSize
+		if end > len(runs) {
+			end = len(runs)
+		}
+		err = s.readRunData(ctx, runs[start:end])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return runs, nil
+}
+
+// readRunData reads the params, tags and latest metrics of runs.
+func (s *Store) readRunData(ctx context.Context, runs []*mlflow.Run) error {
+	byID := map[string]*mlflow.RunData{}
+	ids := make([]any, len(runs))
+	for i, r := range runs {
+		byID[r.Info.RunID] = r.Data
+		ids[i] = r.Info.RunID
+	}
+
+	for _, table := range []string{"params", "tags"} {
+		q := &query{}
+		q.WriteString(`SELECT run_uuid, "key", "value" FROM ` + table + ` WHERE `)
+		q.in("run_uuid", ids)
+		q.WriteString(` ORDER BY run_uuid, "key"`)
+
+		err := s.scan(ctx, q, func(rows *sql.Rows) error {
+			var id, key string
+			var value sql.NullString
+			err := rows.Scan(&id, &key, &value)
+			if err != nil {
+				return err
+			}
+
+			d := byID[id]
+			if table == "params" {
+				d.Params = append(d.Params, &mlflow.Param{Key: key, Value: value.String})
+			} else {
+				d.Tags = append(d.Tags, &mlflow.RunTag{Key: key, Value: value.String})
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	q := &query{}
+	q.WriteString(`SELECT run_uuid, "key", "value", "timestamp", step, is_nan FROM latest_metrics WHERE `)
+	q.in("run_uuid", ids)
+	q.WriteString(` ORDER BY run_uuid, "key"`)
+
+	return s.scan(ctx, q, func(rows *sql.Rows) error {
+		var id string
+		var isNaN bool
+		m := &mlflow.Metric{}
+		err := rows.Scan(&id, &m.Key, &m.Value, &m.Timestamp, &m.Step, &isNaN)
+		if err != nil {
+			return err
+		}
+
+		m.Value = metricValue(m.Value, isNaN)
+		d := byID[id]
+		d.Metrics = append(d.Metrics, m)
+		return nil
+	})
+}
+
+// scan runs a query and calls f with each row.
+func (s *Store) scan(ctx context.Context, q *query, f func(rows *sql.Rows) error) error {
+	rows, err := s.query(ctx, q)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err = f(rows)
+		if err != nil {
+			return fmt.Errorf("sqlstore: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("sqlstore: %w", err)
+	}
+	return nil
+}
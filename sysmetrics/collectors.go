@@ -0,0 +1,166 @@
+package sysmetrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/disk"
+	"github.com/shirou/gopsutil/v4/mem"
+	"github.com/shirou/gopsutil/v4/net"
+)
+
+// megabyte is the unit of the sizes of the metrics, a million bytes as in the Python client.
+const megabyte = 1e6
+
+// collector adds the current values of metrics to values, by metric name.
+type collector interface {
+	collect(ctx context.Context, values map[string]float64) error
+}
+
+func newCollectors(ctx context.Context, cfg *Config) []collector {
+	collectors := []collector{cpuCollector{}, memoryCollector{}, diskCollector{path: cfg.DiskPath}, newNetworkCollector(ctx)}
+	smi, err := exec.LookPath("nvidia-smi")
+	if err == nil {
+		collectors = append(collectors, gpuCollector{path: smi})
+	}
+	return collectors
+}
+
+type cpuCollector struct{}
+
+// collect adds the CPU utilization since the previous call.
+func (cpuCollector) collect(ctx context.Context, values map[string]float64) error {
+	percent, err := cpu.PercentWithContext(ctx, 0, false)
+	if err != nil {
+		return fmt.Errorf("sysmetrics: cpu: %w", err)
+	}
+	if len(percent) > 0 {
+		values["cpu_utilization_percentage"] = percent[0]
+	}
+	return nil
+}
+
+type memoryCollector struct{}
+
+func (memoryCollector) collect(ctx context.Context, values map[string]float64) error {
+	m, err := mem.VirtualMemoryWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("sysmetrics: memory: %w", err)
+	}
+	values["system_memory_usage_megabytes"] = float64(m.Used) / megabyte
+	values["system_memory_usage_percentage"] = m.UsedPercent
+	return nil
+}
+
+type diskCollector struct {
+	path string
+}
+
+func (c diskCollector) collect(ctx context.Context, values map[string]float64) error {
+	u, err := disk.UsageWithContext(ctx, c.path)
+	if err != nil {
+		return fmt.Errorf("sysmetrics: disk: %w", err)
+	}
+	values["disk_usage_percentage"] = u.UsedPercent
+	values["disk_usage_megabytes"] = float64(u.Used) / megabyte
+	values["disk_available_megabytes"] = float64(u.Free) / megabyte
+	return nil
+}
+
+// networkCollector adds the bytes received and sent by all the interfaces since the monitor
+// started.
+type networkCollector struct {
+	initial *net.IOCountersStat
+}
+
+func newNetworkCollector(ctx context.Context) *networkCollector {
+	c := &networkCollector{}
+	c.initial, _ = c.counters(ctx)
+	return c
+}
+
+func (c *networkCollector) counters(ctx context.Context) (*net.IOCountersStat, error) {
+	counters, err := net.IOCountersWithContext(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("sysmetrics: network: %w", err)
+	}
+	if len(counters) == 0 {
+		return nil, fmt.Errorf("sysmetrics: network: no counters")
+	}
+	return &counters[0], nil
+}
+
+func (c *networkCollector) collect(ctx context.Context, values map[string]float64) error {
+	n, err := c.counters(ctx)
+	if err != nil {
+		return err
+	}
+	if c.initial == nil {
+		c.initial = n
+	}
+	values["network_receive_megabytes"] = float64(n.BytesRecv-c.initial.BytesRecv) / megabyte
+	values["network_transmit_megabytes"] = float64(n.BytesSent-c.initial.BytesSent) / megabyte
+	return nil
+}
+
+// gpuCollector samples the NVIDIA GPUs with nvidia-smi.
+type gpuCollector struct {
+	path string
+}
+
+// gpuFields are the fields queried from nvidia-smi, in the units of the Python client but
+// for the memory, in mebibytes.
+var gpuFields = []string{"index", "utilization.gpu", "memory.used", "memory.total", "power.draw", "power.limit"}
+
+func (c gpuCollector) collect(ctx context.Context, values map[string]float64) error {
+	cmd := exec.CommandContext(ctx, c.path, "--query-gpu="+strings.Join(gpuFields, ","), "--format=csv,noheader,nounits")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("sysmetrics: gpu: nvidia-smi: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	r := csv.NewReader(bytes.NewReader(out))
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = len(gpuFields)
+	records, err := r.ReadAll()
+	if err != nil {
+		return fmt.Errorf("sysmetrics: gpu: nvidia-smi: %w", err)
+	}
+
+	for _, rec := range records {
+		// Values the GPU does not support, such as the power of some models, are [N/A].
+		fields := map[string]float64{}
+		for i, s := range rec[1:] {
+			v, err := strconv.ParseFloat(s, 64)
+			if err == nil {
+				fields[gpuFields[i+1]] = v
+			}
+		}
+
+		prefix := "gpu_" + rec[0] + "_"
+		if used, ok := fields["memory.used"]; ok {
+			values[prefix+"memory_usage_megabytes"] = used * (1 << 20) / megabyte
+			if total := fields["memory.total"]; total > 0 {
+				values[prefix+"memory_usage_percentage"] = used / total * 100
+			}
+		}
+		if u, ok := fields["utilization.gpu"]; ok {
+			values[prefix+"utilization_percentage"] = u
+		}
+		if power, ok := fields["power.draw"]; ok {
+			values[prefix+"power_usage_watts"] = power
+			if limit := fields["power.limit"]; limit > 0 {
+				values[prefix+"power_usage_percentage"] = power / limit * 100
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// Package sysmetrics logs the utilization of the CPU, memory, disk, network and NVIDIA GPUs
+// of the host to a run, as system/ metrics named like the ones of the system metrics of the
+// MLflow Python client, so that the MLflow UI shows them in the system metrics of the run.
+//
+//	m := sysmetrics.Start(ctx, client, run.Info.RunID, nil)
+//	defer m.Stop()
+//	train(ctx)
+package sysmetrics
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// DefaultSamplingInterval is the default interval between samples.
+const DefaultSamplingInterval = 10 * time.Second
+
+// Config configures a monitor.
+type Config struct {
+	// SamplingInterval is the interval between samples, DefaultSamplingInterval if zero.
+	SamplingInterval time.Duration
+	// SamplesBeforeLogging is the number of samples averaged into each logged value, one if
+	// zero.
+	SamplesBeforeLogging int
+	// DiskPath is the path of the disk whose usage is sampled, the root directory if empty.
+	DiskPath string
+	// NodeID, if set, is inserted in the metric keys, as in system/<node id>/cpu_utilization_percentage,
+	// to tell apart the hosts of a distributed run.
+	NodeID string
+	// OnError is called with the errors of samples and logs, which are retried at the next
+	// interval.
+	OnError func(err error)
+}
+
+// Monitor samples the utilization of the host and logs it to a run.
+type Monitor struct {
+	client *mlflow.Client
+	runID  string
+	cfg    Config
+
+	collectors []collector
+	// samples are the values sampled since the last log, by metric name.
+	samples map[string][]float64
+	nSample int
+	step    int64
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// Start samples the utilization of the host in the background and logs it to the run of ID
+// runID, until ctx is done or Stop is called. GPUs are sampled with nvidia-smi, the command
+// line tool of the NVIDIA Management Library installed with the NVIDIA drivers, and are
+// skipped on hosts without it.
+func Start(ctx context.Context, client *mlflow.Client, runID string, cfg *Config) *Monitor {
+	m := &Monitor{
+		client:  client,
+		runID:   runID,
+		samples: map[string][]float64{},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if cfg != nil {
+		m.cfg = *cfg
+	}
+	if m.cfg.SamplingInterval <= 0 {
+		m.cfg.SamplingInterval = DefaultSamplingInterval
+	}
+	if m.cfg.SamplesBeforeLogging <= 0 {
+		m.cfg.SamplesBeforeLogging = 1
+	}
+	if m.cfg.DiskPath == "" {
+		m.cfg.DiskPath = "/"
+	}
+	m.collectors = newCollectors(ctx, &m.cfg)
+
+	go m.run(ctx)
+	return m
+}
+
+// Stop stops sampling, logs the samples not logged yet and waits for the monitor to return.
+func (m *Monitor) Stop() {
+	m.once.Do(func() { close(m.stop) })
+	<-m.done
+}
+
+func (m *Monitor) run(ctx context.Context) {
+	defer close(m.done)
+	ticker := time.NewTicker(m.cfg.SamplingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-m.stop:
+			if m.nSample > 0 {
+				m.log(ctx)
+			}
+			return
+		case <-ctx.Done():
+			return
+		}
+
+		m.sample(ctx)
+		if m.nSample >= m.cfg.SamplesBeforeLogging {
+			m.log(ctx)
+		}
+	}
+}
+
+func (m *Monitor) sample(ctx context.Context) {
+	values := map[string]float64{}
+	for _, c := range m.collectors {
+		err := c.collect(ctx, values)
+		if err != nil {
+			m.error(err)
+		}
+	}
+	for name, v := range values {
+		m.samples[name] = append(m.samples[name], v)
+	}
+	m.nSample++
+}
+
+// log logs the averages of the samples, rounded to one decimal as by the Python client.
+func (m *Monitor) log(ctx context.Context) {
+	prefix := "system/"
+	if m.cfg.NodeID != "" {
+		prefix += m.cfg.NodeID + "/"
+	}
+
+	now := time.Now().UnixMilli()
+	data := &mlflow.RunData{}
+	for _, name := range slices.Sorted(maps.Keys(m.samples)) {
+		values := m.samples[name]
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		data.Metrics = append(data.Metrics, &mlflow.Metric{
+			Key:       prefix + name,
+			Value:     math.Round(sum/float64(len(values))*10) / 10,
+			Timestamp: now,
+			Step:      m.step,
+		})
+	}
+	clear(m.samples)
+	m.nSample = 0
+	if len(data.Metrics) == 0 {
+		return
+	}
+
+	err := m.client.Runs.LogBatch(ctx, m.runID, data)
+	if err != nil {
+		m.error(fmt.Errorf("sysmetrics: logging metrics: %w", err))
+		return
+	}
+	m.step++
+}
+
+func (m *Monitor) error(err error) {
+	if m.cfg.OnError != nil {
+		m.cfg.OnError(err)
+	}
+}
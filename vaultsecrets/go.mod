@@ -0,0 +1,5 @@
+module github.com/codeocean/go-mlflow/vaultsecrets
+
+go 1.19
+
+require github.com/codeocean/go-mlflow v0.0.0-20261016210201-e648cfd6b464
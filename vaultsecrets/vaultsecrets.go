@@ -0,0 +1,115 @@
+// Package vaultsecrets provides MLflow credentials stored in a HashiCorp Vault key/value
+// secrets engine, in the username and password or token keys of a secret.
+//
+//	client, err := mlflow.NewClient(nil, "https://mlflow.example.com",
+//		mlflow.WithCredentialsProvider(vaultsecrets.NewCredentialsProvider(&vaultsecrets.Config{
+//			Address: "https://vault.example.com:8200",
+//			Token:   os.Getenv("VAULT_TOKEN"),
+//			Path:    "mlflow/credentials",
+//		}, 5*time.Minute)))
+package vaultsecrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Config locates the secret holding the credentials.
+type Config struct {
+	// Address is the address of the Vault server.
+	Address string
+	// Token authenticates the requests to Vault.
+	Token string
+	// Namespace is the Vault Enterprise namespace of the secret, if any.
+	Namespace string
+	// Mount is the mount path of the key/value secrets engine, "secret" by default.
+	Mount string
+	// Path is the path of the secret in the engine.
+	Path string
+	// KVVersion is the version of the key/value secrets engine, 1 or 2, 2 by default.
+	KVVersion int
+	// HTTPClient sends the requests to Vault, http.DefaultClient by default.
+	HTTPClient *http.Client
+}
+
+// NewCredentialsProvider returns a provider of the credentials stored in the secret of cfg,
+// fetched again once they are older than ttl.
+func NewCredentialsProvider(cfg *Config, ttl time.Duration) mlflow.CredentialsProvider {
+	return mlflow.CachingCredentialsProvider(mlflow.CredentialsProviderFunc(func(ctx context.Context) (*mlflow.Credentials, error) {
+		return cfg.read(ctx)
+	}), ttl)
+}
+
+func (cfg *Config) url() string {
+	mount := strings.Trim(cfg.Mount, "/")
+	if mount == "" {
+		mount = "secret"
+	}
+
+	path := strings.Trim(cfg.Path, "/")
+	if cfg.KVVersion != 1 {
+		path = "data/" + path
+	}
+
+	return strings.TrimSuffix(cfg.Address, "/") + "/v1/" + mount + "/" + path
+}
+
+func (cfg *Config) read(ctx context.Context) (*mlflow.Credentials, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", cfg.url(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", cfg.Token)
+	if cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", cfg.Namespace)
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var v struct {
+		Errors []string        `json:"errors"`
+		Data   json.RawMessage `json:"data"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&v)
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("vaultsecrets: reading %s: status %d: %s", cfg.Path, res.StatusCode, strings.Join(v.Errors, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	secret := v.Data
+	if cfg.KVVersion != 1 {
+		// Version 2 engines nest the secret with its metadata.
+		var data struct {
+			Data json.RawMessage `json:"data"`
+		}
+		err = json.Unmarshal(v.Data, &data)
+		if err != nil {
+			return nil, err
+		}
+		secret = data.Data
+	}
+
+	credentials, err := mlflow.ParseCredentials(secret)
+	if err != nil {
+		return nil, fmt.Errorf("vaultsecrets: secret %s: %w", cfg.Path, err)
+	}
+
+	return credentials, nil
+}
@@ -0,0 +1,239 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// Handler handles events, such as by notifying a deployment system.
+type Handler interface {
+	Handle(ctx context.Context, e Event) error
+}
+
+// HandlerFunc is a Handler calling a function.
+type HandlerFunc func(ctx context.Context, e Event) error
+
+func (f HandlerFunc) Handle(ctx context.Context, e Event) error {
+	return f(ctx, e)
+}
+
+// Filter selects the events of a handler.
+type Filter func(e Event) bool
+
+// OfType selects the events of type T, such as *ModelVersionCreated.
+func OfType[T Event]() Filter {
+	return func(e Event) bool {
+		_, ok := e.(T)
+		return ok
+	}
+}
+
+// StageChangedTo selects the transitions of the versions of a registered model, of any model
+// if model is empty, to a stage.
+func StageChangedTo(model string, stage mlflow.ModelVersionStage) Filter {
+	return func(e Event) bool {
+		c, ok := e.(*ModelVersionStageChanged)
+		return ok && c.Version.CurrentStage == string(stage) && (model == "" || c.Version.Name == model)
+	}
+}
+
+// DeadLetter is an event a handler failed to handle.
+type DeadLetter struct {
+	Event    Event
+	Handler  Handler
+	Err      error
+	Attempts int
+}
+
+// DispatcherOptions configures a dispatcher.
+type DispatcherOptions struct {
+	// Retries is the number of times an event is handled again after the handler failed.
+	Retries int
+	// Backoff is the delay before the first retry, doubled at each retry, one second if zero.
+	Backoff time.Duration
+	// DeadLetter is called with the events handlers failed to handle after all the retries.
+	DeadLetter func(ctx context.Context, d *DeadLetter)
+}
+
+type route struct {
+	filter  Filter
+	handler Handler
+}
+
+// Dispatcher calls the handlers of events.
+//
+//	d := watch.NewDispatcher(&watch.DispatcherOptions{Retries: 3})
+//	d.Handle(watch.StageChangedTo("fraud", mlflow.ModelVersionStageProduction), watch.NewSlackHandler(nil, slackURL))
+//	err := d.Run(ctx, watch.Watch(ctx, client, nil))
+type Dispatcher struct {
+	opts   DispatcherOptions
+	routes []*route
+}
+
+func NewDispatcher(opts *DispatcherOptions) *Dispatcher {
+	d := &Dispatcher{}
+	if opts != nil {
+		d.opts = *opts
+	}
+	if d.opts.Backoff <= 0 {
+		d.opts.Backoff = time.Second
+	}
+	return d
+}
+
+// Handle registers a handler of the events selected by filter, all of them if filter is nil.
+// It must not be called concurrently with Dispatch.
+func (d *Dispatcher) Handle(filter Filter, h Handler) {
+	d.routes = append(d.routes, &route{filter: filter, handler: h})
+}
+
+// Dispatch calls the handlers of an event in turn, retrying the failed ones, until ctx is
+// done. The events handlers failed to handle are passed to DeadLetter.
+func (d *Dispatcher) Dispatch(ctx context.Context, e Event) error {
+	for _, r := range d.routes {
+		if r.filter != nil && !r.filter(e) {
+			continue
+		}
+
+		err := d.handle(ctx, r.handler, e)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && d.opts.DeadLetter != nil {
+			d.opts.DeadLetter(ctx, &DeadLetter{Event: e, Handler: r.handler, Err: err, Attempts: d.opts.Retries + 1})
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) handle(ctx context.Context, h Handler, e Event) error {
+	backoff := d.opts.Backoff
+	for attempt := 0; ; attempt++ {
+		err := h.Handle(ctx, e)
+		if err == nil || attempt == d.opts.Retries {
+			return err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// Run dispatches the events of a channel, such as the one of Watch, until it is closed or
+// ctx is done.
+func (d *Dispatcher) Run(ctx context.Context, events <-chan Event) error {
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			err := d.Dispatch(ctx, e)
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// EventType returns the name of the type of an event, such as "ModelVersionCreated".
+func EventType(e Event) string {
+	return reflect.TypeOf(e).Elem().Name()
+}
+
+// Describe returns a sentence describing an event, as posted to Slack.
+func Describe(e Event) string {
+	switch e := e.(type) {
+	case *ExperimentCreated:
+		return fmt.Sprintf("Experiment %s created", e.Experiment.Name)
+	case *ExperimentDeleted:
+		return fmt.Sprintf("Experiment %s deleted", e.Experiment.Name)
+	case *RunCreated:
+		return fmt.Sprintf("Run %s created in experiment %s", runName(e.Run), e.Run.Info.ExperimentID)
+	case *RunStatusChanged:
+		return fmt.Sprintf("Run %s of experiment %s changed from %s to %s", runName(e.Run), e.Run.Info.ExperimentID, e.From, e.Run.Info.Status)
+	case *RegisteredModelCreated:
+		return fmt.Sprintf("Registered model %s created", e.Model.Name)
+	case *ModelVersionCreated:
+		return fmt.Sprintf("Version %s of model %s created", e.Version.Version, e.Version.Name)
+	case *ModelVersionStatusChanged:
+		return fmt.Sprintf("Version %s of model %s changed from %s to %s", e.Version.Version, e.Version.Name, e.From, e.Version.Status)
+	case *ModelVersionStageChanged:
+		return fmt.Sprintf("Version %s of model %s transitioned from %s to %s", e.Version.Version, e.Version.Name, e.From, e.Version.CurrentStage)
+	case *AliasMoved:
+		switch {
+		case e.Version == "":
+			return fmt.Sprintf("Alias %s of model %s deleted from version %s", e.Alias, e.Model, e.From)
+		case e.From == "":
+			return fmt.Sprintf("Alias %s of model %s set to version %s", e.Alias, e.Model, e.Version)
+		default:
+			return fmt.Sprintf("Alias %s of model %s moved from version %s to %s", e.Alias, e.Model, e.From, e.Version)
+		}
+	}
+	return EventType(e)
+}
+
+func runName(r *mlflow.Run) string {
+	if r.Info.RunName != "" {
+		return r.Info.RunName
+	}
+	return r.Info.RunID
+}
+
+// NewWebhookHandler returns a handler posting the events to an HTTP endpoint, as JSON objects
+// with the type of the event, see EventType, and the event. Responses other than 2xx are
+// errors.
+func NewWebhookHandler(httpClient *http.Client, endpoint string) Handler {
+	return HandlerFunc(func(ctx context.Context, e Event) error {
+		return postJSON(ctx, httpClient, endpoint, map[string]any{"type": EventType(e), "event": e})
+	})
+}
+
+// NewSlackHandler returns a handler posting the events to a Slack incoming webhook, see
+// Describe.
+func NewSlackHandler(httpClient *http.Client, webhookURL string) Handler {
+	return HandlerFunc(func(ctx context.Context, e Event) error {
+		return postJSON(ctx, httpClient, webhookURL, map[string]any{"text": Describe(e)})
+	})
+}
+
+func postJSON(ctx context.Context, httpClient *http.Client, endpoint string, v any) error {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("content-type", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("watch: %s: %s", endpoint, res.Status)
+	}
+	return nil
+}
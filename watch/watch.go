@@ -0,0 +1,398 @@
+// Package watch polls a tracking server for changes to experiments, runs and registered
+// models and emits them as events, emulating webhooks on MLflow servers which have none.
+//
+//	for e := range watch.Watch(ctx, client, &watch.Config{Models: []string{"fraud"}}) {
+//		if e, ok := e.(*watch.ModelVersionStageChanged); ok && e.Version.CurrentStage == string(mlflow.ModelVersionStageProduction) {
+//			deploy(e.Version)
+//		}
+//	}
+package watch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/codeocean/go-mlflow/internal/filterstring"
+	"github.com/codeocean/go-mlflow/mlflow"
+)
+
+// DefaultInterval is the default interval between polls.
+const DefaultInterval = 30 * time.Second
+
+// Event is a change, one of the event types of this package.
+type Event interface {
+	event()
+}
+
+type ExperimentCreated struct {
+	Experiment *mlflow.Experiment `json:"experiment"`
+}
+
+// ExperimentDeleted holds the experiment as it was last seen.
+type ExperimentDeleted struct {
+	Experiment *mlflow.Experiment `json:"experiment"`
+}
+
+type RunCreated struct {
+	Run *mlflow.Run `json:"run"`
+}
+
+type RunStatusChanged struct {
+	Run  *mlflow.Run      `json:"run"`
+	From mlflow.RunStatus `json:"from"`
+}
+
+type RegisteredModelCreated struct {
+	Model *mlflow.RegisteredModel `json:"model"`
+}
+
+type ModelVersionCreated struct {
+	Version *mlflow.ModelVersion `json:"version"`
+}
+
+// ModelVersionStatusChanged is a change of the registration status of a model version, such
+// as to ready once its artifacts are copied.
+type ModelVersionStatusChanged struct {
+	Version *mlflow.ModelVersion      `json:"version"`
+	From    mlflow.ModelVersionStatus `json:"from"`
+}
+
+type ModelVersionStageChanged struct {
+	Version *mlflow.ModelVersion `json:"version"`
+	From    string               `json:"from"`
+}
+
+// AliasMoved is an alias of a registered model set to a version. From is the version it
+// pointed to before, empty for a new alias, and Version is empty for a deleted alias.
+type AliasMoved struct {
+	Model   string `json:"model"`
+	Alias   string `json:"alias"`
+	Version string `json:"version"`
+	From    string `json:"from"`
+}
+
+func (*ExperimentCreated) event()         {}
+func (*ExperimentDeleted) event()         {}
+func (*RunCreated) event()                {}
+func (*RunStatusChanged) event()          {}
+func (*RegisteredModelCreated) event()    {}
+func (*ModelVersionCreated) event()       {}
+func (*ModelVersionStatusChanged) event() {}
+func (*ModelVersionStageChanged) event()  {}
+func (*AliasMoved) event()                {}
+
+// Config configures a watcher.
+type Config struct {
+	// Interval is the interval between polls, DefaultInterval if zero.
+	Interval time.Duration
+	// ExperimentIDs are the experiments whose runs are watched, all the active experiments
+	// if empty.
+	ExperimentIDs []string
+	// Models are the names of the registered models watched, all of them if empty.
+	Models []string
+	// OnError is called with the errors of polls, which are retried at the next poll.
+	OnError func(err error)
+}
+
+// Watcher polls a tracking server for changes. The first poll records the state of the
+// server, changes are emitted from the second one on. Runs are found by their start time,
+// the runs created with a start time before the previous poll are missed.
+type Watcher struct {
+	client *mlflow.Client
+	cfg    Config
+
+	experiments map[string]*mlflow.Experiment
+	runs        map[string]*mlflow.RunInfo
+	// runsSince is the start time, in milliseconds since the epoch, of the runs searched.
+	runsSince int64
+	models    map[string]bool
+	versions  map[string]*mlflow.ModelVersion
+	aliases   map[string]map[string]string
+}
+
+func New(client *mlflow.Client, cfg *Config) *Watcher {
+	w := &Watcher{client: client}
+	if cfg != nil {
+		w.cfg = *cfg
+	}
+	if w.cfg.Interval <= 0 {
+		w.cfg.Interval = DefaultInterval
+	}
+	return w
+}
+
+// Watch polls the server for changes until ctx is done, sending them on the returned channel,
+// which is then closed.
+func Watch(ctx context.Context, client *mlflow.Client, cfg *Config) <-chan Event {
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		_ = New(client, cfg).Run(ctx, events)
+	}()
+	return events
+}
+
+// Run polls the server for changes until ctx is done, sending them on events.
+func (w *Watcher) Run(ctx context.Context, events chan<- Event) error {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		for _, e := range w.Poll(ctx) {
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Poll polls the server once and returns the changes since the previous poll.
+func (w *Watcher) Poll(ctx context.Context) []Event {
+	var events []Event
+	for _, poll := range []func(context.Context) ([]Event, error){w.pollExperiments, w.pollRuns, w.pollModels} {
+		e, err := poll(ctx)
+		if err != nil {
+			if w.cfg.OnError != nil && ctx.Err() == nil {
+				w.cfg.OnError(err)
+			}
+			continue
+		}
+		events = append(events, e...)
+	}
+	return events
+}
+
+func (w *Watcher) pollExperiments(ctx context.Context) ([]Event, error) {
+	experiments, err := w.client.Experiments.SearchAll(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("watch: experiments: %w", err)
+	}
+
+	seen := map[string]*mlflow.Experiment{}
+	var events []Event
+	for _, e := range experiments {
+		seen[e.ExperimentID] = e
+		if _, ok := w.experiments[e.ExperimentID]; !ok && w.experiments != nil {
+			events = append(events, &ExperimentCreated{Experiment: e})
+		}
+	}
+	for id, e := range w.experiments {
+		if _, ok := seen[id]; !ok {
+			events = append(events, &ExperimentDeleted{Experiment: e})
+		}
+	}
+	w.experiments = seen
+
+	return events, nil
+}
+
+func (w *Watcher) pollRuns(ctx context.Context) ([]Event, error) {
+	ids := w.cfg.ExperimentIDs
+	if len(ids) == 0 {
+		for id := range w.experiments {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	start := time.Now()
+
+	if w.runs == nil {
+		// Record the active runs, whatever their start time, to follow their status, and the
+		// runs the next poll searches.
+		var runs []*mlflow.Run
+		for _, status := range []mlflow.RunStatus{mlflow.RunStatusRunning, mlflow.RunStatusScheduled} {
+			r, err := w.searchRuns(ctx, ids, "attributes.status = '"+string(status)+"'")
+			if err != nil {
+				return nil, err
+			}
+			runs = append(runs, r...)
+		}
+
+		since := runsSince(start.Add(-w.cfg.Interval), runs)
+		recent, err := w.searchRuns(ctx, ids, fmt.Sprintf("attributes.start_time >= %d", since))
+		if err != nil {
+			return nil, err
+		}
+
+		w.runs = map[string]*mlflow.RunInfo{}
+		for _, r := range append(runs, recent...) {
+			w.runs[r.Info.RunID] = r.Info
+		}
+		w.runsSince = since
+
+		return nil, nil
+	}
+
+	runs, err := w.searchRuns(ctx, ids, fmt.Sprintf("attributes.start_time >= %d", w.runsSince))
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	seen := map[string]*mlflow.RunInfo{}
+	for _, r := range runs {
+		seen[r.Info.RunID] = r.Info
+
+		prev, ok := w.runs[r.Info.RunID]
+		switch {
+		case !ok:
+			events = append(events, &RunCreated{Run: r})
+		case prev.Status != r.Info.Status:
+			events = append(events, &RunStatusChanged{Run: r, From: prev.Status})
+		}
+	}
+	w.runs = seen
+	w.runsSince = runsSince(start.Add(-w.cfg.Interval), runs)
+
+	return events, nil
+}
+
+func (w *Watcher) searchRuns(ctx context.Context, ids []string, filter string) ([]*mlflow.Run, error) {
+	runs, err := w.client.Runs.SearchAll(ctx, &mlflow.RunSearchOptions{ExperimentIDs: ids, Filter: filter})
+	if err != nil {
+		return nil, fmt.Errorf("watch: runs: %w", err)
+	}
+	return runs, nil
+}
+
+// runsSince returns the start time, in milliseconds since the epoch, of the runs the next
+// poll searches: the runs started since t, the time of the poll with a margin for the clock
+// skew with the server, and the active runs.
+func runsSince(t time.Time, runs []*mlflow.Run) int64 {
+	since := t.UnixMilli()
+	for _, r := range runs {
+		active := r.Info.Status == mlflow.RunStatusRunning || r.Info.Status == mlflow.RunStatusScheduled
+		if active && r.Info.StartTime < since {
+			since = r.Info.StartTime
+		}
+	}
+	return since
+}
+
+func (w *Watcher) pollModels(ctx context.Context) ([]Event, error) {
+	var models []*mlflow.RegisteredModel
+	if len(w.cfg.Models) == 0 {
+		var err error
+		models, err = w.client.RegisteredModels.SearchAll(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("watch: registered models: %w", err)
+		}
+	} else {
+		for _, name := range w.cfg.Models {
+			m, err := w.client.RegisteredModels.Get(ctx, name)
+			if mlflow.IsResourceDoesNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("watch: registered model %s: %w", name, err)
+			}
+			models = append(models, m)
+		}
+	}
+
+	var versions []*mlflow.ModelVersion
+	if len(w.cfg.Models) == 0 {
+		var err error
+		versions, err = w.client.ModelVersions.SearchAll(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("watch: model versions: %w", err)
+		}
+	} else {
+		for _, m := range models {
+			v, err := w.client.ModelVersions.SearchAll(ctx, &mlflow.ModelVersionsSearchOptions{
+				Filter: "name = " + filterstring.Quote(m.Name),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("watch: model versions of %s: %w", m.Name, err)
+			}
+			versions = append(versions, v...)
+		}
+	}
+
+	initialized := w.models != nil
+	var events []Event
+
+	seenModels := map[string]bool{}
+	seenAliases := map[string]map[string]string{}
+	for _, m := range models {
+		seenModels[m.Name] = true
+		if initialized && !w.models[m.Name] {
+			events = append(events, &RegisteredModelCreated{Model: m})
+		}
+
+		aliases := map[string]string{}
+		for _, a := range m.Aliases {
+			aliases[a.Alias] = a.Version
+		}
+		seenAliases[m.Name] = aliases
+	}
+
+	seenVersions := map[string]*mlflow.ModelVersion{}
+	for _, v := range versions {
+		key := v.Name + "/" + v.Version
+		seenVersions[key] = v
+		if !initialized {
+			continue
+		}
+
+		prev, ok := w.versions[key]
+		if !ok {
+			events = append(events, &ModelVersionCreated{Version: v})
+			continue
+		}
+		if prev.Status != v.Status {
+			events = append(events, &ModelVersionStatusChanged{Version: v, From: prev.Status})
+		}
+		if prev.CurrentStage != v.CurrentStage {
+			events = append(events, &ModelVersionStageChanged{Version: v, From: prev.CurrentStage})
+		}
+	}
+
+	if initialized {
+		for _, m := range models {
+			events = append(events, aliasEvents(m.Name, w.aliases[m.Name], seenAliases[m.Name])...)
+		}
+	}
+
+	w.models = seenModels
+	w.versions = seenVersions
+	w.aliases = seenAliases
+
+	return events, nil
+}
+
+// aliasEvents returns the moves of the aliases of a registered model, sorted by alias.
+func aliasEvents(model string, prev, cur map[string]string) []Event {
+	var names []string
+	for alias := range prev {
+		names = append(names, alias)
+	}
+	for alias := range cur {
+		if _, ok := prev[alias]; !ok {
+			names = append(names, alias)
+		}
+	}
+	sort.Strings(names)
+
+	var events []Event
+	for _, alias := range names {
+		if prev[alias] != cur[alias] {
+			events = append(events, &AliasMoved{Model: model, Alias: alias, Version: cur[alias], From: prev[alias]})
+		}
+	}
+	return events
+}